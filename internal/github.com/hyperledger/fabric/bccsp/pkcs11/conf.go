@@ -101,6 +101,11 @@ type PKCS11Opts struct {
 	Pin        string `mapstructure:"pin" json:"pin"`
 	Sensitive  bool   `mapstructure:"sensitivekeys,omitempty" json:"sensitivekeys,omitempty"`
 	SoftVerify bool   `mapstructure:"softwareverify,omitempty" json:"softwareverify,omitempty"`
+
+	// SessionCacheSize is the number of idle sessions kept open for reuse (default 10).
+	// It doesn't limit the number of sessions in use: a session is opened whenever
+	// none is idle, and closed when it's returned while the cache is full.
+	SessionCacheSize int `mapstructure:"sessioncachesize,omitempty" json:"sessioncachesize,omitempty"`
 }
 
 // Since currently only ECDSA operations go to PKCS11, need a keystore still
@@ -27,6 +27,7 @@ import (
 	"crypto/x509"
 	"math/big"
 	"os"
+	"sync"
 
 	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp/sw"
@@ -70,8 +71,26 @@ func New(opts PKCS11Opts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
 			lib, label)
 	}
 
-	sessions := make(chan pkcs11.SessionHandle, sessionCacheSize)
-	csp := &impl{swCSP, conf, keyStore, ctx, sessions, slot, lib, opts.Sensitive, opts.SoftVerify}
+	cacheSize := opts.SessionCacheSize
+	if cacheSize <= 0 {
+		cacheSize = sessionCacheSize
+	}
+	sessions := make(chan pkcs11.SessionHandle, cacheSize)
+	csp := &impl{
+		BCCSP:        swCSP,
+		conf:         conf,
+		ks:           keyStore,
+		module:       ctxModule{ctx},
+		sessions:     sessions,
+		slot:         slot,
+		lib:          lib,
+		pin:          pin,
+		label:        label,
+		noPrivImport: opts.Sensitive,
+		softVerify:   opts.SoftVerify,
+		handleCache:  make(map[string]pkcs11.ObjectHandle),
+	}
+	csp.load = csp.loadModule
 	csp.returnSession(*session)
 	return csp, nil
 }
@@ -82,13 +101,23 @@ type impl struct {
 	conf *config
 	ks   bccsp.KeyStore
 
-	ctx      *pkcs11.Ctx
-	sessions chan pkcs11.SessionHandle
-	slot     uint
+	// ctxLock is held for writing while the library is reloaded
+	ctxLock    sync.RWMutex
+	module     p11Module
+	load       func() (p11Module, uint, *pkcs11.SessionHandle, error)
+	sessions   chan pkcs11.SessionHandle
+	slot       uint
+	generation uint64
 
 	lib          string
+	pin          string
+	label        string
 	noPrivImport bool
 	softVerify   bool
+
+	// handleCache holds object handles of keys by SKI
+	handleCache     map[string]pkcs11.ObjectHandle
+	handleCacheLock sync.RWMutex
 }
 
 // KeyGen generates a key using opts.
@@ -118,7 +147,7 @@ func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 	case *bccsp.ECDSAP384KeyGenOpts:
 		ski, pub, err := csp.generateECKey(oidNamedCurveP384, opts.Ephemeral())
 		if err != nil {
-			return nil, errors.Wrapf(err, "Failed generating ECDSA P384 key [%s]")
+			return nil, errors.Wrap(err, "Failed generating ECDSA P384 key")
 		}
 
 		k = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pub}}
@@ -354,7 +383,7 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 
 		lowLevelKey, err := utils.DERToPrivateKey(der)
 		if err != nil {
-			return nil, errors.Wrapf(err, "Failed converting PKIX to ECDSA public key [%s]")
+			return nil, errors.Wrap(err, "Failed converting PKIX to ECDSA public key")
 		}
 
 		ecdsaSK, ok := lowLevelKey.(*ecdsa.PrivateKey)
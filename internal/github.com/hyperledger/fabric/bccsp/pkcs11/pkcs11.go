@@ -22,6 +22,7 @@ import (
 
 	logging "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/sdkpatch/logbridge"
 	"github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
 )
 
 func loadLib(lib, pin, label string) (*pkcs11.Ctx, uint, *pkcs11.SessionHandle, error) {
@@ -85,7 +86,36 @@ func loadLib(lib, pin, label string) (*pkcs11.Ctx, uint, *pkcs11.SessionHandle,
 	return ctx, slot, &session, nil
 }
 
-func (csp *impl) getSession() (session pkcs11.SessionHandle) {
+// p11Module is a loaded PKCS11 library. Sessions are opened and closed through it,
+// so that the session cache and reconnection don't depend on a token being present.
+type p11Module interface {
+	// lib returns the library passed to the operations run with a session
+	lib() *pkcs11.Ctx
+	OpenSession(slotID uint, flags uint) (pkcs11.SessionHandle, error)
+	CloseSession(sh pkcs11.SessionHandle) error
+	Finalize() error
+	Destroy()
+}
+
+// ctxModule is a p11Module backed by a library loaded by loadLib
+type ctxModule struct {
+	*pkcs11.Ctx
+}
+
+func (m ctxModule) lib() *pkcs11.Ctx {
+	return m.Ctx
+}
+
+// loadModule loads the PKCS11 library and logs in to the token
+func (csp *impl) loadModule() (p11Module, uint, *pkcs11.SessionHandle, error) {
+	ctx, slot, session, err := loadLib(csp.lib, csp.pin, csp.label)
+	if err != nil {
+		return nil, slot, nil, err
+	}
+	return ctxModule{ctx}, slot, session, nil
+}
+
+func (csp *impl) getSession() (session pkcs11.SessionHandle, err error) {
 	select {
 	case session = <-csp.sessions:
 		logger.Debugf("Reusing existing pkcs11 session %+v on slot %d\n", session, csp.slot)
@@ -93,9 +123,8 @@ func (csp *impl) getSession() (session pkcs11.SessionHandle) {
 	default:
 		// cache is empty (or completely in use), create a new session
 		var s pkcs11.SessionHandle
-		var err error = nil
 		for i := 0; i < 10; i++ {
-			s, err = csp.ctx.OpenSession(csp.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+			s, err = csp.module.OpenSession(csp.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
 			if err != nil {
 				logger.Warningf("OpenSession failed, retrying [%s]\n", err)
 			} else {
@@ -103,12 +132,12 @@ func (csp *impl) getSession() (session pkcs11.SessionHandle) {
 			}
 		}
 		if err != nil {
-			panic(fmt.Errorf("OpenSession failed [%s]\n", err))
+			return 0, errors.Wrap(err, "OpenSession failed")
 		}
 		logger.Debugf("Created new pkcs11 session %+v on slot %d\n", s, csp.slot)
 		session = s
 	}
-	return session
+	return session, nil
 }
 
 func (csp *impl) returnSession(session pkcs11.SessionHandle) {
@@ -117,31 +146,168 @@ func (csp *impl) returnSession(session pkcs11.SessionHandle) {
 		// returned session back to session cache
 	default:
 		// have plenty of sessions in cache, dropping
-		csp.ctx.CloseSession(session)
+		csp.module.CloseSession(session)
 	}
 }
 
-// Look for an EC key by SKI, stored in CKA_ID
-// This function can probably be adapted for both EC and RSA keys.
-func (csp *impl) getECKey(ski []byte) (pubKey *ecdsa.PublicKey, isPriv bool, err error) {
-	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
-	isPriv = true
-	_, err = findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+// withSession calls f with a session from the session cache. If f fails because
+// the session, the key handles or the device were lost, the session is discarded,
+// the key handle cache purged and the library reloaded as needed, and f is retried once.
+func (csp *impl) withSession(f func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error) error {
+	for attempt := 0; ; attempt++ {
+		generation, err := csp.trySession(f)
+		if err == nil || attempt > 0 {
+			return err
+		}
+
+		switch code := p11ErrorCode(err); code {
+		case pkcs11.CKR_DEVICE_REMOVED, pkcs11.CKR_DEVICE_ERROR, pkcs11.CKR_TOKEN_NOT_PRESENT,
+			pkcs11.CKR_CRYPTOKI_NOT_INITIALIZED, pkcs11.CKR_USER_NOT_LOGGED_IN:
+			logger.Warningf("PKCS11 device lost [%s], reconnecting\n", err)
+			if rerr := csp.reconnect(generation); rerr != nil {
+				return errors.WithMessage(err, fmt.Sprintf("reconnect failed [%s]", rerr))
+			}
+		case pkcs11.CKR_SESSION_HANDLE_INVALID, pkcs11.CKR_SESSION_CLOSED,
+			pkcs11.CKR_OBJECT_HANDLE_INVALID, pkcs11.CKR_KEY_HANDLE_INVALID:
+			logger.Debugf("PKCS11 session or key handle lost [%s], retrying\n", err)
+			csp.purgeHandleCache()
+		default:
+			return err
+		}
+	}
+}
+
+func (csp *impl) trySession(f func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error) (uint64, error) {
+	csp.ctxLock.RLock()
+	defer csp.ctxLock.RUnlock()
+
+	session, err := csp.getSession()
 	if err != nil {
-		isPriv = false
-		logger.Debugf("Private key not found [%s] for SKI [%s], looking for Public key", err, hex.EncodeToString(ski))
+		return csp.generation, err
+	}
+
+	err = f(csp.module.lib(), session)
+	switch p11ErrorCode(err) {
+	case pkcs11.CKR_SESSION_HANDLE_INVALID, pkcs11.CKR_SESSION_CLOSED, pkcs11.CKR_DEVICE_REMOVED,
+		pkcs11.CKR_DEVICE_ERROR, pkcs11.CKR_TOKEN_NOT_PRESENT:
+		// the session can't be reused
+		csp.module.CloseSession(session)
+	default:
+		csp.returnSession(session)
+	}
+	return csp.generation, err
+}
+
+// reconnect reloads the PKCS11 library and logs in again, unless that was
+// already done since generation was observed
+func (csp *impl) reconnect(generation uint64) error {
+	csp.ctxLock.Lock()
+	defer csp.ctxLock.Unlock()
+
+	if csp.generation != generation {
+		return nil
+	}
+
+drain:
+	for {
+		select {
+		case session := <-csp.sessions:
+			csp.module.CloseSession(session)
+		default:
+			break drain
+		}
 	}
+	csp.module.Finalize()
+	csp.module.Destroy()
 
-	publicKey, err := findKeyPairFromSKI(p11lib, session, ski, publicKeyFlag)
+	module, slot, session, err := csp.load()
 	if err != nil {
-		return nil, false, fmt.Errorf("Public key not found [%s] for SKI [%s]", err, hex.EncodeToString(ski))
+		return err
 	}
+	csp.module = module
+	csp.slot = slot
+	csp.generation++
+	csp.purgeHandleCache()
+	csp.returnSession(*session)
 
-	ecpt, marshaledOid, err := ecPoint(p11lib, session, *publicKey)
+	logger.Infof("Reconnected to PKCS11 token %s\n", csp.label)
+	return nil
+}
+
+// findKeyPairFromSKI looks up the key handle in the key handle cache first
+func (csp *impl) findKeyPairFromSKI(mod *pkcs11.Ctx, session pkcs11.SessionHandle, ski []byte, keyType bool) (*pkcs11.ObjectHandle, error) {
+	cacheKey := handleCacheKey(ski, keyType)
+
+	csp.handleCacheLock.RLock()
+	handle, ok := csp.handleCache[cacheKey]
+	csp.handleCacheLock.RUnlock()
+	if ok {
+		return &handle, nil
+	}
+
+	h, err := findKeyPairFromSKI(mod, session, ski, keyType)
 	if err != nil {
-		return nil, false, fmt.Errorf("Public key not found [%s] for SKI [%s]", err, hex.EncodeToString(ski))
+		return nil, err
+	}
+	csp.cacheHandle(ski, keyType, *h)
+	return h, nil
+}
+
+func (csp *impl) cacheHandle(ski []byte, keyType bool, handle pkcs11.ObjectHandle) {
+	csp.handleCacheLock.Lock()
+	csp.handleCache[handleCacheKey(ski, keyType)] = handle
+	csp.handleCacheLock.Unlock()
+}
+
+func (csp *impl) purgeHandleCache() {
+	csp.handleCacheLock.Lock()
+	csp.handleCache = make(map[string]pkcs11.ObjectHandle)
+	csp.handleCacheLock.Unlock()
+}
+
+func handleCacheKey(ski []byte, keyType bool) string {
+	if keyType == privateKeyFlag {
+		return "prv:" + hex.EncodeToString(ski)
+	}
+	return "pub:" + hex.EncodeToString(ski)
+}
+
+// p11ErrorCode returns the PKCS11 return value causing err, or CKR_OK
+func p11ErrorCode(err error) uint {
+	if p11Err, ok := errors.Cause(err).(pkcs11.Error); ok {
+		return uint(p11Err)
+	}
+	return pkcs11.CKR_OK
+}
+
+// Look for an EC key by SKI, stored in CKA_ID
+// This function can probably be adapted for both EC and RSA keys.
+func (csp *impl) getECKey(ski []byte) (pubKey *ecdsa.PublicKey, isPriv bool, err error) {
+	var ecpt, marshaledOid []byte
+	err = csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		isPriv = true
+		_, err := csp.findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+		if err != nil {
+			if p11ErrorCode(err) != pkcs11.CKR_OK {
+				return err
+			}
+			isPriv = false
+			logger.Debugf("Private key not found [%s] for SKI [%s], looking for Public key", err, hex.EncodeToString(ski))
+		}
+
+		publicKey, err := csp.findKeyPairFromSKI(p11lib, session, ski, publicKeyFlag)
+		if err != nil {
+			return errors.Wrapf(err, "Public key not found for SKI [%s]", hex.EncodeToString(ski))
+		}
+
+		ecpt, marshaledOid, err = ecPoint(p11lib, session, *publicKey)
+		if err != nil {
+			return errors.Wrapf(err, "Public key not found for SKI [%s]", hex.EncodeToString(ski))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
 	}
 
 	curveOid := new(asn1.ObjectIdentifier)
@@ -215,10 +381,15 @@ func oidFromNamedCurve(curve elliptic.Curve) (asn1.ObjectIdentifier, bool) {
 }
 
 func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
-	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
+	err = csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		var err error
+		ski, pubKey, err = csp.generateECKeyWithSession(p11lib, session, curve, ephemeral)
+		return err
+	})
+	return ski, pubKey, err
+}
 
+func (csp *impl) generateECKeyWithSession(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, curve asn1.ObjectIdentifier, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
 	id := nextIDCtr()
 	publabel := fmt.Sprintf("BCPUB%s", id.Text(16))
 	prvlabel := fmt.Sprintf("BCPRV%s", id.Text(16))
@@ -258,7 +429,7 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 		pubkey_t, prvkey_t)
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("P11: keypair generate failed [%s]\n", err)
+		return nil, nil, errors.Wrap(err, "P11: keypair generate failed")
 	}
 
 	ecpt, _, _ := ecPoint(p11lib, session, pub)
@@ -274,13 +445,15 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 	logger.Infof("Generated new P11 key, SKI %x\n", ski)
 	err = p11lib.SetAttributeValue(session, pub, setski_t)
 	if err != nil {
-		return nil, nil, fmt.Errorf("P11: set-ID-to-SKI[public] failed [%s]\n", err)
+		return nil, nil, errors.Wrap(err, "P11: set-ID-to-SKI[public] failed")
 	}
 
 	err = p11lib.SetAttributeValue(session, prv, setski_t)
 	if err != nil {
-		return nil, nil, fmt.Errorf("P11: set-ID-to-SKI[private] failed [%s]\n", err)
+		return nil, nil, errors.Wrap(err, "P11: set-ID-to-SKI[private] failed")
 	}
+	csp.cacheHandle(ski, publicKeyFlag, pub)
+	csp.cacheHandle(ski, privateKeyFlag, prv)
 
 	nistCurve := namedCurveFromOID(curve)
 	if curve == nil {
@@ -302,25 +475,26 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 }
 
 func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error) {
-	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
-
-	privateKey, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
-	if err != nil {
-		return nil, nil, fmt.Errorf("Private key not found [%s]\n", err)
-	}
-
-	err = p11lib.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, *privateKey)
-	if err != nil {
-		return nil, nil, fmt.Errorf("Sign-initialize  failed [%s]\n", err)
-	}
-
 	var sig []byte
+	err = csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		privateKey, err := csp.findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+		if err != nil {
+			return errors.Wrap(err, "Private key not found")
+		}
+
+		err = p11lib.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, *privateKey)
+		if err != nil {
+			return errors.Wrap(err, "Sign-initialize  failed")
+		}
 
-	sig, err = p11lib.Sign(session, msg)
+		sig, err = p11lib.Sign(session, msg)
+		if err != nil {
+			return errors.Wrap(err, "P11: sign failed")
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("P11: sign failed [%s]\n", err)
+		return nil, nil, err
 	}
 
 	R = new(big.Int)
@@ -332,17 +506,8 @@ func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error)
 }
 
 func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize int) (valid bool, err error) {
-	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
-
 	logger.Debugf("Verify ECDSA\n")
 
-	publicKey, err := findKeyPairFromSKI(p11lib, session, ski, publicKeyFlag)
-	if err != nil {
-		return false, fmt.Errorf("Public key not found [%s]\n", err)
-	}
-
 	r := R.Bytes()
 	s := S.Bytes()
 
@@ -351,27 +516,51 @@ func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize
 	copy(sig[byteSize-len(r):byteSize], r)
 	copy(sig[2*byteSize-len(s):], s)
 
-	err = p11lib.VerifyInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)},
-		*publicKey)
-	if err != nil {
-		return false, fmt.Errorf("PKCS11: Verify-initialize [%s]\n", err)
-	}
-	err = p11lib.Verify(session, msg, sig)
-	if err == pkcs11.Error(pkcs11.CKR_SIGNATURE_INVALID) {
-		return false, nil
-	}
+	err = csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		publicKey, err := csp.findKeyPairFromSKI(p11lib, session, ski, publicKeyFlag)
+		if err != nil {
+			return errors.Wrap(err, "Public key not found")
+		}
+
+		err = p11lib.VerifyInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)},
+			*publicKey)
+		if err != nil {
+			return errors.Wrap(err, "PKCS11: Verify-initialize")
+		}
+		err = p11lib.Verify(session, msg, sig)
+		if err == pkcs11.Error(pkcs11.CKR_SIGNATURE_INVALID) {
+			valid = false
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "PKCS11: Verify failed")
+		}
+		valid = true
+		return nil
+	})
 	if err != nil {
-		return false, fmt.Errorf("PKCS11: Verify failed [%s]\n", err)
+		return false, err
 	}
-
-	return true, nil
+	return valid, nil
 }
 
 func (csp *impl) importECKey(curve asn1.ObjectIdentifier, privKey, ecPt []byte, ephemeral bool, keyType bool) (ski []byte, err error) {
-	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
+	if keyType == privateKeyFlag {
+		ski, err = csp.importECKey(curve, nil, ecPt, ephemeral, publicKeyFlag)
+		if err != nil {
+			return nil, fmt.Errorf("Failed importing private EC Key [%s]\n", err)
+		}
+	}
+
+	err = csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		var err error
+		ski, err = csp.importECKeyWithSession(p11lib, session, curve, privKey, ecPt, ski, ephemeral, keyType)
+		return err
+	})
+	return ski, err
+}
 
+func (csp *impl) importECKeyWithSession(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, curve asn1.ObjectIdentifier, privKey, ecPt, pubSKI []byte, ephemeral bool, keyType bool) (ski []byte, err error) {
 	marshaledOID, err := asn1.Marshal(curve)
 	if err != nil {
 		return nil, fmt.Errorf("Could not marshal OID [%s]", err.Error())
@@ -402,10 +591,7 @@ func (csp *impl) importECKey(curve asn1.ObjectIdentifier, privKey, ecPt []byte,
 			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, false),
 		}
 	} else { // isPrivateKey
-		ski, err = csp.importECKey(curve, nil, ecPt, ephemeral, publicKeyFlag)
-		if err != nil {
-			return nil, fmt.Errorf("Failed importing private EC Key [%s]\n", err)
-		}
+		ski = pubSKI
 
 		logger.Debugf("Importing Private EC Key [%d]\n%s\n", len(privKey)*8, hex.Dump(privKey))
 		prvlabel := hex.EncodeToString(ski)
@@ -427,8 +613,9 @@ func (csp *impl) importECKey(curve asn1.ObjectIdentifier, privKey, ecPt []byte,
 
 	keyHandle, err := p11lib.CreateObject(session, keyTemplate)
 	if err != nil {
-		return nil, fmt.Errorf("P11: keypair generate failed [%s]\n", err)
+		return nil, errors.Wrap(err, "P11: keypair generate failed")
 	}
+	csp.cacheHandle(ski, keyType, keyHandle)
 
 	if logger.IsEnabledFor(logging.DEBUG) {
 		listAttrs(p11lib, session, keyHandle)
@@ -581,30 +768,40 @@ func listAttrs(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, obj pkcs11.Obje
 }
 
 func (csp *impl) getSecretValue(ski []byte) []byte {
-	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
+	var value []byte
+	err := csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		keyHandle, err := csp.findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+		if err != nil {
+			return err
+		}
 
-	keyHandle, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+		var privKey []byte
+		template := []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_VALUE, privKey),
+		}
 
-	var privKey []byte
-	template := []*pkcs11.Attribute{
-		pkcs11.NewAttribute(pkcs11.CKA_VALUE, privKey),
-	}
+		// certain errors are tolerated, if value is missing
+		attr, err := p11lib.GetAttributeValue(session, *keyHandle, template)
+		if err != nil {
+			logger.Warningf("P11: get(attrlist) [%s]\n", err)
+		}
 
-	// certain errors are tolerated, if value is missing
-	attr, err := p11lib.GetAttributeValue(session, *keyHandle, template)
+		for _, a := range attr {
+			// Would be friendlier if the bindings provided a way convert Attribute hex to string
+			logger.Debugf("ListAttr: type %d/0x%x, length %d\n%s", a.Type, a.Type, len(a.Value), hex.Dump(a.Value))
+			value = a.Value
+			return nil
+		}
+		return nil
+	})
 	if err != nil {
-		logger.Warningf("P11: get(attrlist) [%s]\n", err)
+		logger.Warningf("P11: secret key not found [%s]\n", err)
+		return nil
 	}
-
-	for _, a := range attr {
-		// Would be friendlier if the bindings provided a way convert Attribute hex to string
-		logger.Debugf("ListAttr: type %d/0x%x, length %d\n%s", a.Type, a.Type, len(a.Value), hex.Dump(a.Value))
-		return a.Value
+	if value == nil {
+		logger.Warningf("No Key Value found!")
 	}
-	logger.Warningf("No Key Value found!", err)
-	return nil
+	return value
 }
 
 var (
@@ -0,0 +1,304 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+
+package pkcs11
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
+)
+
+var testSKI = []byte{1, 2, 3, 4}
+
+func TestWithSessionReconnectsAfterTokenError(t *testing.T) {
+	module := newMockModule(100)
+	reloaded := newMockModule(200)
+	csp := newTestImpl(module, reloaded)
+	csp.cacheHandle(testSKI, privateKeyFlag, 42)
+
+	var sessions []pkcs11.SessionHandle
+	err := csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		sessions = append(sessions, session)
+		if len(sessions) == 1 {
+			return pkcs11.Error(pkcs11.CKR_DEVICE_REMOVED)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withSession failed: %s", err)
+	}
+
+	if len(sessions) != 2 {
+		t.Fatalf("Expected the operation to be retried once, got %d attempts", len(sessions))
+	}
+	if sessions[0] != 101 || sessions[1] != 201 {
+		t.Fatalf("Expected the retry to use a session of the reloaded library, got sessions %v", sessions)
+	}
+	if csp.generation != 1 || csp.module != reloaded {
+		t.Fatalf("Expected the library to be reloaded")
+	}
+	if !module.finalized || module.openSessions() != 0 {
+		t.Fatalf("Expected the sessions of the lost library to be closed and the library finalized")
+	}
+	if len(csp.handleCache) != 0 {
+		t.Fatalf("Expected the key handle cache to be purged on reconnect")
+	}
+	if len(csp.sessions) != 1 || reloaded.openSessions() != 1 {
+		t.Fatalf("Expected the session of the reloaded library to be returned to the cache")
+	}
+}
+
+func TestWithSessionReconnectFailure(t *testing.T) {
+	csp := newTestImpl(newMockModule(100), nil)
+
+	attempts := 0
+	err := csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		attempts++
+		return pkcs11.Error(pkcs11.CKR_TOKEN_NOT_PRESENT)
+	})
+	if err == nil || !strings.Contains(err.Error(), "reconnect failed") {
+		t.Fatalf("Expected reconnect failure, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("Expected no retry after a failed reconnect, got %d attempts", attempts)
+	}
+}
+
+func TestWithSessionRetriesOnce(t *testing.T) {
+	csp := newTestImpl(newMockModule(100), newMockModule(200))
+
+	attempts := 0
+	err := csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		attempts++
+		return pkcs11.Error(pkcs11.CKR_DEVICE_ERROR)
+	})
+	if p11ErrorCode(err) != pkcs11.CKR_DEVICE_ERROR {
+		t.Fatalf("Expected the device error to be returned, got %v", err)
+	}
+	if attempts != 2 || csp.generation != 1 {
+		t.Fatalf("Expected one reconnect and one retry, got %d attempts and generation %d", attempts, csp.generation)
+	}
+}
+
+func TestWithSessionInvalidKeyHandle(t *testing.T) {
+	module := newMockModule(100)
+	csp := newTestImpl(module, nil)
+	csp.cacheHandle(testSKI, privateKeyFlag, 42)
+
+	var sessions []pkcs11.SessionHandle
+	err := csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		sessions = append(sessions, session)
+		if len(sessions) == 1 {
+			return pkcs11.Error(pkcs11.CKR_KEY_HANDLE_INVALID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withSession failed: %s", err)
+	}
+
+	if len(csp.handleCache) != 0 {
+		t.Fatalf("Expected the key handle cache to be purged")
+	}
+	if csp.generation != 0 {
+		t.Fatalf("Expected no reconnect for an invalid key handle")
+	}
+	if len(sessions) != 2 || sessions[0] != sessions[1] {
+		t.Fatalf("Expected the retry to reuse the session, got sessions %v", sessions)
+	}
+}
+
+func TestWithSessionClosedSession(t *testing.T) {
+	module := newMockModule(100)
+	csp := newTestImpl(module, nil)
+	csp.cacheHandle(testSKI, privateKeyFlag, 42)
+
+	var sessions []pkcs11.SessionHandle
+	err := csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		sessions = append(sessions, session)
+		if len(sessions) == 1 {
+			return pkcs11.Error(pkcs11.CKR_SESSION_HANDLE_INVALID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withSession failed: %s", err)
+	}
+
+	if len(sessions) != 2 || sessions[0] == sessions[1] {
+		t.Fatalf("Expected the retry to use a new session, got sessions %v", sessions)
+	}
+	if module.isOpen(sessions[0]) {
+		t.Fatalf("Expected the invalid session to be closed")
+	}
+	if len(csp.handleCache) != 0 {
+		t.Fatalf("Expected the key handle cache to be purged")
+	}
+}
+
+func TestWithSessionOtherError(t *testing.T) {
+	module := newMockModule(100)
+	csp := newTestImpl(module, nil)
+	csp.cacheHandle(testSKI, privateKeyFlag, 42)
+
+	attempts := 0
+	err := csp.withSession(func(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+		attempts++
+		return errors.WithMessage(pkcs11.Error(pkcs11.CKR_FUNCTION_FAILED), "sign failed")
+	})
+	if p11ErrorCode(err) != pkcs11.CKR_FUNCTION_FAILED {
+		t.Fatalf("Expected the error to be returned, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("Expected no retry, got %d attempts", attempts)
+	}
+	if len(csp.handleCache) != 1 {
+		t.Fatalf("Expected the key handle cache to be kept")
+	}
+	if len(csp.sessions) != 1 {
+		t.Fatalf("Expected the session to be returned to the cache")
+	}
+}
+
+func TestReconnectOnce(t *testing.T) {
+	loads := 0
+	csp := newTestImpl(newMockModule(100), nil)
+	csp.load = func() (p11Module, uint, *pkcs11.SessionHandle, error) {
+		loads++
+		module := newMockModule(200)
+		session, _ := module.OpenSession(0, 0)
+		return module, 0, &session, nil
+	}
+
+	// Operations that failed on the same library only reload it once
+	if err := csp.reconnect(0); err != nil {
+		t.Fatalf("reconnect failed: %s", err)
+	}
+	if err := csp.reconnect(0); err != nil {
+		t.Fatalf("reconnect failed: %s", err)
+	}
+	if loads != 1 || csp.generation != 1 {
+		t.Fatalf("Expected the library to be reloaded once, got %d loads", loads)
+	}
+}
+
+func TestSessionCacheSize(t *testing.T) {
+	module := newMockModule(100)
+	csp := newTestImpl(module, nil)
+
+	var sessions []pkcs11.SessionHandle
+	for i := 0; i < 3; i++ {
+		session, err := csp.getSession()
+		if err != nil {
+			t.Fatalf("getSession failed: %s", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if module.openSessions() != 3 {
+		t.Fatalf("Expected sessions to be opened beyond the cache size, got %d", module.openSessions())
+	}
+
+	for _, session := range sessions {
+		csp.returnSession(session)
+	}
+	if len(csp.sessions) != cap(csp.sessions) || module.openSessions() != cap(csp.sessions) {
+		t.Fatalf("Expected only %d idle sessions to be kept open, got %d", cap(csp.sessions), module.openSessions())
+	}
+}
+
+func TestHandleCache(t *testing.T) {
+	csp := newTestImpl(newMockModule(100), nil)
+
+	csp.cacheHandle(testSKI, privateKeyFlag, 42)
+	csp.cacheHandle(testSKI, publicKeyFlag, 43)
+
+	handle, err := csp.findKeyPairFromSKI(nil, 0, testSKI, privateKeyFlag)
+	if err != nil || *handle != 42 {
+		t.Fatalf("Expected cached private key handle, got %v, %v", handle, err)
+	}
+	handle, err = csp.findKeyPairFromSKI(nil, 0, testSKI, publicKeyFlag)
+	if err != nil || *handle != 43 {
+		t.Fatalf("Expected cached public key handle, got %v, %v", handle, err)
+	}
+
+	csp.purgeHandleCache()
+	if len(csp.handleCache) != 0 {
+		t.Fatalf("Expected the key handle cache to be empty after purge")
+	}
+}
+
+// newTestImpl returns an impl using module, with a session cache of two sessions.
+// The library is reloaded as reloaded, or fails to load if reloaded is nil.
+func newTestImpl(module *mockModule, reloaded *mockModule) *impl {
+	csp := &impl{
+		module:      module,
+		sessions:    make(chan pkcs11.SessionHandle, 2),
+		label:       "test",
+		handleCache: make(map[string]pkcs11.ObjectHandle),
+	}
+	csp.load = func() (p11Module, uint, *pkcs11.SessionHandle, error) {
+		if reloaded == nil {
+			return nil, 0, nil, errors.New("token not present")
+		}
+		session, err := reloaded.OpenSession(0, 0)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		return reloaded, 0, &session, nil
+	}
+	return csp
+}
+
+// mockModule is a p11Module tracking its open sessions
+type mockModule struct {
+	next      pkcs11.SessionHandle
+	open      map[pkcs11.SessionHandle]bool
+	finalized bool
+}
+
+func newMockModule(firstSession pkcs11.SessionHandle) *mockModule {
+	return &mockModule{next: firstSession, open: make(map[pkcs11.SessionHandle]bool)}
+}
+
+func (m *mockModule) lib() *pkcs11.Ctx {
+	return nil
+}
+
+func (m *mockModule) OpenSession(slotID uint, flags uint) (pkcs11.SessionHandle, error) {
+	if m.finalized {
+		return 0, pkcs11.Error(pkcs11.CKR_CRYPTOKI_NOT_INITIALIZED)
+	}
+	m.next++
+	m.open[m.next] = true
+	return m.next, nil
+}
+
+func (m *mockModule) CloseSession(sh pkcs11.SessionHandle) error {
+	delete(m.open, sh)
+	return nil
+}
+
+func (m *mockModule) Finalize() error {
+	m.finalized = true
+	return nil
+}
+
+func (m *mockModule) Destroy() {}
+
+func (m *mockModule) isOpen(sh pkcs11.SessionHandle) bool {
+	return m.open[sh]
+}
+
+func (m *mockModule) openSessions() int {
+	return len(m.open)
+}
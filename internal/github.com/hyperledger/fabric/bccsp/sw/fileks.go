@@ -226,6 +226,30 @@ func (ks *fileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 	return
 }
 
+// DeleteKey removes the keys whose SKI is the one passed from this KeyStore.
+// Deleting a key that isn't in the KeyStore isn't an error.
+// If this KeyStore is read only then the method will fail.
+func (ks *fileBasedKeyStore) DeleteKey(ski []byte) error {
+	if ks.readOnly {
+		return errors.New("Read only KeyStore.")
+	}
+	if len(ski) == 0 {
+		return errors.New("Invalid SKI. Cannot be of zero length.")
+	}
+	alias := hex.EncodeToString(ski)
+
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	for _, suffix := range []string{"sk", "pk", "key"} {
+		err := os.Remove(ks.getPathForAlias(alias, suffix))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Failed deleting key [%s] [%s]", alias, err)
+		}
+	}
+	return nil
+}
+
 func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err error) {
 
 	files, _ := ioutil.ReadDir(ks.path)
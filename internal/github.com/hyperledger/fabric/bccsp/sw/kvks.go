@@ -0,0 +1,191 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+package sw
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp/utils"
+)
+
+// KVStore is the key/value storage used by a KV-based KeyStore.
+// Keys are strings and values are PEM encoded keys ([]byte).
+type KVStore interface {
+	Store(key interface{}, value interface{}) error
+	Load(key interface{}) (interface{}, error)
+	Delete(key interface{}) error
+}
+
+// NewKVBasedKeyStore instantiates a key store that keeps keys in a key/value store.
+// Keys are stored under the same names as in a file-based key store
+// (<hex SKI>_sk, <hex SKI>_pk and <hex SKI>_key), so that a file key/value
+// store rooted at a keystore directory is interchangeable with it.
+// The key store can be encrypted if a non-empty password is specified.
+// It can be also be set as read only. In this case, any store operation
+// will be forbidden
+func NewKVBasedKeyStore(pwd []byte, store KVStore, readOnly bool) (bccsp.KeyStore, error) {
+	if store == nil {
+		return nil, errors.New("An invalid KVStore provided. It must be different from nil.")
+	}
+	return &kvBasedKeyStore{store: store, pwd: utils.Clone(pwd), readOnly: readOnly}, nil
+}
+
+// kvBasedKeyStore is a KeyStore backed by a key/value store
+type kvBasedKeyStore struct {
+	store    KVStore
+	pwd      []byte
+	readOnly bool
+
+	m sync.Mutex
+}
+
+// ReadOnly returns true if this KeyStore is read only, false otherwise.
+// If ReadOnly is true then StoreKey will fail.
+func (ks *kvBasedKeyStore) ReadOnly() bool {
+	return ks.readOnly
+}
+
+// GetKey returns a key object whose SKI is the one passed.
+func (ks *kvBasedKeyStore) GetKey(ski []byte) (bccsp.Key, error) {
+	if len(ski) == 0 {
+		return nil, errors.New("Invalid SKI. Cannot be of zero length.")
+	}
+	alias := hex.EncodeToString(ski)
+
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	if raw, err := ks.load(alias, "sk"); err == nil {
+		key, err := utils.PEMtoPrivateKey(raw, ks.pwd)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading secret key [%x] [%s]", ski, err)
+		}
+		switch k := key.(type) {
+		case *ecdsa.PrivateKey:
+			return &ecdsaPrivateKey{k}, nil
+		case *rsa.PrivateKey:
+			return &rsaPrivateKey{k}, nil
+		default:
+			return nil, errors.New("Secret key type not recognized")
+		}
+	}
+
+	if raw, err := ks.load(alias, "pk"); err == nil {
+		key, err := utils.PEMtoPublicKey(raw, ks.pwd)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading public key [%x] [%s]", ski, err)
+		}
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			return &ecdsaPublicKey{k}, nil
+		case *rsa.PublicKey:
+			return &rsaPublicKey{k}, nil
+		default:
+			return nil, errors.New("Public key type not recognized")
+		}
+	}
+
+	if raw, err := ks.load(alias, "key"); err == nil {
+		key, err := utils.PEMtoAES(raw, ks.pwd)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading key [%x] [%s]", ski, err)
+		}
+		return &aesPrivateKey{key, false}, nil
+	}
+
+	return nil, fmt.Errorf("Key with SKI %s not found", alias)
+}
+
+// StoreKey stores the key k in this KeyStore.
+// If this KeyStore is read only then the method will fail.
+func (ks *kvBasedKeyStore) StoreKey(k bccsp.Key) error {
+	if ks.readOnly {
+		return errors.New("Read only KeyStore.")
+	}
+	if k == nil {
+		return errors.New("Invalid key. It must be different from nil.")
+	}
+	alias := hex.EncodeToString(k.SKI())
+
+	var raw []byte
+	var suffix string
+	var err error
+	switch kk := k.(type) {
+	case *ecdsaPrivateKey:
+		raw, err = utils.PrivateKeyToPEM(kk.privKey, ks.pwd)
+		suffix = "sk"
+	case *ecdsaPublicKey:
+		raw, err = utils.PublicKeyToPEM(kk.pubKey, ks.pwd)
+		suffix = "pk"
+	case *rsaPrivateKey:
+		raw, err = utils.PrivateKeyToPEM(kk.privKey, ks.pwd)
+		suffix = "sk"
+	case *rsaPublicKey:
+		raw, err = utils.PublicKeyToPEM(kk.pubKey, ks.pwd)
+		suffix = "pk"
+	case *aesPrivateKey:
+		raw, err = utils.AEStoEncryptedPEM(kk.privKey, ks.pwd)
+		suffix = "key"
+	default:
+		return fmt.Errorf("Key type not reconigned [%s]", k)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed converting key [%s] to PEM [%s]", alias, err)
+	}
+
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	if err := ks.store.Store(alias+"_"+suffix, raw); err != nil {
+		return fmt.Errorf("Failed storing key [%s] [%s]", alias, err)
+	}
+	return nil
+}
+
+// DeleteKey removes the keys whose SKI is the one passed from this KeyStore.
+// Deleting a key that isn't in the KeyStore isn't an error.
+// If this KeyStore is read only then the method will fail.
+func (ks *kvBasedKeyStore) DeleteKey(ski []byte) error {
+	if ks.readOnly {
+		return errors.New("Read only KeyStore.")
+	}
+	if len(ski) == 0 {
+		return errors.New("Invalid SKI. Cannot be of zero length.")
+	}
+	alias := hex.EncodeToString(ski)
+
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	for _, suffix := range []string{"sk", "pk", "key"} {
+		if err := ks.store.Delete(alias + "_" + suffix); err != nil {
+			return fmt.Errorf("Failed deleting key [%s] [%s]", alias, err)
+		}
+	}
+	return nil
+}
+
+func (ks *kvBasedKeyStore) load(alias, suffix string) ([]byte, error) {
+	value, err := ks.store.Load(alias + "_" + suffix)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("Unexpected value type [%T] for key [%s]", value, alias)
+	}
+	return raw, nil
+}
@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	reqContext "context"
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/retry"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 )
 
 // CCEvent contains the data for a chaincocde event
@@ -32,6 +34,15 @@ type opts struct {
 	ProposalProcessors []fab.ProposalProcessor // targets
 	Timeout            time.Duration
 	Retry              retry.Opts
+	ParentContext      reqContext.Context
+	// WaitForCommit, if true, waits until the transaction is committed on the
+	// WaitForCommitTargets (or the endorsers, if none) before returning
+	WaitForCommit        bool
+	WaitForCommitTargets []fab.ProposalProcessor
+	// CommitConfirmations is the number of peers that must confirm the commit of the transaction
+	CommitConfirmations int
+	// CommitConfirmationPerOrg, if true, requires a commit confirmation from a peer of each endorsing org
+	CommitConfirmationPerOrg bool
 }
 
 //Option func for each Opts argument
@@ -52,6 +63,9 @@ type Response struct {
 	TxValidationCode pb.TxValidationCode
 	Proposal         *fab.TransactionProposal
 	Responses        []*fab.TransactionProposalResponse
+	// PeerValidationCodes holds the validation code reported by each peer (by URL)
+	// that confirmed the commit of the transaction
+	PeerValidationCodes map[string]pb.TxValidationCode
 }
 
 //WithTimeout encapsulates time.Duration to Option
@@ -70,6 +84,15 @@ func WithProposalProcessor(proposalProcessors ...fab.ProposalProcessor) Option {
 	}
 }
 
+// WithParentContext sets the context of the caller. Spans recorded for the
+// request are children of the span carried by ctx.
+func WithParentContext(ctx reqContext.Context) Option {
+	return func(o *opts) error {
+		o.ParentContext = ctx
+		return nil
+	}
+}
+
 // WithRetry option to configure retries
 func WithRetry(retryOpt retry.Opts) Option {
 	return func(o *opts) error {
@@ -77,3 +100,36 @@ func WithRetry(retryOpt retry.Opts) Option {
 		return nil
 	}
 }
+
+// WithWaitForCommit option causes Execute to wait, after the transaction has been committed, until
+// each of the given targets has committed the transaction, so that subsequent queries to those targets
+// see its writes. If no targets are given then the endorsers of the transaction are used.
+func WithWaitForCommit(targets ...fab.ProposalProcessor) Option {
+	return func(o *opts) error {
+		o.WaitForCommit = true
+		o.WaitForCommitTargets = targets
+		return nil
+	}
+}
+
+// WithCommitConfirmations option causes Execute to wait until the given number of peers have
+// committed the transaction, and to fail if any of them report a validation code other than
+// the one received in the commit event. The endorsers are queried first, then the channel's other peers.
+func WithCommitConfirmations(n int) Option {
+	return func(o *opts) error {
+		if n < 0 {
+			return errors.New("number of commit confirmations must not be negative")
+		}
+		o.CommitConfirmations = n
+		return nil
+	}
+}
+
+// WithCommitConfirmationPerOrg option causes Execute to wait until at least one peer of each
+// endorsing org has committed the transaction. It may be combined with WithCommitConfirmations.
+func WithCommitConfirmationPerOrg() Option {
+	return func(o *opts) error {
+		o.CommitConfirmationPerOrg = true
+		return nil
+	}
+}
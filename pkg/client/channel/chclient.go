@@ -8,7 +8,9 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	reqContext "context"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
@@ -21,11 +23,15 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/multi"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/retry"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics"
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/chclient")
 
 const (
 	defaultHandlerTimeout = time.Second * 10
@@ -44,6 +50,14 @@ type Client struct {
 	transactor fab.Transactor
 	eventHub   fab.EventHub
 	greylist   *greylist.Filter
+	metrics    *metrics.Metrics
+
+	// greylistExpiry is the time after which a greylisted peer is accepted again
+	greylistExpiry time.Duration
+
+	channelService fab.ChannelService
+	ledgerMutex    sync.Mutex
+	ledger         fab.ChannelLedger
 }
 
 // Context holds the providers and services needed to create a Client.
@@ -56,7 +70,8 @@ type Context struct {
 
 // New returns a Client instance.
 func New(c Context) (*Client, error) {
-	greylistProvider := greylist.New(c.Config().TimeoutOrDefault(core.DiscoveryGreylistExpiry))
+	greylistExpiry := c.Config().TimeoutOrDefault(core.DiscoveryGreylistExpiry)
+	greylistProvider := greylist.New(greylistExpiry)
 
 	eventHub, err := c.ChannelService.EventHub()
 	if err != nil {
@@ -82,6 +97,10 @@ func New(c Context) (*Client, error) {
 		channel:    channel,
 		transactor: transactor,
 		eventHub:   eventHub,
+		metrics:    metrics.New(c.MetricsProvider()),
+
+		greylistExpiry: greylistExpiry,
+		channelService: c.ChannelService,
 	}
 
 	return &channelClient, nil
@@ -97,6 +116,64 @@ func (cc *Client) Execute(request Request, options ...Option) (Response, error)
 	return cc.InvokeHandler(invoke.NewExecuteHandler(), request, cc.addDefaultTimeout(core.Execute, options...)...)
 }
 
+// WaitForBlockHeight waits until the ledger of each of the targets has at least the given height,
+// or until ctx is done. If no targets are given then all of the channel's peers are used.
+func (cc *Client) WaitForBlockHeight(ctx reqContext.Context, height uint64, targets ...fab.ProposalProcessor) error {
+	ledger, err := cc.getLedger()
+	if err != nil {
+		return err
+	}
+	targets, err = cc.waitTargets(targets)
+	if err != nil {
+		return err
+	}
+	return channel.WaitForBlockHeight(ctx, ledger, height, targets)
+}
+
+// WaitForTransaction waits until each of the targets has committed the given transaction,
+// or until ctx is done. If no targets are given then all of the channel's peers are used.
+func (cc *Client) WaitForTransaction(ctx reqContext.Context, txID fab.TransactionID, targets ...fab.ProposalProcessor) error {
+	ledger, err := cc.getLedger()
+	if err != nil {
+		return err
+	}
+	targets, err = cc.waitTargets(targets)
+	if err != nil {
+		return err
+	}
+	return channel.WaitForTransaction(ctx, ledger, txID, targets)
+}
+
+// getLedger returns the ledger client. It is created on first use since it is only
+// needed to wait for commit.
+func (cc *Client) getLedger() (fab.ChannelLedger, error) {
+	cc.ledgerMutex.Lock()
+	defer cc.ledgerMutex.Unlock()
+
+	if cc.ledger == nil {
+		ledger, err := cc.channelService.Ledger()
+		if err != nil {
+			return nil, errors.WithMessage(err, "ledger client creation failed")
+		}
+		if ledger == nil {
+			return nil, errors.New("ledger is not available")
+		}
+		cc.ledger = ledger
+	}
+	return cc.ledger, nil
+}
+
+func (cc *Client) waitTargets(targets []fab.ProposalProcessor) ([]fab.ProposalProcessor, error) {
+	if len(targets) > 0 {
+		return targets, nil
+	}
+	peers, err := cc.discovery.GetPeers()
+	if err != nil {
+		return nil, errors.WithMessage(err, "GetPeers failed")
+	}
+	return peer.PeersToTxnProcessors(peers), nil
+}
+
 //InvokeHandler invokes handler using request and options provided
 func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options ...Option) (Response, error) {
 	//Read execute tx options
@@ -111,6 +188,15 @@ func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options
 		return Response{}, err
 	}
 
+	span, ctx := tracing.StartSpan(txnOpts.ParentContext, cc.context.Tracer(), tracing.InvokeOperation)
+	span.SetTag(tracing.ChannelTag, cc.channel.Name())
+	span.SetTag(tracing.ChaincodeTag, request.ChaincodeID)
+	span.SetTag(tracing.FcnTag, request.Fcn)
+	// The request, including retries and waiting for commit, must complete within the timeout
+	ctx, cancel := reqContext.WithTimeout(ctx, requestContext.Opts.Timeout)
+	defer cancel()
+	requestContext.Ctx = ctx
+
 	complete := make(chan bool)
 
 	go func() {
@@ -124,10 +210,16 @@ func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options
 	}()
 	select {
 	case <-complete:
+		if requestContext.Response.TransactionID != "" {
+			span.SetTag(tracing.TxIDTag, string(requestContext.Response.TransactionID))
+		}
+		tracing.FinishSpan(span, requestContext.Error)
 		return Response(requestContext.Response), requestContext.Error
-	case <-time.After(requestContext.Opts.Timeout):
-		return Response{}, status.New(status.ClientStatus, status.Timeout.ToInt32(),
+	case <-ctx.Done():
+		err := status.New(status.ClientStatus, status.Timeout.ToInt32(),
 			"request timed out", nil)
+		tracing.FinishSpan(span, err)
+		return Response{}, err
 	}
 }
 
@@ -138,8 +230,12 @@ func (cc *Client) resolveRetry(ctx *invoke.RequestContext, o opts) bool {
 	}
 	for _, e := range errs {
 		if ctx.RetryHandler.Required(e) {
-			logger.Infof("Retrying on error %s", e)
+			logger.ForProvider(cc.context.LoggerProvider()).Infof("Retrying on error %s", e)
 			cc.greylist.Greylist(e)
+			cc.metrics.Retries.With(metrics.ChannelLabel, cc.channel.Name(), metrics.ChaincodeLabel, ctx.Request.ChaincodeID).Add(1)
+			cc.updateGreylistSize()
+			// The greylist expires lazily, so refresh the gauge once this entry has expired
+			time.AfterFunc(cc.greylistExpiry, cc.updateGreylistSize)
 
 			// Reset context parameters
 			ctx.Opts.ProposalProcessors = o.ProposalProcessors
@@ -152,6 +248,11 @@ func (cc *Client) resolveRetry(ctx *invoke.RequestContext, o opts) bool {
 	return false
 }
 
+// updateGreylistSize sets the greylist size gauge to the number of peers currently greylisted
+func (cc *Client) updateGreylistSize() {
+	cc.metrics.GreylistSize.With(metrics.ChannelLabel, cc.channel.Name()).Set(float64(cc.greylist.Len()))
+}
+
 //prepareHandlerContexts prepares context objects for handlers
 func (cc *Client) prepareHandlerContexts(request Request, o opts) (*invoke.RequestContext, *invoke.ClientContext, error) {
 
@@ -160,11 +261,14 @@ func (cc *Client) prepareHandlerContexts(request Request, o opts) (*invoke.Reque
 	}
 
 	clientContext := &invoke.ClientContext{
-		Selection:  cc.selection,
-		Discovery:  cc.discovery,
-		Channel:    cc.channel,
-		Transactor: cc.transactor,
-		EventHub:   cc.eventHub,
+		Selection:      cc.selection,
+		Discovery:      cc.discovery,
+		Channel:        cc.channel,
+		Transactor:     cc.transactor,
+		EventHub:       cc.eventHub,
+		Metrics:        cc.metrics,
+		Tracer:         cc.context.Tracer(),
+		LoggerProvider: cc.context.LoggerProvider(),
 	}
 
 	requestContext := &invoke.RequestContext{
@@ -178,6 +282,14 @@ func (cc *Client) prepareHandlerContexts(request Request, o opts) (*invoke.Reque
 		requestContext.Opts.Timeout = defaultHandlerTimeout
 	}
 
+	if o.WaitForCommit || o.CommitConfirmations > 0 || o.CommitConfirmationPerOrg {
+		ledger, err := cc.getLedger()
+		if err != nil {
+			return nil, nil, err
+		}
+		clientContext.Ledger = ledger
+	}
+
 	return requestContext, clientContext, nil
 }
 
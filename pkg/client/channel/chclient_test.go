@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	reqContext "context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +28,9 @@ import (
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics/prometheus"
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing"
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing/recorder"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
@@ -145,6 +150,29 @@ func TestQueryWithOptSync(t *testing.T) {
 	}
 }
 
+func TestQueryWithParentContext(t *testing.T) {
+	chClient := setupChannelClient(nil, t)
+	tracer := recorder.New()
+	chClient.context.(Context).ProviderContext.(*fcmocks.MockContext).SetTracer(tracer)
+
+	parent, ctx := tracer.StartSpan(reqContext.Background(), "caller")
+	_, err := chClient.Query(Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")}}, WithParentContext(ctx))
+	if err != nil {
+		t.Fatalf("Failed to invoke test cc: %s", err)
+	}
+
+	spans := tracer.SpansByName(tracing.InvokeOperation)
+	if len(spans) != 1 || spans[0].ParentID != parent.(*recorder.Span).ID {
+		t.Fatal("expected invoke span to be a child of the caller's span")
+	}
+	if fcn, _ := spans[0].Tag(tracing.FcnTag); fcn != "invoke" {
+		t.Fatalf("expected fcn tag, got %v", fcn)
+	}
+	if len(tracer.SpansByName(tracing.EndorsementOperation)) != 1 {
+		t.Fatal("expected endorsement span")
+	}
+}
+
 // TestQueryWithOptAsync demonstrates an example of an asynchronous query call
 func TestQueryWithOptAsync(t *testing.T) {
 	chClient := setupChannelClient(nil, t)
@@ -313,6 +341,50 @@ func TestExecuteTxDiscoveryError(t *testing.T) {
 	}
 }
 
+func TestWaitForTransaction(t *testing.T) {
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer2 := fcmocks.NewMockPeer("Peer2", "http://peer2.com")
+	chClient := setupChannelClient([]fab.Peer{testPeer1, testPeer2}, t)
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := chClient.WaitForTransaction(ctx, "txid", testPeer1); err == nil {
+		t.Fatalf("Should have failed without a ledger")
+	}
+
+	// The ledger is resolved when it is first needed
+	ledger := fcmocks.NewMockChannelLedger()
+	ledger.CommitTransaction(testPeer1, "txid", pb.TxValidationCode_VALID)
+	ledger.CommitTransaction(testPeer2, "txid", pb.TxValidationCode_VALID)
+	ledger.SetHeight(testPeer1, 2)
+	ledger.SetHeight(testPeer2, 2)
+	chClient.channelService.(*fcmocks.MockChannelService).SetLedger(ledger, nil)
+
+	if err := chClient.WaitForTransaction(ctx, "txid", testPeer1, testPeer2); err != nil {
+		t.Fatalf("WaitForTransaction failed: %s", err)
+	}
+	if err := chClient.WaitForBlockHeight(ctx, 2, testPeer1, testPeer2); err != nil {
+		t.Fatalf("WaitForBlockHeight failed: %s", err)
+	}
+}
+
+func TestLedgerError(t *testing.T) {
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+	chClient.channelService.(*fcmocks.MockChannelService).SetLedger(nil, errors.New("ledger error"))
+
+	// The ledger is only required to wait for commit
+	if _, err := chClient.Query(Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")}}); err != nil {
+		t.Fatalf("Query failed: %s", err)
+	}
+
+	_, err := chClient.Execute(Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}, WithWaitForCommit())
+	if err == nil || !strings.Contains(err.Error(), "ledger error") {
+		t.Fatalf("Should have failed to wait for commit without a ledger but got: %v", err)
+	}
+}
+
 func TestExecuteTxSelectionError(t *testing.T) {
 	chClient := setupChannelClientWithError(nil, errors.New("Test Error"), nil, t)
 
@@ -507,6 +579,62 @@ func TestDiscoveryGreylist(t *testing.T) {
 
 }
 
+func TestGreylistSizeMetric(t *testing.T) {
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer1.Error = status.New(status.EndorserClientStatus,
+		status.ConnectionFailed.ToInt32(), "test", []interface{}{testPeer1.URL()})
+
+	fabCtx := fcmocks.NewMockContext(fcmocks.NewMockUser("test"))
+	provider := prometheus.NewProvider()
+	fabCtx.SetMetricsProvider(provider)
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	testChannelSvc, err := setupTestChannelService(fabCtx, []fab.Orderer{orderer})
+	assert.Nil(t, err, "Got error %s", err)
+
+	discoveryService, err := setupTestDiscovery(nil, []fab.Peer{testPeer1})
+	assert.Nil(t, err, "Got error %s", err)
+
+	selectionService, err := setupTestSelection(nil, nil)
+	assert.Nil(t, err, "Got error %s", err)
+	selectionService.SelectAll = true
+
+	chClient, err := New(Context{
+		ProviderContext:  fabCtx,
+		DiscoveryService: discoveryService,
+		SelectionService: selectionService,
+		ChannelService:   testChannelSvc,
+	})
+	assert.Nil(t, err, "Got error %s", err)
+
+	retryOpts := retry.Opts{
+		Attempts:       1,
+		BackoffFactor:  1,
+		InitialBackoff: time.Millisecond * 1,
+		MaxBackoff:     time.Second * 1,
+		RetryableCodes: retry.ChannelClientRetryableCodes,
+	}
+	_, err = chClient.Query(Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")}},
+		WithRetry(retryOpts))
+	assert.NotNil(t, err, "expected error")
+
+	greylistSize := func() string {
+		var buf strings.Builder
+		provider.WriteTo(&buf)
+		for _, line := range strings.Split(buf.String(), "\n") {
+			if strings.HasPrefix(line, "fabric_sdk_channel_greylist_size{") {
+				return line
+			}
+		}
+		return ""
+	}
+	assert.Equal(t, `fabric_sdk_channel_greylist_size{channel="testChannel"} 1`, greylistSize())
+
+	// The gauge must drop once the peer expires from the greylist, without further requests
+	time.Sleep(fabCtx.Config().TimeoutOrDefault(core.DiscoveryGreylistExpiry) + 100*time.Millisecond)
+	assert.Equal(t, `fabric_sdk_channel_greylist_size{channel="testChannel"} 0`, greylistSize())
+}
+
 func setupTestChannelService(ctx context.Context, orderers []fab.Orderer) (fab.ChannelService, error) {
 	const channelName = "testChannel"
 	testChannel, err := setupChannel(channelName)
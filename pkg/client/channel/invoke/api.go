@@ -8,11 +8,15 @@ SPDX-License-Identifier: Apache-2.0
 package invoke
 
 import (
+	reqContext "context"
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/retry"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics"
+	tracingApi "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -21,6 +25,15 @@ type Opts struct {
 	ProposalProcessors []fab.ProposalProcessor // targets
 	Timeout            time.Duration
 	Retry              retry.Opts
+	ParentContext      reqContext.Context
+	// WaitForCommit, if true, waits until the transaction is committed on the
+	// WaitForCommitTargets (or the endorsers, if none) before returning
+	WaitForCommit        bool
+	WaitForCommitTargets []fab.ProposalProcessor
+	// CommitConfirmations is the number of peers that must confirm the commit of the transaction
+	CommitConfirmations int
+	// CommitConfirmationPerOrg, if true, requires a commit confirmation from a peer of each endorsing org
+	CommitConfirmationPerOrg bool
 }
 
 // Request contains the parameters to execute transaction
@@ -38,6 +51,9 @@ type Response struct {
 	TxValidationCode pb.TxValidationCode
 	Proposal         *fab.TransactionProposal
 	Responses        []*fab.TransactionProposalResponse
+	// PeerValidationCodes holds the validation code reported by each peer (by URL)
+	// that confirmed the commit of the transaction
+	PeerValidationCodes map[string]pb.TxValidationCode
 }
 
 //Handler for chaining transaction executions
@@ -53,6 +69,11 @@ type ClientContext struct {
 	Channel     fab.Channel // TODO: this should be removed when we have MSP split out.
 	Transactor  fab.Transactor
 	EventHub    fab.EventHub
+	Ledger      fab.ChannelLedger // optional; required for WaitForCommit
+	Metrics     *metrics.Metrics  // optional; nil disables metrics
+	Tracer      tracingApi.Tracer // optional; nil disables tracing
+	// LoggerProvider is optional; nil uses the process-wide logger provider
+	LoggerProvider logApi.LoggerProvider
 }
 
 //RequestContext contains request, opts, response parameters for handler execution
@@ -62,4 +83,7 @@ type RequestContext struct {
 	Response     Response
 	Error        error
 	RetryHandler retry.Handler
+	// Ctx carries the span and the deadline of the request; handlers start their spans as its
+	// children and stop waiting when it's done
+	Ctx reqContext.Context
 }
@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+func confirmationRequired(opts Opts) bool {
+	return opts.CommitConfirmations > 0 || opts.CommitConfirmationPerOrg
+}
+
+// confirmCommit queries peers for the transaction until the required commit confirmations have
+// been received. An error is returned if a peer reports a validation code other than the given code.
+func confirmCommit(requestContext *RequestContext, clientContext *ClientContext, code pb.TxValidationCode) error {
+	if clientContext.Ledger == nil {
+		return errors.New("ledger is required to confirm commit")
+	}
+
+	candidates, orgs, err := confirmationCandidates(requestContext, clientContext)
+	if err != nil {
+		return err
+	}
+	required := requestContext.Opts.CommitConfirmations
+	if required > len(candidates) {
+		return status.New(status.ClientStatus, status.NoPeersFound.ToInt32(),
+			fmt.Sprintf("%d commit confirmations required but only %d peers are available", required, len(candidates)), nil)
+	}
+	if requestContext.Opts.CommitConfirmationPerOrg && len(orgs) == 0 {
+		return status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "endorsing orgs are unknown", nil)
+	}
+
+	targets := make([]fab.ProposalProcessor, len(candidates))
+	mspIDs := make(map[string]string)
+	for i, p := range candidates {
+		targets[i] = p
+		mspIDs[p.URL()] = p.MSPID()
+	}
+
+	ctx, cancel := requestDeadline(requestContext)
+	defer cancel()
+
+	var confirmed map[string]pb.TxValidationCode
+	err = channel.WaitForTransactionCommits(ctx, clientContext.Ledger, requestContext.Response.TransactionID, targets, func(codes map[string]pb.TxValidationCode) (bool, error) {
+		confirmed = codes
+		if mismatches := validationCodeMismatches(codes, code); len(mismatches) > 0 {
+			return false, status.New(status.ClientStatus, status.ValidationCodeMismatch.ToInt32(),
+				fmt.Sprintf("commit event reported validation code %s but peers reported [%s]", code, strings.Join(mismatches, ", ")), nil)
+		}
+		return len(codes) >= required && allOrgsConfirmed(orgs, codes, mspIDs), nil
+	})
+	requestContext.Response.PeerValidationCodes = confirmed
+	if err != nil && ctx.Err() != nil {
+		return status.New(status.ClientStatus, status.Timeout.ToInt32(),
+			fmt.Sprintf("timed out waiting for commit confirmations: received %d: %s", len(confirmed), err), nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	txLogger(requestContext, clientContext).Debugf("commit confirmed by %d peer(s)", len(confirmed))
+	return nil
+}
+
+// confirmationCandidates returns the peers that may confirm the commit, endorsers first,
+// and the MSP IDs of the endorsers
+func confirmationCandidates(requestContext *RequestContext, clientContext *ClientContext) ([]fab.Peer, []string, error) {
+	var candidates []fab.Peer
+	var orgs []string
+	seen := make(map[string]bool)
+	seenOrgs := make(map[string]bool)
+	for _, target := range requestContext.Opts.ProposalProcessors {
+		p, ok := target.(fab.Peer)
+		if !ok || seen[p.URL()] {
+			continue
+		}
+		seen[p.URL()] = true
+		candidates = append(candidates, p)
+		if !seenOrgs[p.MSPID()] {
+			seenOrgs[p.MSPID()] = true
+			orgs = append(orgs, p.MSPID())
+		}
+	}
+
+	if clientContext.Discovery != nil {
+		peers, err := clientContext.Discovery.GetPeers()
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "GetPeers failed")
+		}
+		for _, p := range peers {
+			if !seen[p.URL()] {
+				seen[p.URL()] = true
+				candidates = append(candidates, p)
+			}
+		}
+	}
+
+	return candidates, orgs, nil
+}
+
+func validationCodeMismatches(confirmed map[string]pb.TxValidationCode, code pb.TxValidationCode) []string {
+	var mismatches []string
+	for url, c := range confirmed {
+		if c != code {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s", url, c))
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches
+}
+
+func allOrgsConfirmed(orgs []string, confirmed map[string]pb.TxValidationCode, mspIDs map[string]string) bool {
+	confirmedOrgs := make(map[string]bool)
+	for url := range confirmed {
+		confirmedOrgs[mspIDs[url]] = true
+	}
+	for _, org := range orgs {
+		if !confirmedOrgs[org] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestCommitConfirmations(t *testing.T) {
+	peer1, peer2 := newConfirmationPeers()
+	requestContext := executeWithConfirmations(t, Opts{CommitConfirmations: 2}, []fab.Peer{peer1, peer2}, func(ledger *fcmocks.MockChannelLedger, txID fab.TransactionID) {
+		ledger.CommitTransaction(peer1, txID, pb.TxValidationCode_VALID)
+		time.Sleep(50 * time.Millisecond)
+		ledger.CommitTransaction(peer2, txID, pb.TxValidationCode_VALID)
+	})
+	if requestContext.Error != nil {
+		t.Fatalf("expecting commit to be confirmed but got error: %s", requestContext.Error)
+	}
+	codes := requestContext.Response.PeerValidationCodes
+	if len(codes) != 2 || codes[peer1.URL()] != pb.TxValidationCode_VALID || codes[peer2.URL()] != pb.TxValidationCode_VALID {
+		t.Fatalf("unexpected peer validation codes: %v", codes)
+	}
+}
+
+func TestCommitConfirmationMismatch(t *testing.T) {
+	peer1, peer2 := newConfirmationPeers()
+	requestContext := executeWithConfirmations(t, Opts{CommitConfirmations: 2}, []fab.Peer{peer1, peer2}, func(ledger *fcmocks.MockChannelLedger, txID fab.TransactionID) {
+		ledger.CommitTransaction(peer1, txID, pb.TxValidationCode_VALID)
+		ledger.CommitTransaction(peer2, txID, pb.TxValidationCode_MVCC_READ_CONFLICT)
+	})
+	checkStatusCode(t, requestContext.Error, status.ValidationCodeMismatch)
+	if requestContext.Response.PeerValidationCodes[peer2.URL()] != pb.TxValidationCode_MVCC_READ_CONFLICT {
+		t.Fatalf("unexpected peer validation codes: %v", requestContext.Response.PeerValidationCodes)
+	}
+}
+
+func TestCommitConfirmationPerOrg(t *testing.T) {
+	peer1, peer2 := newConfirmationPeers()
+	requestContext := executeWithConfirmations(t, Opts{CommitConfirmationPerOrg: true}, []fab.Peer{peer1, peer2}, func(ledger *fcmocks.MockChannelLedger, txID fab.TransactionID) {
+		ledger.CommitTransaction(peer2, txID, pb.TxValidationCode_VALID)
+		ledger.CommitTransaction(peer1, txID, pb.TxValidationCode_VALID)
+	})
+	if requestContext.Error != nil {
+		t.Fatalf("expecting commit to be confirmed but got error: %s", requestContext.Error)
+	}
+
+	// Org2 never confirms
+	requestContext = executeWithConfirmations(t, Opts{CommitConfirmationPerOrg: true}, []fab.Peer{peer1, peer2}, func(ledger *fcmocks.MockChannelLedger, txID fab.TransactionID) {
+		ledger.CommitTransaction(peer1, txID, pb.TxValidationCode_VALID)
+	})
+	checkStatusCode(t, requestContext.Error, status.Timeout)
+
+	// Not enough peers
+	requestContext = executeWithConfirmations(t, Opts{CommitConfirmations: 3}, []fab.Peer{peer1, peer2}, func(ledger *fcmocks.MockChannelLedger, txID fab.TransactionID) {})
+	checkStatusCode(t, requestContext.Error, status.NoPeersFound)
+}
+
+func TestCommitConfirmationInvalidTransaction(t *testing.T) {
+	peer1, peer2 := newConfirmationPeers()
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+	requestContext := prepareRequestContext(request, Opts{CommitConfirmations: 2}, t)
+
+	clientContext := setupChannelClientContext(nil, nil, []fab.Peer{peer1, peer2}, t)
+	mockEventHub := fcmocks.NewMockEventHub()
+	clientContext.EventHub = mockEventHub
+	clientContext.Ledger = fcmocks.NewMockChannelLedger()
+
+	go func() {
+		select {
+		case callback := <-mockEventHub.RegisteredTxCallbacks:
+			callback(requestContext.Response.TransactionID, pb.TxValidationCode_MVCC_READ_CONFLICT,
+				status.New(status.EventServerStatus, int32(pb.TxValidationCode_MVCC_READ_CONFLICT), "received invalid transaction", nil))
+		case <-time.After(requestContext.Opts.Timeout):
+			t.Error("Execute handler : time out not expected")
+		}
+	}()
+
+	// The commits of a transaction that failed validation are not confirmed
+	NewExecuteHandler().Handle(requestContext, clientContext)
+	s, ok := status.FromError(requestContext.Error)
+	if !ok || s.Group != status.EventServerStatus || s.Code != int32(pb.TxValidationCode_MVCC_READ_CONFLICT) {
+		t.Fatalf("expecting the validation error of the commit event but got: %v", requestContext.Error)
+	}
+	if requestContext.Response.PeerValidationCodes != nil {
+		t.Fatalf("expecting no commit confirmations but got: %v", requestContext.Response.PeerValidationCodes)
+	}
+}
+
+func newConfirmationPeers() (*fcmocks.MockPeer, *fcmocks.MockPeer) {
+	peer1 := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+	peer2 := &fcmocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", MockRoles: []string{}, MockMSP: "Org2MSP", Status: 200, Payload: []byte("value")}
+	return peer1, peer2
+}
+
+// executeWithConfirmations executes a transaction endorsed by the given peers. When the
+// transaction is committed, commit is invoked to update the peers' ledgers.
+func executeWithConfirmations(t *testing.T, opts Opts, peers []fab.Peer, commit func(ledger *fcmocks.MockChannelLedger, txID fab.TransactionID)) *RequestContext {
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+	requestContext := prepareRequestContext(request, opts, t)
+	requestContext.Opts.Timeout = time.Second
+
+	clientContext := setupChannelClientContext(nil, nil, peers, t)
+	mockEventHub := fcmocks.NewMockEventHub()
+	clientContext.EventHub = mockEventHub
+	ledger := fcmocks.NewMockChannelLedger()
+	clientContext.Ledger = ledger
+
+	go func() {
+		select {
+		case callback := <-mockEventHub.RegisteredTxCallbacks:
+			txID := requestContext.Response.TransactionID
+			callback(txID, pb.TxValidationCode_VALID, nil)
+			commit(ledger, txID)
+		case <-time.After(requestContext.Opts.Timeout):
+			t.Error("Execute handler : time out not expected")
+		}
+	}()
+
+	NewExecuteHandler().Handle(requestContext, clientContext)
+	return requestContext
+}
+
+func checkStatusCode(t *testing.T, err error, expected status.Code) {
+	s, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("expecting status error with code %s but got: %v", expected, err)
+	}
+	if s.Code != expected.ToInt32() {
+		t.Fatalf("expecting status code %s but got %d: %s", expected, s.Code, err)
+	}
+}
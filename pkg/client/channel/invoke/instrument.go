@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	reqContext "context"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics"
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing"
+	tracingApi "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+)
+
+// disabledMetrics is used when the client context doesn't supply metrics
+var disabledMetrics = metrics.New(nil)
+
+// clientMetrics returns the metrics of the client context
+func clientMetrics(clientContext *ClientContext) *metrics.Metrics {
+	if clientContext.Metrics == nil {
+		return disabledMetrics
+	}
+	return clientContext.Metrics
+}
+
+// metricLabels returns the channel and chaincode labels of the request
+func metricLabels(requestContext *RequestContext, clientContext *ClientContext) []string {
+	channelID := ""
+	if clientContext.Channel != nil {
+		channelID = clientContext.Channel.Name()
+	}
+	return []string{metrics.ChannelLabel, channelID, metrics.ChaincodeLabel, requestContext.Request.ChaincodeID}
+}
+
+// instrumentedProposalProcessor records the endorsement latency and errors of a
+// proposal processor, and traces each endorsement as a child span of ctx
+type instrumentedProposalProcessor struct {
+	fab.ProposalProcessor
+	metrics *metrics.Metrics
+	labels  []string
+	tracer  tracingApi.Tracer
+	ctx     reqContext.Context
+}
+
+// instrumentProposalProcessors wraps the targets of the request so that each endorsement is recorded
+func instrumentProposalProcessors(ctx reqContext.Context, requestContext *RequestContext, clientContext *ClientContext) []fab.ProposalProcessor {
+	m := clientMetrics(clientContext)
+	labels := metricLabels(requestContext, clientContext)
+
+	targets := requestContext.Opts.ProposalProcessors
+	instrumented := make([]fab.ProposalProcessor, len(targets))
+	for i, target := range targets {
+		instrumented[i] = &instrumentedProposalProcessor{ProposalProcessor: target, metrics: m, labels: labels, tracer: clientContext.Tracer, ctx: ctx}
+	}
+	return instrumented
+}
+
+// ProcessTransactionProposal sends the proposal to the wrapped processor and records the outcome
+func (p *instrumentedProposalProcessor) ProcessTransactionProposal(request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	span, _ := tracing.StartSpan(p.ctx, p.tracer, tracing.EndorseOperation)
+	start := time.Now()
+	resp, err := p.ProposalProcessor.ProcessTransactionProposal(request)
+
+	peer := p.peer(resp)
+	labels := append(append([]string(nil), p.labels...), metrics.PeerLabel, peer)
+	p.metrics.EndorsementDuration.With(labels...).Observe(time.Since(start).Seconds())
+	if err != nil || resp == nil || resp.ProposalResponse.GetResponse().GetStatus() != int32(common.Status_SUCCESS) {
+		p.metrics.EndorsementErrors.With(labels...).Add(1)
+	}
+
+	span.SetTag(tracing.PeerTag, peer)
+	if err == nil && resp != nil {
+		span.SetTag(tracing.StatusTag, resp.ProposalResponse.GetResponse().GetStatus())
+	}
+	tracing.FinishSpan(span, err)
+	return resp, err
+}
+
+// peer returns the URL of the endorser
+func (p *instrumentedProposalProcessor) peer(resp *fab.TransactionProposalResponse) string {
+	if withURL, ok := p.ProposalProcessor.(interface {
+		URL() string
+	}); ok {
+		return withURL.URL()
+	}
+	if resp != nil {
+		return resp.Endorser
+	}
+	return ""
+}
+
+// startSpan starts the span of a handler stage as a child of the request's span
+func startSpan(requestContext *RequestContext, clientContext *ClientContext, operationName string) (tracingApi.Span, reqContext.Context) {
+	span, ctx := tracing.StartSpan(requestContext.Ctx, clientContext.Tracer, operationName)
+	if clientContext.Channel != nil {
+		span.SetTag(tracing.ChannelTag, clientContext.Channel.Name())
+	}
+	span.SetTag(tracing.ChaincodeTag, requestContext.Request.ChaincodeID)
+	if requestContext.Response.TransactionID != "" {
+		span.SetTag(tracing.TxIDTag, string(requestContext.Response.TransactionID))
+	}
+	return span, ctx
+}
+
+// peerURLs returns the URLs of peers
+func peerURLs(peers []fab.Peer) []string {
+	urls := make([]string, len(peers))
+	for i, p := range peers {
+		urls[i] = p.URL()
+	}
+	return urls
+}
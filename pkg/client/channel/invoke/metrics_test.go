@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics/prometheus"
+)
+
+func TestExecuteTxHandlerMetrics(t *testing.T) {
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+	requestContext := prepareRequestContext(request, Opts{}, t)
+
+	mockPeer1 := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+	mockPeer2 := &fcmocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+
+	provider := prometheus.NewProvider()
+	clientContext := setupChannelClientContext(nil, nil, []fab.Peer{mockPeer1, mockPeer2}, t)
+	clientContext.Metrics = metrics.New(provider)
+
+	mockEventHub := fcmocks.NewMockEventHub()
+	clientContext.EventHub = mockEventHub
+
+	go func() {
+		select {
+		case callback := <-mockEventHub.RegisteredTxCallbacks:
+			callback("txid", 0, nil)
+		case <-time.After(requestContext.Opts.Timeout):
+			t.Error("Execute handler : time out not expected")
+		}
+	}()
+
+	NewExecuteHandler().Handle(requestContext, clientContext)
+	if requestContext.Error != nil {
+		t.Fatalf("execute handler failed: %s", requestContext.Error)
+	}
+
+	var buf bytes.Buffer
+	provider.WriteTo(&buf)
+	output := buf.String()
+
+	expected := []string{
+		`fabric_sdk_endorser_proposal_duration_seconds_count{channel="testChannel",chaincode="test",peer="http://peer1.com"} 1`,
+		`fabric_sdk_endorser_proposal_duration_seconds_count{channel="testChannel",chaincode="test",peer="http://peer2.com"} 1`,
+		`fabric_sdk_transaction_commit_duration_seconds_count{channel="testChannel",chaincode="test"} 1`,
+		`fabric_sdk_transaction_validation_codes_total{channel="testChannel",chaincode="test",code="VALID"} 1`,
+		`fabric_sdk_orderer_broadcast_duration_seconds_count{channel="testChannel",chaincode="test",orderer=`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(output, e) {
+			t.Fatalf("expected metrics to contain [%s], got:\n%s", e, output)
+		}
+	}
+	if strings.Contains(output, "fabric_sdk_endorser_proposal_errors_total{") {
+		t.Fatalf("no endorsement errors expected, got:\n%s", output)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing"
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing/recorder"
+)
+
+func TestExecuteTxHandlerTracing(t *testing.T) {
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+	requestContext := prepareRequestContext(request, Opts{}, t)
+
+	mockPeer1 := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+	mockPeer2 := &fcmocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+
+	tracer := recorder.New()
+	clientContext := setupChannelClientContext(nil, nil, []fab.Peer{mockPeer1, mockPeer2}, t)
+	clientContext.Tracer = tracer
+
+	root, ctx := tracer.StartSpan(context.Background(), "rest.request")
+	requestContext.Ctx = ctx
+
+	mockEventHub := fcmocks.NewMockEventHub()
+	clientContext.EventHub = mockEventHub
+
+	go func() {
+		select {
+		case callback := <-mockEventHub.RegisteredTxCallbacks:
+			callback("txid", 0, nil)
+		case <-time.After(requestContext.Opts.Timeout):
+			t.Error("Execute handler : time out not expected")
+		}
+	}()
+
+	NewExecuteHandler().Handle(requestContext, clientContext)
+	if requestContext.Error != nil {
+		t.Fatalf("execute handler failed: %s", requestContext.Error)
+	}
+	rootID := root.(*recorder.Span).ID
+
+	for _, op := range []string{tracing.SelectionOperation, tracing.EndorsementOperation, tracing.EndorsementValidateOperation,
+		tracing.SignatureValidateOperation, tracing.BroadcastOperation, tracing.CommitOperation} {
+		spans := tracer.SpansByName(op)
+		if len(spans) != 1 {
+			t.Fatalf("expected one %s span, got %d", op, len(spans))
+		}
+		if spans[0].ParentID != rootID {
+			t.Fatalf("expected %s span to be a child of the caller's span", op)
+		}
+		if cc, _ := spans[0].Tag(tracing.ChaincodeTag); cc != "test" {
+			t.Fatalf("expected chaincode tag on %s span, got %v", op, cc)
+		}
+		if spans[0].Err() != nil {
+			t.Fatalf("unexpected error on %s span: %s", op, spans[0].Err())
+		}
+	}
+
+	endorsement := tracer.SpansByName(tracing.EndorsementOperation)[0]
+	if txID, _ := endorsement.Tag(tracing.TxIDTag); txID != string(requestContext.Response.TransactionID) {
+		t.Fatalf("expected txID tag %s, got %v", requestContext.Response.TransactionID, txID)
+	}
+
+	endorsements := tracer.SpansByName(tracing.EndorseOperation)
+	if len(endorsements) != 2 {
+		t.Fatalf("expected a span per endorsing peer, got %d", len(endorsements))
+	}
+	peers := map[interface{}]bool{}
+	for _, span := range endorsements {
+		if span.ParentID != endorsement.ID {
+			t.Fatal("expected peer endorsement spans to be children of the endorsement span")
+		}
+		peer, _ := span.Tag(tracing.PeerTag)
+		peers[peer] = true
+	}
+	if !peers["http://peer1.com"] || !peers["http://peer2.com"] {
+		t.Fatalf("expected peer tags for both endorsers, got %v", peers)
+	}
+
+	if code, _ := tracer.SpansByName(tracing.CommitOperation)[0].Tag(tracing.ValidationCodeTag); code != "VALID" {
+		t.Fatalf("expected validation code tag, got %v", code)
+	}
+}
+
+func TestEndorsementHandlerTracingError(t *testing.T) {
+	request := Request{ChaincodeID: "test", Fcn: "invoke"}
+	failingPeer := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Error: errors.New("endorsement failed")}
+	requestContext := prepareRequestContext(request, Opts{ProposalProcessors: []fab.ProposalProcessor{failingPeer}}, t)
+
+	tracer := recorder.New()
+	clientContext := setupChannelClientContext(nil, nil, nil, t)
+	clientContext.Tracer = tracer
+
+	NewEndorsementHandler().Handle(requestContext, clientContext)
+	if requestContext.Error == nil {
+		t.Fatal("expected endorsement to fail")
+	}
+	for _, op := range []string{tracing.EndorsementOperation, tracing.EndorseOperation} {
+		spans := tracer.SpansByName(op)
+		if len(spans) != 1 || spans[0].Err() == nil {
+			t.Fatalf("expected %s span to be marked as failed", op)
+		}
+	}
+}
@@ -8,19 +8,23 @@ package invoke
 
 import (
 	"bytes"
+	reqContext "context"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics"
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/invoke")
 
 //EndorsementHandler for handling endorse transactions
 type EndorsementHandler struct {
@@ -35,16 +39,24 @@ func (e *EndorsementHandler) Handle(requestContext *RequestContext, clientContex
 		return
 	}
 
+	span, ctx := startSpan(requestContext, clientContext, tracing.EndorsementOperation)
+
 	// Endorse Tx
-	transactionProposalResponses, proposal, err := createAndSendTransactionProposal(clientContext.Transactor, &requestContext.Request, requestContext.Opts.ProposalProcessors)
+	targets := instrumentProposalProcessors(ctx, requestContext, clientContext)
+	transactionProposalResponses, proposal, err := createAndSendTransactionProposal(clientContext.Transactor, &requestContext.Request, targets)
 
 	requestContext.Response.Proposal = proposal
 	requestContext.Response.TransactionID = proposal.TxnID // TODO: still needed?
+	span.SetTag(tracing.TxIDTag, string(proposal.TxnID))
 
 	if err != nil {
+		txLogger(requestContext, clientContext).Debugf("endorsement failed: %s", err)
 		requestContext.Error = err
+		tracing.FinishSpan(span, err)
 		return
 	}
+	txLogger(requestContext, clientContext).Debugf("received %d endorsement(s)", len(transactionProposalResponses))
+	tracing.FinishSpan(span, nil)
 
 	requestContext.Response.Responses = transactionProposalResponses
 	if len(transactionProposalResponses) > 0 {
@@ -67,20 +79,15 @@ func (h *ProposalProcessorHandler) Handle(requestContext *RequestContext, client
 	//Get proposal processor, if not supplied then use discovery service to get available peers as endorser
 	//If selection service available then get endorser peers for this chaincode
 	if len(requestContext.Opts.ProposalProcessors) == 0 {
-		// Use discovery service to figure out proposal processors
-		peers, err := clientContext.Discovery.GetPeers()
+		span, _ := startSpan(requestContext, clientContext, tracing.SelectionOperation)
+		endorsers, err := h.selectEndorsers(requestContext, clientContext)
 		if err != nil {
-			requestContext.Error = errors.WithMessage(err, "GetPeers failed")
+			requestContext.Error = err
+			tracing.FinishSpan(span, err)
 			return
 		}
-		endorsers := peers
-		if clientContext.Selection != nil {
-			endorsers, err = clientContext.Selection.GetEndorsersForChaincode(peers, requestContext.Request.ChaincodeID)
-			if err != nil {
-				requestContext.Error = errors.WithMessage(err, "Failed to get endorsing peers")
-				return
-			}
-		}
+		span.SetTag(tracing.PeersTag, peerURLs(endorsers))
+		tracing.FinishSpan(span, nil)
 		requestContext.Opts.ProposalProcessors = peer.PeersToTxnProcessors(endorsers)
 	}
 
@@ -90,6 +97,22 @@ func (h *ProposalProcessorHandler) Handle(requestContext *RequestContext, client
 	}
 }
 
+// selectEndorsers uses the discovery service, and the selection service if available, to figure out proposal processors
+func (h *ProposalProcessorHandler) selectEndorsers(requestContext *RequestContext, clientContext *ClientContext) ([]fab.Peer, error) {
+	peers, err := clientContext.Discovery.GetPeers()
+	if err != nil {
+		return nil, errors.WithMessage(err, "GetPeers failed")
+	}
+	if clientContext.Selection == nil {
+		return peers, nil
+	}
+	endorsers, err := clientContext.Selection.GetEndorsersForChaincode(peers, requestContext.Request.ChaincodeID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to get endorsing peers")
+	}
+	return endorsers, nil
+}
+
 //EndorsementValidationHandler for transaction proposal response filtering
 type EndorsementValidationHandler struct {
 	next Handler
@@ -98,8 +121,11 @@ type EndorsementValidationHandler struct {
 //Handle for Filtering proposal response
 func (f *EndorsementValidationHandler) Handle(requestContext *RequestContext, clientContext *ClientContext) {
 
+	span, _ := startSpan(requestContext, clientContext, tracing.EndorsementValidateOperation)
+
 	//Filter tx proposal responses
 	err := f.validate(requestContext.Response.Responses)
+	tracing.FinishSpan(span, err)
 	if err != nil {
 		requestContext.Error = errors.WithMessage(err, "endorsement validation failed")
 		return
@@ -149,34 +175,99 @@ func (c *CommitTxHandler) Handle(requestContext *RequestContext, clientContext *
 	}
 
 	txnID := requestContext.Response.TransactionID
+	commitLogger := txLogger(requestContext, clientContext)
+	m := clientMetrics(clientContext)
+	labels := metricLabels(requestContext, clientContext)
 
 	//Register Tx event
 	statusNotifier := txn.RegisterStatus(txnID, clientContext.EventHub)
-	_, err := createAndSendTransaction(clientContext.Transactor, requestContext.Response.Proposal, requestContext.Response.Responses)
+	broadcastSpan, _ := startSpan(requestContext, clientContext, tracing.BroadcastOperation)
+	start := time.Now()
+	resp, err := createAndSendTransaction(clientContext.Transactor, requestContext.Response.Proposal, requestContext.Response.Responses, commitLogger)
 	if err != nil {
 		requestContext.Error = errors.Wrap(err, "CreateAndSendTransaction failed")
+		tracing.FinishSpan(broadcastSpan, err)
 		return
 	}
+	m.BroadcastDuration.With(append(labels, metrics.OrdererLabel, resp.Orderer)...).Observe(time.Since(start).Seconds())
+	broadcastSpan.SetTag(tracing.OrdererTag, resp.Orderer)
+	tracing.FinishSpan(broadcastSpan, nil)
 
+	commitSpan, _ := startSpan(requestContext, clientContext, tracing.CommitOperation)
 	select {
 	case result := <-statusNotifier:
 		requestContext.Response.TxValidationCode = result.Code
+		commitLogger.Debugf("transaction committed with validation code %s", result.Code)
+		m.CommitDuration.With(labels...).Observe(time.Since(start).Seconds())
+		m.ValidationCodes.With(append(labels, metrics.CodeLabel, result.Code.String())...).Add(1)
+		commitSpan.SetTag(tracing.ValidationCodeTag, result.Code.String())
+		tracing.FinishSpan(commitSpan, result.Error)
 
 		if result.Error != nil {
 			requestContext.Error = result.Error
 			return
 		}
+
+		if confirmationRequired(requestContext.Opts) {
+			if err := confirmCommit(requestContext, clientContext, result.Code); err != nil {
+				commitLogger.Debugf("commit confirmation failed: %s", err)
+				requestContext.Error = err
+				return
+			}
+		}
 	case <-time.After(requestContext.Opts.Timeout):
+		commitLogger.Debugf("timed out after %s waiting for commit event", requestContext.Opts.Timeout)
 		requestContext.Error = errors.New("Execute didn't receive block event")
+		tracing.FinishSpan(commitSpan, requestContext.Error)
 		return
 	}
 
+	if requestContext.Opts.WaitForCommit {
+		if err := waitForCommit(requestContext, clientContext); err != nil {
+			requestContext.Error = err
+			return
+		}
+	}
+
 	//Delegate to next step if any
 	if c.next != nil {
 		c.next.Handle(requestContext, clientContext)
 	}
 }
 
+// waitForCommit waits until the transaction has been committed on the WaitForCommitTargets,
+// or on the endorsers if no targets were specified
+func waitForCommit(requestContext *RequestContext, clientContext *ClientContext) error {
+	if clientContext.Ledger == nil {
+		return errors.New("ledger is required to wait for commit")
+	}
+
+	targets := requestContext.Opts.WaitForCommitTargets
+	if len(targets) == 0 {
+		targets = requestContext.Opts.ProposalProcessors
+	}
+
+	ctx, cancel := requestDeadline(requestContext)
+	defer cancel()
+
+	txLogger(requestContext, clientContext).Debugf("waiting for commit on %d peer(s)", len(targets))
+	err := channel.WaitForTransaction(ctx, clientContext.Ledger, requestContext.Response.TransactionID, targets)
+	return errors.WithMessage(err, "waiting for commit failed")
+}
+
+// requestDeadline returns the context of the request, which is done when the request times out.
+// If the request has no deadline then one is set from the request timeout.
+func requestDeadline(requestContext *RequestContext) (reqContext.Context, reqContext.CancelFunc) {
+	ctx := requestContext.Ctx
+	if ctx == nil {
+		ctx = reqContext.Background()
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return reqContext.WithCancel(ctx)
+	}
+	return reqContext.WithTimeout(ctx, requestContext.Opts.Timeout)
+}
+
 //NewQueryHandler returns query handler with EndorseTxHandler & EndorsementValidationHandler Chained
 func NewQueryHandler(next ...Handler) Handler {
 	return NewProposalProcessorHandler(
@@ -226,7 +317,19 @@ func getNext(next []Handler) Handler {
 	return nil
 }
 
-func createAndSendTransaction(sender fab.Sender, proposal *fab.TransactionProposal, resps []*fab.TransactionProposalResponse) (*fab.TransactionResponse, error) {
+// txLogger returns a logger tagged with the channel and transaction ID of the request
+func txLogger(requestContext *RequestContext, clientContext *ClientContext) *logging.Logger {
+	var keysAndValues []interface{}
+	if clientContext.Channel != nil {
+		keysAndValues = append(keysAndValues, logging.ChannelIDKey, clientContext.Channel.Name())
+	}
+	if requestContext.Response.TransactionID != "" {
+		keysAndValues = append(keysAndValues, logging.TxIDKey, requestContext.Response.TransactionID)
+	}
+	return logger.ForProvider(clientContext.LoggerProvider).With(keysAndValues...)
+}
+
+func createAndSendTransaction(sender fab.Sender, proposal *fab.TransactionProposal, resps []*fab.TransactionProposalResponse, txLogger *logging.Logger) (*fab.TransactionResponse, error) {
 
 	txnRequest := fab.TransactionRequest{
 		Proposal:          proposal,
@@ -244,7 +347,7 @@ func createAndSendTransaction(sender fab.Sender, proposal *fab.TransactionPropos
 
 	}
 	if transactionResponse.Err != nil {
-		logger.Debugf("orderer %s failed (%s)", transactionResponse.Orderer, transactionResponse.Err.Error())
+		txLogger.With(logging.OrdererKey, transactionResponse.Orderer).Debugf("orderer failed (%s)", transactionResponse.Err.Error())
 		return nil, errors.Wrap(transactionResponse.Err, "orderer failed")
 	}
 
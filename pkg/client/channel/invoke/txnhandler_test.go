@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package invoke
 
 import (
+	reqContext "context"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
 const (
@@ -86,6 +88,81 @@ func TestExecuteTxHandlerSuccess(t *testing.T) {
 	assert.Nil(t, requestContext.Error)
 }
 
+func TestExecuteTxHandlerWaitForCommit(t *testing.T) {
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+
+	mockPeer1 := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, MockCert: nil, MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+	mockPeer2 := &fcmocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", MockRoles: []string{}, MockCert: nil, MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+	mockPeer3 := &fcmocks.MockPeer{MockName: "Peer3", MockURL: "http://peer3.com", MockRoles: []string{}, MockCert: nil, MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+
+	// Wait on the endorsers
+	requestContext := prepareRequestContext(request, Opts{WaitForCommit: true}, t)
+	clientContext := setupChannelClientContext(nil, nil, []fab.Peer{mockPeer1, mockPeer2}, t)
+	mockEventHub := fcmocks.NewMockEventHub()
+	clientContext.EventHub = mockEventHub
+	ledger := fcmocks.NewMockChannelLedger()
+	clientContext.Ledger = ledger
+
+	go func() {
+		select {
+		case callback := <-mockEventHub.RegisteredTxCallbacks:
+			txID := requestContext.Response.TransactionID
+			ledger.CommitTransaction(mockPeer1, txID, pb.TxValidationCode_VALID)
+			callback(txID, pb.TxValidationCode_VALID, nil)
+			time.Sleep(100 * time.Millisecond)
+			ledger.CommitTransaction(mockPeer2, txID, pb.TxValidationCode_VALID)
+		case <-time.After(requestContext.Opts.Timeout):
+			t.Error("Execute handler : time out not expected")
+		}
+	}()
+
+	NewExecuteHandler().Handle(requestContext, clientContext)
+	assert.Nil(t, requestContext.Error)
+
+	// Wait on a peer that doesn't commit the transaction
+	requestContext = prepareRequestContext(request, Opts{WaitForCommit: true, WaitForCommitTargets: []fab.ProposalProcessor{mockPeer3}}, t)
+	requestContext.Opts.Timeout = time.Second
+	clientContext.EventHub = mockEventHub
+
+	go func() {
+		select {
+		case callback := <-mockEventHub.RegisteredTxCallbacks:
+			callback(requestContext.Response.TransactionID, pb.TxValidationCode_VALID, nil)
+		case <-time.After(requestContext.Opts.Timeout):
+			t.Error("Execute handler : time out not expected")
+		}
+	}()
+
+	NewExecuteHandler().Handle(requestContext, clientContext)
+	if requestContext.Error == nil || !strings.Contains(requestContext.Error.Error(), "http://peer3.com") {
+		t.Fatalf("expecting wait for commit to fail on peer3 but got: %v", requestContext.Error)
+	}
+
+	// The wait ends at the deadline of the request rather than after a new timeout
+	requestContext = prepareRequestContext(request, Opts{WaitForCommit: true, WaitForCommitTargets: []fab.ProposalProcessor{mockPeer3}}, t)
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), time.Second)
+	defer cancel()
+	requestContext.Ctx = ctx
+
+	go func() {
+		select {
+		case callback := <-mockEventHub.RegisteredTxCallbacks:
+			callback(requestContext.Response.TransactionID, pb.TxValidationCode_VALID, nil)
+		case <-time.After(requestContext.Opts.Timeout):
+			t.Error("Execute handler : time out not expected")
+		}
+	}()
+
+	start := time.Now()
+	NewExecuteHandler().Handle(requestContext, clientContext)
+	if requestContext.Error == nil {
+		t.Fatalf("expecting wait for commit to fail on peer3")
+	}
+	if elapsed := time.Since(start); elapsed >= requestContext.Opts.Timeout {
+		t.Fatalf("expecting wait for commit to end at the request deadline but it took %s", elapsed)
+	}
+}
+
 func TestQueryHandlerErrors(t *testing.T) {
 
 	//Error Scenario 1
@@ -16,7 +16,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/discovery/greylist")
 
 // Filter is a discovery filter that greylists certain peers that are
 // known to be down for the configured amount of time
@@ -60,6 +60,18 @@ func (b *Filter) Greylist(err error) {
 	}
 }
 
+// Len returns the number of peers currently greylisted
+func (b *Filter) Len() int {
+	n := 0
+	b.greylistURLs.Range(func(key, value interface{}) bool {
+		if timeAdded, ok := value.(time.Time); ok && timeAdded.Add(b.expiryInterval).After(time.Now()) {
+			n++
+		}
+		return true
+	})
+	return n
+}
+
 // required decides whether the given status error warrants a greylist
 // on the peer causing the error
 func required(s *status.Status) (bool, string) {
@@ -24,7 +24,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/selection/dynamicselection")
 
 const (
 	ccDataProviderSCC      = "lscc"
@@ -54,7 +54,7 @@ func newCCPolicyProvider(sdk *fabsdk.FabricSDK, channelID string, userName strin
 		return nil, errors.WithMessage(err, "unable to read configuration for channel peers")
 	}
 
-	return &ccPolicyProvider{config: sdk.Config(), client: client, channelID: channelID, targetPeers: targetPeers, ccDataMap: make(map[string]*ccprovider.ChaincodeData)}, nil
+	return &ccPolicyProvider{config: sdk.Config(), client: client, channelID: channelID, targetPeers: targetPeers, ccDataMap: make(map[string]*ccprovider.ChaincodeData), logger: logger.ForProvider(sdk.LoggerProvider())}, nil
 }
 
 type ccPolicyProvider struct {
@@ -64,6 +64,7 @@ type ccPolicyProvider struct {
 	targetPeers []core.ChannelPeer
 	ccDataMap   map[string]*ccprovider.ChaincodeData // TODO: Add expiry and configurable timeout for map entries
 	mutex       sync.RWMutex
+	logger      *logging.Logger
 }
 
 func (dp *ccPolicyProvider) GetChaincodePolicy(chaincodeID string) (*common.SignaturePolicyEnvelope, error) {
@@ -111,7 +112,7 @@ func unmarshalPolicy(policy []byte) (*common.SignaturePolicyEnvelope, error) {
 }
 
 func (dp *ccPolicyProvider) queryChaincode(ccID string, ccFcn string, ccArgs [][]byte) ([]byte, error) {
-	logger.Debugf("queryChaincode channelID:%s", dp.channelID)
+	dp.logger.Debugf("queryChaincode channelID:%s", dp.channelID)
 
 	var queryErrors []string
 	var response []byte
@@ -146,7 +147,7 @@ func (dp *ccPolicyProvider) queryChaincode(ccID string, ccFcn string, ccArgs [][
 			break
 		}
 	}
-	logger.Debugf("queryErrors: %v", queryErrors)
+	dp.logger.Debugf("queryErrors: %v", queryErrors)
 
 	// If all queries failed, return error
 	if len(queryErrors) == len(dp.targetPeers) {
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/pkg/errors"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/selection/dynamicselection/pgresolver"
@@ -49,6 +50,7 @@ type selectionService struct {
 	pgResolvers      map[string]pgresolver.PeerGroupResolver
 	pgLBP            pgresolver.LoadBalancePolicy
 	ccPolicyProvider CCPolicyProvider
+	logger           *logging.Logger
 }
 
 // Initialize allow for initializing providers
@@ -85,6 +87,7 @@ func (p *SelectionProvider) NewSelectionService(channelID string) (fab.Selection
 		pgResolvers:      make(map[string]pgresolver.PeerGroupResolver),
 		pgLBP:            p.lbp,
 		ccPolicyProvider: ccPolicyProvider,
+		logger:           logger.ForProvider(p.sdk.LoggerProvider()),
 	}, nil
 }
 
@@ -185,7 +188,7 @@ func (s *selectionService) getAvailablePeers(channelPeers []fab.Peer, mspID stri
 			str += ","
 		}
 	}
-	logger.Debugf("Available peers:\n%s\n", str)
+	s.logger.Debugf("Available peers:\n%s\n", str)
 
 	return peers
 }
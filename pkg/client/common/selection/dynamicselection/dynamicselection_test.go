@@ -229,6 +229,7 @@ func newMockSelectionService(ccPolicyProvider CCPolicyProvider, lbp pgresolver.L
 		ccPolicyProvider: ccPolicyProvider,
 		pgLBP:            lbp,
 		pgResolvers:      make(map[string]pgresolver.PeerGroupResolver),
+		logger:           logger,
 	}
 }
 
@@ -18,7 +18,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/selection/dynamicselection/pgresolver")
 
 type peerGroupResolver struct {
 	mspGroups []Group
@@ -13,7 +13,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/selection/staticselection")
 
 // SelectionProvider implements selection provider
 type SelectionProvider struct {
@@ -91,7 +91,7 @@ type SaveChannelRequest struct {
 //RequestOption func for each Opts argument
 type RequestOption func(opts *Opts) error
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/resmgmt")
 
 // Client enables managing resources in Fabric network.
 type Client struct {
@@ -301,7 +301,7 @@ func (rc *Client) isChaincodeInstalled(req InstallCCRequest, peer fab.Peer) (boo
 		return false, err
 	}
 
-	logger.Debugf("isChaincodeInstalled: %v", chaincodeQueryResponse)
+	rc.logger().Debugf("isChaincodeInstalled: %v", chaincodeQueryResponse)
 
 	for _, chaincode := range chaincodeQueryResponse.Chaincodes {
 		if chaincode.Name == req.Name && chaincode.Version == req.Version && chaincode.Path == req.Path {
@@ -376,7 +376,7 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 	icr := api.InstallChaincodeRequest{Name: req.Name, Path: req.Path, Version: req.Version, Package: req.Package, Targets: peer.PeersToTxnProcessors(newTargets)}
 	transactionProposalResponse, _, err := rc.resource.InstallChaincode(icr)
 	for _, v := range transactionProposalResponse {
-		logger.Debugf("Install chaincode '%s' endorser '%s' returned ProposalResponse status:%v", req.Name, v.Endorser, v.Status)
+		rc.logger().Debugf("Install chaincode '%s' endorser '%s' returned ProposalResponse status:%v", req.Name, v.Endorser, v.Status)
 
 		response := InstallCCResponse{Target: v.Endorser, Status: v.Status}
 		responses = append(responses, response)
@@ -504,7 +504,7 @@ func (rc *Client) sendCCProposal(ccProposalType channel.ChaincodeProposalType, c
 		Proposal:          tp,
 		ProposalResponses: txProposalResponse,
 	}
-	if _, err = createAndSendTransaction(transactor, transactionRequest); err != nil {
+	if _, err = rc.createAndSendTransaction(transactor, transactionRequest); err != nil {
 		return errors.WithMessage(err, "CreateAndSendTransaction failed")
 	}
 
@@ -549,7 +549,12 @@ func (rc *Client) prepareResmgmtOpts(options ...RequestOption) (Opts, error) {
 	return resmgmtOpts, nil
 }
 
-func createAndSendTransaction(sender fab.Sender, request fab.TransactionRequest) (*fab.TransactionResponse, error) {
+// logger returns the package logger bound to the logger provider of the client context
+func (rc *Client) logger() *logging.Logger {
+	return logger.ForProvider(rc.provider.LoggerProvider())
+}
+
+func (rc *Client) createAndSendTransaction(sender fab.Sender, request fab.TransactionRequest) (*fab.TransactionResponse, error) {
 
 	tx, err := sender.CreateTransaction(request)
 	if err != nil {
@@ -562,7 +567,7 @@ func createAndSendTransaction(sender fab.Sender, request fab.TransactionRequest)
 
 	}
 	if transactionResponse.Err != nil {
-		logger.Debugf("orderer %s failed (%s)", transactionResponse.Orderer, transactionResponse.Err.Error())
+		rc.logger().Debugf("orderer %s failed (%s)", transactionResponse.Orderer, transactionResponse.Err.Error())
 		return nil, errors.Wrap(transactionResponse.Err, "orderer failed")
 	}
 
@@ -591,7 +596,7 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 		return errors.New("must provide channel ID and channel config")
 	}
 
-	logger.Debugf("***** Saving channel: %s *****\n", req.ChannelID)
+	rc.logger().Debugf("***** Saving channel: %s *****\n", req.ChannelID)
 
 	// Signing user has to belong to one of configured channel organisations
 	// In case that order org is one of channel orgs we can use context user
@@ -641,7 +646,7 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 		return errors.Errorf("failed to retrieve orderer config: %s", err)
 	}
 
-	orderer, err := orderer.New(rc.provider.Config(), orderer.FromOrdererConfig(ordererCfg))
+	orderer, err := orderer.New(rc.provider.Config(), orderer.FromOrdererConfig(ordererCfg), orderer.WithLoggerProvider(rc.provider.LoggerProvider()))
 	if err != nil {
 		return errors.WithMessage(err, "failed to create new orderer from config")
 	}
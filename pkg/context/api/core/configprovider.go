@@ -46,6 +46,7 @@ type Config interface {
 	SecurityProviderLibPath() string
 	SecurityProviderPin() string
 	SecurityProviderLabel() string
+	SecurityProviderConfig(provider string) map[string]interface{}
 	KeyStorePath() string
 	CAKeyStorePath() string
 	CryptoConfigPath() string
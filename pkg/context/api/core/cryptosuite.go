@@ -63,6 +63,14 @@ type CryptoSuite interface {
 	Verify(k Key, signature, digest []byte, opts SignerOpts) (valid bool, err error)
 }
 
+// KeyDeleter is implemented by crypto suites whose key store supports removing keys
+type KeyDeleter interface {
+
+	// DeleteKey removes the key whose Subject Key Identifier is ski from the key store.
+	// Deleting a key that isn't in the key store isn't an error.
+	DeleteKey(ski []byte) error
+}
+
 // Key represents a cryptographic key
 type Key interface {
 
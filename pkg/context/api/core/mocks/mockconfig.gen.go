@@ -405,6 +405,18 @@ func (mr *MockConfigMockRecorder) SecurityProvider() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityProvider", reflect.TypeOf((*MockConfig)(nil).SecurityProvider))
 }
 
+// SecurityProviderConfig mocks base method
+func (m *MockConfig) SecurityProviderConfig(arg0 string) map[string]interface{} {
+	ret := m.ctrl.Call(m, "SecurityProviderConfig", arg0)
+	ret0, _ := ret[0].(map[string]interface{})
+	return ret0
+}
+
+// SecurityProviderConfig indicates an expected call of SecurityProviderConfig
+func (mr *MockConfigMockRecorder) SecurityProviderConfig(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityProviderConfig", reflect.TypeOf((*MockConfig)(nil).SecurityProviderConfig), arg0)
+}
+
 // SecurityProviderLabel mocks base method
 func (m *MockConfig) SecurityProviderLabel() string {
 	ret := m.ctrl.Call(m, "SecurityProviderLabel")
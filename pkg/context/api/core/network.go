@@ -10,6 +10,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"io/ioutil"
+	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
 	"github.com/pkg/errors"
@@ -37,6 +38,7 @@ type ClientConfig struct {
 	TLS             TLSType
 	TLSCerts        MutualTLSConfig
 	CredentialStore CredentialStoreType
+	SigningService  SigningServiceConfig
 }
 
 // LoggingType defines the level of logging
@@ -56,13 +58,41 @@ type TLSType struct {
 
 // CredentialStoreType defines pluggable KV store properties
 type CredentialStoreType struct {
+	// Type selects the store backend: "file" (default), "memory" or "sql"
+	Type        string
 	Path        string
+	Encryption  CredentialStoreEncryption
+	SQL         CredentialStoreSQL
 	CryptoStore struct {
 		Path string
 	}
+	// Wallet is the directory of a filesystem wallet holding identities by user name (optional)
 	Wallet string
 }
 
+// SigningServiceConfig defines a remote signing service holding the private keys of users (optional).
+// If URL is set then the default signing manager forwards digests signed with remote keys to the
+// service over HTTP(S), with mutual TLS if a client key pair is configured.
+type SigningServiceConfig struct {
+	URL      string
+	TLSCerts MutualTLSConfig
+	Timeout  time.Duration
+}
+
+// CredentialStoreEncryption defines the key used to encrypt values held by the credential store.
+// Key holds a base64-encoded AES key; KeyPath points to a file holding the same.
+type CredentialStoreEncryption struct {
+	Key     string
+	KeyPath string
+}
+
+// CredentialStoreSQL defines the database/sql connection used by the "sql" credential store
+type CredentialStoreSQL struct {
+	Driver     string
+	DataSource string
+	Table      string
+}
+
 // ChannelConfig provides the definition of channels for the network
 type ChannelConfig struct {
 	// Orderers list of ordering service nodes
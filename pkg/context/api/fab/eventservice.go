@@ -11,20 +11,30 @@ import (
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
+// DeliveryInfo contains the delivery information that is common to all events
+type DeliveryInfo struct {
+	// Skipped is the number of events that were dropped before this one because the
+	// consumer wasn't keeping up. It is only set if requested in the registration's consumer policy.
+	Skipped uint64
+}
+
 // BlockEvent contains the data for the block event
 type BlockEvent struct {
 	Block *cb.Block
+	DeliveryInfo
 }
 
 // FilteredBlockEvent contains the data for a filtered block event
 type FilteredBlockEvent struct {
 	FilteredBlock *pb.FilteredBlock
+	DeliveryInfo
 }
 
 // TxStatusEvent contains the data for a transaction status event
 type TxStatusEvent struct {
 	TxID             string
 	TxValidationCode pb.TxValidationCode
+	DeliveryInfo
 }
 
 // CCEvent contains the data for a chaincode event
@@ -32,6 +42,30 @@ type CCEvent struct {
 	TxID        string
 	ChaincodeID string
 	EventName   string
+	// Payload is the event payload. It is only available if the event source delivers full blocks.
+	Payload []byte
+	// BlockNumber is the number of the block that contains the transaction
+	BlockNumber uint64
+	// TxValidationCode is the validation code of the transaction that emitted the event.
+	// Unless the registration includes invalid transactions, it is always VALID.
+	TxValidationCode pb.TxValidationCode
+	DeliveryInfo
+}
+
+// AnyChaincode may be given as a chaincode ID in a ChaincodeEventFilter to match events of all chaincodes
+const AnyChaincode = "*"
+
+// ChaincodeEventFilter selects the chaincode events that are delivered to a registration
+type ChaincodeEventFilter struct {
+	// ChaincodeIDs are the IDs of the chaincodes whose events are wanted (or AnyChaincode)
+	ChaincodeIDs []string
+	// EventFilter is a regular expression that the event name must match
+	EventFilter string
+	// IncludeInvalid also delivers the events of transactions that failed validation
+	IncludeInvalid bool
+	// PayloadFilter, if set, is called with the event payload and the event is only delivered
+	// if it returns true. The payload is nil if the event source delivers filtered blocks.
+	PayloadFilter func(payload []byte) bool
 }
 
 // Registration is a handle that is returned from a successful RegisterXXXEvent.
@@ -67,6 +101,13 @@ type EventService interface {
 	//   is closed when Unregister is called.
 	RegisterChaincodeEvent(ccID, eventFilter string) (Registration, <-chan *CCEvent, error)
 
+	// RegisterChaincodeEventWithFilter registers for chaincode events selected by the given filter.
+	// Note that Unregister must be called when the registration is no longer needed.
+	// - filter selects the events by chaincode, event name, transaction validity and payload
+	// - Returns the registration and a channel that is used to receive events. The channel
+	//   is closed when Unregister is called.
+	RegisterChaincodeEventWithFilter(filter ChaincodeEventFilter) (Registration, <-chan *CCEvent, error)
+
 	// RegisterTxStatusEvent registers for transaction status events.
 	// Note that Unregister must be called when the registration is no longer needed.
 	// - txID is the transaction ID for which events are to be received
@@ -18,6 +18,7 @@ type Orderer interface {
 	URL() string
 	SendBroadcast(envelope *SignedEnvelope) (*common.Status, error)
 	SendDeliver(envelope *SignedEnvelope) (chan *common.Block, chan error, context.CancelFunc)
+	SendDeliverContext(ctx context.Context, envelope *SignedEnvelope) (chan *common.Block, chan error, context.CancelFunc)
 }
 
 // A SignedEnvelope can can be sent to an orderer for broadcasting
@@ -9,6 +9,9 @@ package context
 import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	metricsApi "github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+	tracingApi "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
 )
 
 // IdentityContext supplies the serialized identity and key reference.
@@ -36,4 +39,12 @@ type ProviderContext interface {
 	SigningManager() api.SigningManager
 	Config() core.Config
 	CryptoSuite() core.CryptoSuite
+	// LoggerProvider returns the logger provider scoped to the SDK instance,
+	// or nil to use the process-wide logger provider
+	LoggerProvider() logApi.LoggerProvider
+	// MetricsProvider returns the metrics provider of the SDK instance, or nil
+	// if metrics are disabled
+	MetricsProvider() metricsApi.Provider
+	// Tracer returns the tracer of the SDK instance, or nil if tracing is disabled
+	Tracer() tracingApi.Tracer
 }
@@ -0,0 +1,249 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/pkg/errors"
+)
+
+// SecurityConfig holds the client.BCCSP.security settings
+type SecurityConfig struct {
+	Enabled       bool
+	HashAlgorithm string
+	Level         int
+	Provider      string
+	Ephemeral     bool
+	SoftVerify    bool
+	// Library, Pin and Label are used by the PKCS11 provider
+	Library string
+	Pin     string
+	Label   string
+}
+
+// NetworkBuilder assembles a network configuration in code, for applications that
+// discover their network at runtime instead of reading it from a config file.
+//
+// Names of organizations, peers, orderers, CAs and channels are case insensitive,
+// as they are when loaded from a file. The network configuration passed to the builder
+// is used as is; options passed to Build (overlays, overrides, environment variables)
+// apply only to the remaining client settings (timeouts, BCCSP, stores, logging).
+type NetworkBuilder struct {
+	networkConfig core.NetworkConfig
+	settings      map[string]interface{}
+	err           error
+}
+
+// NewNetworkBuilder returns a builder for the named network
+func NewNetworkBuilder(name string) *NetworkBuilder {
+	return &NetworkBuilder{
+		networkConfig: core.NetworkConfig{
+			Name:                   name,
+			Channels:               make(map[string]core.ChannelConfig),
+			Organizations:          make(map[string]core.OrganizationConfig),
+			Orderers:               make(map[string]core.OrdererConfig),
+			Peers:                  make(map[string]core.PeerConfig),
+			CertificateAuthorities: make(map[string]core.CAConfig),
+		},
+		settings: make(map[string]interface{}),
+	}
+}
+
+// WithDescription sets the network description and version
+func (b *NetworkBuilder) WithDescription(description, version string) *NetworkBuilder {
+	b.networkConfig.Description = description
+	b.networkConfig.Version = version
+	return b
+}
+
+// WithClient sets the client configuration: the organization the client belongs to,
+// logging level, crypto config path, TLS client certs and credential store.
+func (b *NetworkBuilder) WithClient(client core.ClientConfig) *NetworkBuilder {
+	b.networkConfig.Client = client
+
+	// settings read directly by Config rather than through NetworkConfig
+	b.setOrDelete("client.organization", client.Organization)
+	b.setOrDelete("client.logging.level", client.Logging.Level)
+	b.setOrDelete("client.cryptoconfig.path", client.CryptoConfig.Path)
+	b.setOrDelete("client.credentialStore.path", client.CredentialStore.Path)
+	b.setOrDelete("client.credentialStore.cryptoStore.path", client.CredentialStore.CryptoStore.Path)
+	return b
+}
+
+// WithOrganization adds an organization, replacing any previously added under the same name
+func (b *NetworkBuilder) WithOrganization(name string, org core.OrganizationConfig) *NetworkBuilder {
+	if b.checkName("organization", name) {
+		b.networkConfig.Organizations[strings.ToLower(name)] = org
+	}
+	return b
+}
+
+// WithPeer adds a peer, replacing any previously added under the same name.
+// The peer must also be listed in its organization's Peers.
+func (b *NetworkBuilder) WithPeer(name string, peer core.PeerConfig) *NetworkBuilder {
+	if b.checkName("peer", name) {
+		b.networkConfig.Peers[strings.ToLower(name)] = peer
+	}
+	return b
+}
+
+// WithOrderer adds an orderer, replacing any previously added under the same name
+func (b *NetworkBuilder) WithOrderer(name string, orderer core.OrdererConfig) *NetworkBuilder {
+	if b.checkName("orderer", name) {
+		b.networkConfig.Orderers[strings.ToLower(name)] = orderer
+	}
+	return b
+}
+
+// WithCertificateAuthority adds a CA, replacing any previously added under the same name
+func (b *NetworkBuilder) WithCertificateAuthority(name string, ca core.CAConfig) *NetworkBuilder {
+	if b.checkName("certificate authority", name) {
+		b.networkConfig.CertificateAuthorities[strings.ToLower(name)] = ca
+	}
+	return b
+}
+
+// WithChannel adds a channel, replacing any previously added under the same name
+func (b *NetworkBuilder) WithChannel(name string, channel core.ChannelConfig) *NetworkBuilder {
+	if !b.checkName("channel", name) {
+		return b
+	}
+
+	peers := make(map[string]core.PeerChannelConfig, len(channel.Peers))
+	for peer, peerChannelConfig := range channel.Peers {
+		peers[strings.ToLower(peer)] = peerChannelConfig
+	}
+	channel.Peers = peers
+	b.networkConfig.Channels[strings.ToLower(name)] = channel
+	return b
+}
+
+// WithTimeout sets the timeout for the given connection type
+func (b *NetworkBuilder) WithTimeout(conn core.TimeoutType, timeout time.Duration) *NetworkBuilder {
+	key, ok := timeoutKeys[conn]
+	if !ok {
+		b.setError(errors.Errorf("unsupported timeout type %d", conn))
+		return b
+	}
+	b.settings[key] = timeout
+	return b
+}
+
+// WithSecurity sets the BCCSP settings
+func (b *NetworkBuilder) WithSecurity(security SecurityConfig) *NetworkBuilder {
+	b.settings["client.BCCSP.security.enabled"] = security.Enabled
+	b.settings["client.BCCSP.security.hashAlgorithm"] = security.HashAlgorithm
+	b.settings["client.BCCSP.security.level"] = security.Level
+	b.settings["client.BCCSP.security.default.provider"] = security.Provider
+	b.settings["client.BCCSP.security.ephemeral"] = security.Ephemeral
+	b.settings["client.BCCSP.security.softVerify"] = security.SoftVerify
+	b.setOrDelete("client.BCCSP.security.library", security.Library)
+	b.setOrDelete("client.BCCSP.security.pin", security.Pin)
+	b.setOrDelete("client.BCCSP.security.label", security.Label)
+	return b
+}
+
+// WithSecurityProviderConfig sets the provider-specific settings returned by SecurityProviderConfig
+func (b *NetworkBuilder) WithSecurityProviderConfig(provider string, providerConfig map[string]interface{}) *NetworkBuilder {
+	if b.checkName("security provider", provider) {
+		b.settings["client.BCCSP.security.providers."+strings.ToLower(provider)] = providerConfig
+	}
+	return b
+}
+
+// WithSystemCertPool sets whether the system cert pool is used to verify TLS peers
+func (b *NetworkBuilder) WithSystemCertPool(enabled bool) *NetworkBuilder {
+	b.settings["client.tlsCerts.systemCertPool"] = enabled
+	return b
+}
+
+// Build returns a provider for a Config holding what has been added to the builder.
+// Each call to the provider returns a new Config; later changes to the builder
+// do not affect configs already built.
+func (b *NetworkBuilder) Build(opts ...Option) core.ConfigProvider {
+	networkConfig := b.copyNetworkConfig()
+	settings := make(map[string]interface{}, len(b.settings))
+	for key, value := range b.settings {
+		settings[key] = value
+	}
+	err := b.err
+
+	return func() (core.Config, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := newConfig(opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		// defaults have the lowest precedence, so overlays, overrides and
+		// environment variables can still change these settings
+		for key, value := range settings {
+			c.configViper.SetDefault(key, value)
+		}
+
+		nc := networkConfig
+		c.networkConfig = &nc
+		c.networkConfigCached = true
+
+		return initConfig(c)
+	}
+}
+
+func (b *NetworkBuilder) copyNetworkConfig() core.NetworkConfig {
+	nc := b.networkConfig
+
+	nc.Channels = make(map[string]core.ChannelConfig, len(b.networkConfig.Channels))
+	for k, v := range b.networkConfig.Channels {
+		nc.Channels[k] = v
+	}
+	nc.Organizations = make(map[string]core.OrganizationConfig, len(b.networkConfig.Organizations))
+	for k, v := range b.networkConfig.Organizations {
+		nc.Organizations[k] = v
+	}
+	nc.Orderers = make(map[string]core.OrdererConfig, len(b.networkConfig.Orderers))
+	for k, v := range b.networkConfig.Orderers {
+		nc.Orderers[k] = v
+	}
+	nc.Peers = make(map[string]core.PeerConfig, len(b.networkConfig.Peers))
+	for k, v := range b.networkConfig.Peers {
+		nc.Peers[k] = v
+	}
+	nc.CertificateAuthorities = make(map[string]core.CAConfig, len(b.networkConfig.CertificateAuthorities))
+	for k, v := range b.networkConfig.CertificateAuthorities {
+		nc.CertificateAuthorities[k] = v
+	}
+	return nc
+}
+
+func (b *NetworkBuilder) checkName(what, name string) bool {
+	if name == "" {
+		b.setError(errors.Errorf("%s name is required", what))
+		return false
+	}
+	return true
+}
+
+// setError records the first error, which is returned when the config is built
+func (b *NetworkBuilder) setError(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *NetworkBuilder) setOrDelete(key string, value string) {
+	if value == "" {
+		delete(b.settings, key)
+		return
+	}
+	b.settings[key] = value
+}
@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+)
+
+const (
+	builderPeerTLSCACert    = "${GOPATH}/src/github.com/hyperledger/fabric-sdk-go/${CRYPTOCONFIG_FIXTURES_PATH}/peerOrganizations/org1.example.com/tlsca/tlsca.org1.example.com-cert.pem"
+	builderOrdererTLSCACert = "${GOPATH}/src/github.com/hyperledger/fabric-sdk-go/${CRYPTOCONFIG_FIXTURES_PATH}/ordererOrganizations/example.com/tlsca/tlsca.example.com-cert.pem"
+)
+
+func newTestNetworkBuilder() *NetworkBuilder {
+	return NewNetworkBuilder("runtime-network").
+		WithClient(core.ClientConfig{
+			Organization: "Org1",
+			Logging:      core.LoggingType{Level: "info"},
+			CryptoConfig: core.CCType{Path: "/tmp/crypto-config"},
+			CredentialStore: core.CredentialStoreType{
+				Path:        "/tmp/state-store",
+				CryptoStore: struct{ Path string }{Path: "/tmp/msp"},
+			},
+		}).
+		WithOrganization("Org1", core.OrganizationConfig{
+			MspID: "Org1MSP",
+			Peers: []string{"peer0.org1.example.com"},
+		}).
+		WithPeer("peer0.org1.example.com", core.PeerConfig{
+			URL:         "grpcs://peer0.org1.example.com:7051",
+			EventURL:    "grpcs://peer0.org1.example.com:7053",
+			GRPCOptions: map[string]interface{}{"ssl-target-name-override": "peer0.org1.example.com"},
+			TLSCACerts:  core.TLSConfig{Path: builderPeerTLSCACert},
+		}).
+		WithOrderer("orderer.example.com", core.OrdererConfig{
+			URL:        "grpcs://orderer.example.com:7050",
+			TLSCACerts: core.TLSConfig{Path: builderOrdererTLSCACert},
+		}).
+		WithChannel("MyChannel", core.ChannelConfig{
+			Orderers: []string{"orderer.example.com"},
+			Peers: map[string]core.PeerChannelConfig{
+				"Peer0.Org1.example.com": {EndorsingPeer: true, EventSource: true},
+			},
+		}).
+		WithTimeout(core.Execute, 90*time.Second).
+		WithSecurity(SecurityConfig{
+			Enabled:       true,
+			HashAlgorithm: "SHA2",
+			Level:         256,
+			Provider:      "SW",
+			SoftVerify:    true,
+		}).
+		WithSecurityProviderConfig("PKCS11", map[string]interface{}{"sessioncachesize": 20})
+}
+
+func TestNetworkBuilder(t *testing.T) {
+	c, err := newTestNetworkBuilder().Build(WithValidation())()
+	if err != nil {
+		t.Fatalf("Failed to build config: %s", err)
+	}
+
+	netConfig, err := c.NetworkConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if netConfig.Name != "runtime-network" {
+		t.Fatalf("Unexpected network name: %s", netConfig.Name)
+	}
+
+	peers, err := c.PeersConfig("org1")
+	if err != nil || len(peers) != 1 || peers[0].URL != "grpcs://peer0.org1.example.com:7051" {
+		t.Fatalf("Unexpected peers for org1: %v, %v", peers, err)
+	}
+
+	chPeers, err := c.ChannelPeers("mychannel")
+	if err != nil || len(chPeers) != 1 || chPeers[0].MspID != "Org1MSP" || !chPeers[0].EndorsingPeer {
+		t.Fatalf("Unexpected channel peers: %v, %v", chPeers, err)
+	}
+
+	orderers, err := c.ChannelOrderers("MyChannel")
+	if err != nil || len(orderers) != 1 {
+		t.Fatalf("Unexpected channel orderers: %v, %v", orderers, err)
+	}
+
+	if c.TimeoutOrDefault(core.Execute) != 90*time.Second {
+		t.Fatalf("Unexpected execute timeout: %s", c.TimeoutOrDefault(core.Execute))
+	}
+	if c.TimeoutOrDefault(core.Query) != defaultTimeout {
+		t.Fatalf("Expected default query timeout, got %s", c.TimeoutOrDefault(core.Query))
+	}
+
+	if !c.IsSecurityEnabled() || c.SecurityLevel() != 256 || c.SecurityAlgorithm() != "SHA2" || c.SecurityProvider() != "SW" || !c.SoftVerify() {
+		t.Fatal("Unexpected security settings")
+	}
+	if c.SecurityProviderConfig("pkcs11")["sessioncachesize"] != 20 {
+		t.Fatalf("Unexpected security provider config: %v", c.SecurityProviderConfig("pkcs11"))
+	}
+
+	if c.CredentialStorePath() != "/tmp/state-store" || c.KeyStorePath() != "/tmp/msp/keystore" || c.CryptoConfigPath() != "/tmp/crypto-config" {
+		t.Fatal("Unexpected store paths")
+	}
+
+	mspID, err := c.MspID("Org1")
+	if err != nil || mspID != "Org1MSP" {
+		t.Fatalf("Unexpected MSP ID: %s, %v", mspID, err)
+	}
+}
+
+func TestNetworkBuilderEnvironmentOverride(t *testing.T) {
+	os.Setenv("FABRIC_SDK_CLIENT_BCCSP_SECURITY_LEVEL", "384")
+	defer os.Unsetenv("FABRIC_SDK_CLIENT_BCCSP_SECURITY_LEVEL")
+
+	c, err := newTestNetworkBuilder().Build()()
+	if err != nil {
+		t.Fatalf("Failed to build config: %s", err)
+	}
+	if c.SecurityLevel() != 384 {
+		t.Fatalf("Expected environment to override built security level, got %d", c.SecurityLevel())
+	}
+}
+
+func TestNetworkBuilderIsolation(t *testing.T) {
+	b := newTestNetworkBuilder()
+	provider := b.Build()
+
+	b.WithPeer("peer1.org1.example.com", core.PeerConfig{URL: "grpc://peer1.org1.example.com:7051"})
+
+	c, err := provider()
+	if err != nil {
+		t.Fatalf("Failed to build config: %s", err)
+	}
+	netConfig, err := c.NetworkConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(netConfig.Peers) != 1 {
+		t.Fatal("Expected config not to be affected by changes made to the builder after Build")
+	}
+}
+
+func TestNetworkBuilderErrors(t *testing.T) {
+	_, err := NewNetworkBuilder("n").WithPeer("", core.PeerConfig{}).Build()()
+	if err == nil {
+		t.Fatal("Expected error for empty peer name")
+	}
+
+	_, err = NewNetworkBuilder("n").WithTimeout(core.TimeoutType(-1), time.Second).Build()()
+	if err == nil {
+		t.Fatal("Expected error for unsupported timeout type")
+	}
+
+	_, err = newTestNetworkBuilder().
+		WithChannel("orgchannel", core.ChannelConfig{Orderers: []string{"orderer2.example.com"}}).
+		Build(WithValidation())()
+	if err == nil {
+		t.Fatal("Expected validation error for undefined orderer")
+	}
+}
@@ -31,7 +31,7 @@ import (
 	cs "github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
 )
 
-var logger = logging.NewLogger(logModule)
+var logger = logging.NewLogger("fabric_sdk_go/config")
 
 const (
 	cmdRoot        = "FABRIC_SDK"
@@ -39,6 +39,18 @@ const (
 	defaultTimeout = time.Second * 5
 )
 
+// timeoutKeys maps connection types to the config keys holding their timeouts
+var timeoutKeys = map[core.TimeoutType]string{
+	core.Endorser:                "client.peer.timeout.connection",
+	core.Query:                   "client.peer.timeout.queryResponse",
+	core.Execute:                 "client.peer.timeout.executeTxResponse",
+	core.DiscoveryGreylistExpiry: "client.peer.timeout.discovery.greylistExpiry",
+	core.EventHubConnection:      "client.eventService.timeout.connection",
+	core.EventReg:                "client.eventService.timeout.registrationResponse",
+	core.OrdererConnection:       "client.orderer.timeout.connection",
+	core.OrdererResponse:         "client.orderer.timeout.response",
+}
+
 // Config represents the configuration for the client
 type Config struct {
 	tlsCertPool         *x509.CertPool
@@ -52,6 +64,9 @@ type options struct {
 	envPrefix    string
 	templatePath string
 	template     *Config
+	overlays     []overlay
+	overrides    map[string]interface{}
+	validate     bool
 }
 
 // Option configures the package.
@@ -198,6 +213,10 @@ func newViper(cmdRootPrefix string) *viper.Viper {
 }
 
 func initConfig(c *Config) (*Config, error) {
+	if err := c.applyLayers(); err != nil {
+		return nil, err
+	}
+
 	setLogLevel(c.configViper)
 	tlsCertPool, err := getCertPool(c.configViper)
 	if err != nil {
@@ -205,8 +224,17 @@ func initConfig(c *Config) (*Config, error) {
 	}
 	c.tlsCertPool = tlsCertPool
 
-	if err = c.cacheNetworkConfiguration(); err != nil {
-		return nil, errors.WithMessage(err, "network configuration load failed")
+	// a network config supplied in code (see NetworkBuilder) is used as is
+	if !c.networkConfigCached {
+		if err = c.cacheNetworkConfiguration(); err != nil {
+			return nil, errors.WithMessage(err, "network configuration load failed")
+		}
+	}
+
+	if c.opts.validate {
+		if err := c.Validate(); err != nil {
+			return nil, errors.WithMessage(err, "network configuration validation failed")
+		}
 	}
 
 	logger.Infof("config %s logging level is set to: %s", logModule, loglevel.ParseString(logging.GetLevel(logModule)))
@@ -268,6 +296,13 @@ func (c *Config) Client() (*core.ClientConfig, error) {
 	client.TLSCerts.Path = substPathVars(client.TLSCerts.Path)
 	client.TLSCerts.Client.Key.Path = substPathVars(client.TLSCerts.Client.Key.Path)
 	client.TLSCerts.Client.Cert.Path = substPathVars(client.TLSCerts.Client.Cert.Path)
+	client.CredentialStore.Path = substPathVars(client.CredentialStore.Path)
+	client.CredentialStore.CryptoStore.Path = substPathVars(client.CredentialStore.CryptoStore.Path)
+	client.CredentialStore.Encryption.KeyPath = substPathVars(client.CredentialStore.Encryption.KeyPath)
+	client.CredentialStore.Wallet = substPathVars(client.CredentialStore.Wallet)
+	client.SigningService.TLSCerts.Path = substPathVars(client.SigningService.TLSCerts.Path)
+	client.SigningService.TLSCerts.Client.Key.Path = substPathVars(client.SigningService.TLSCerts.Client.Key.Path)
+	client.SigningService.TLSCerts.Client.Cert.Path = substPathVars(client.SigningService.TLSCerts.Client.Cert.Path)
 
 	return &client, nil
 }
@@ -441,24 +476,8 @@ func (c *Config) CAClientCertPem(org string) (string, error) {
 // TimeoutOrDefault reads connection timeouts for the given connection type
 func (c *Config) TimeoutOrDefault(conn core.TimeoutType) time.Duration {
 	var timeout time.Duration
-	switch conn {
-	case core.Endorser:
-		timeout = c.configViper.GetDuration("client.peer.timeout.connection")
-	case core.Query:
-		timeout = c.configViper.GetDuration("client.peer.timeout.queryResponse")
-	case core.Execute:
-		timeout = c.configViper.GetDuration("client.peer.timeout.executeTxResponse")
-	case core.DiscoveryGreylistExpiry:
-		timeout = c.configViper.GetDuration("client.peer.timeout.discovery.greylistExpiry")
-	case core.EventHubConnection:
-		timeout = c.configViper.GetDuration("client.eventService.timeout.connection")
-	case core.EventReg:
-		timeout = c.configViper.GetDuration("client.eventService.timeout.registrationResponse")
-	case core.OrdererConnection:
-		timeout = c.configViper.GetDuration("client.orderer.timeout.connection")
-	case core.OrdererResponse:
-		timeout = c.configViper.GetDuration("client.orderer.timeout.response")
-
+	if key, ok := timeoutKeys[conn]; ok {
+		timeout = c.configViper.GetDuration(key)
 	}
 	if timeout == 0 {
 		timeout = defaultTimeout
@@ -883,6 +902,12 @@ func (c *Config) SecurityProviderLabel() string {
 	return c.configViper.GetString("client.BCCSP.security.label")
 }
 
+// SecurityProviderConfig returns the provider-specific configuration of a
+// security provider, found under client.BCCSP.security.providers.<provider>
+func (c *Config) SecurityProviderConfig(provider string) map[string]interface{} {
+	return c.configViper.GetStringMap("client.BCCSP.security.providers." + provider)
+}
+
 // CredentialStorePath returns the user store path
 func (c *Config) CredentialStorePath() string {
 	return substPathVars(c.configViper.GetString("client.credentialStore.path"))
@@ -534,6 +534,33 @@ func TestFromRawSuccess(t *testing.T) {
 	}
 }
 
+func TestSecurityProviderConfig(t *testing.T) {
+	raw := []byte(`
+client:
+  BCCSP:
+    security:
+      default:
+        provider: "Vault"
+      providers:
+        vault:
+          address: "https://vault.example.com:8200"
+          mount: "transit"
+`)
+	c, err := FromRaw(raw, configType)()
+	if err != nil {
+		t.Fatalf("Failed to initialize config from bytes array. Error: %s", err)
+	}
+
+	providerConfig := c.SecurityProviderConfig(c.SecurityProvider())
+	if providerConfig["address"] != "https://vault.example.com:8200" || providerConfig["mount"] != "transit" {
+		t.Fatalf("Unexpected security provider config: %v", providerConfig)
+	}
+
+	if len(c.SecurityProviderConfig("SW")) != 0 {
+		t.Fatal("Expected empty config for unconfigured security provider")
+	}
+}
+
 func TestFromReaderSuccess(t *testing.T) {
 	// get a config byte for testing
 	cBytes, err := loadConfigBytesFromFile(t, configTestFilePath)
@@ -0,0 +1,173 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Configuration is assembled from layers, each one merged over the previous:
+//
+//   1. the base source given to FromFile, FromReader or FromRaw
+//   2. overlays, in the order they were passed (WithOverlayFile, WithOverlayReader, WithOverlayRaw)
+//   3. in-code overrides (WithOverrides)
+//   4. environment variables (see WithEnvPrefix)
+//
+// Maps are merged key by key; any other value (including lists) from a later layer
+// replaces the value from an earlier one.
+
+// overlay is a configuration source merged over the base configuration
+type overlay struct {
+	name       string
+	in         io.Reader
+	configType string
+}
+
+// WithOverlayFile merges the named config file over the base configuration,
+// e.g. an environment-specific file on top of a shared network definition.
+// The config type is taken from the file extension.
+func WithOverlayFile(name string) Option {
+	return func(opts *options) error {
+		if name == "" {
+			return errors.New("overlay filename is required")
+		}
+		opts.overlays = append(opts.overlays, overlay{name: name})
+		return nil
+	}
+}
+
+// WithOverlayReader merges configuration read from in over the base configuration.
+// configType can be "json" or "yaml".
+func WithOverlayReader(in io.Reader, configType string) Option {
+	return func(opts *options) error {
+		if configType == "" {
+			return errors.New("empty overlay config type")
+		}
+		opts.overlays = append(opts.overlays, overlay{in: in, configType: configType})
+		return nil
+	}
+}
+
+// WithOverlayRaw merges configBytes over the base configuration.
+// configType can be "json" or "yaml".
+func WithOverlayRaw(configBytes []byte, configType string) Option {
+	return WithOverlayReader(bytes.NewReader(configBytes), configType)
+}
+
+// WithOverrides sets configuration values in code. Keys are dot-separated paths
+// (e.g. "client.logging.level"); values may themselves be maps. Overrides are applied
+// after all overlays, environment variables still take precedence.
+// The option may be passed more than once, later values win.
+func WithOverrides(values map[string]interface{}) Option {
+	return func(opts *options) error {
+		if opts.overrides == nil {
+			opts.overrides = make(map[string]interface{})
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		// shorter paths first so that "a.b.c" refines rather than gets replaced by "a.b"
+		sort.Strings(keys)
+		for _, key := range keys {
+			if key == "" {
+				return errors.New("override key is required")
+			}
+			setPath(opts.overrides, strings.Split(strings.ToLower(key), "."), values[key])
+		}
+		return nil
+	}
+}
+
+// WithValidation makes loading fail if Validate reports any problem with the configuration
+func WithValidation() Option {
+	return func(opts *options) error {
+		opts.validate = true
+		return nil
+	}
+}
+
+// applyLayers merges overlays and overrides over the base configuration
+func (c *Config) applyLayers() error {
+	for _, o := range c.opts.overlays {
+		if err := c.mergeOverlay(o); err != nil {
+			return err
+		}
+	}
+
+	if len(c.opts.overrides) == 0 {
+		return nil
+	}
+
+	// overrides are merged as another config layer rather than with viper.Set,
+	// which would take precedence over environment variables
+	raw, err := json.Marshal(c.opts.overrides)
+	if err != nil {
+		return errors.Wrap(err, "marshal of config overrides failed")
+	}
+	c.configViper.SetConfigType("json")
+	if err := c.configViper.MergeConfig(bytes.NewReader(raw)); err != nil {
+		return errors.Wrap(err, "merging config overrides failed")
+	}
+	return nil
+}
+
+func (c *Config) mergeOverlay(o overlay) error {
+	in := o.in
+	configType := o.configType
+	if o.name != "" {
+		f, err := os.Open(substPathVars(o.name))
+		if err != nil {
+			return errors.Wrap(err, "loading config overlay file failed")
+		}
+		defer f.Close()
+
+		in = f
+		configType = strings.TrimPrefix(filepath.Ext(o.name), ".")
+		logger.Debugf("Using config overlay file: %s", o.name)
+	}
+
+	c.configViper.SetConfigType(configType)
+	if err := c.configViper.MergeConfig(in); err != nil {
+		if o.name != "" {
+			return errors.Wrapf(err, "merging config overlay %s failed", o.name)
+		}
+		return errors.Wrap(err, "merging config overlay failed")
+	}
+	return nil
+}
+
+// setPath sets value at path within m, creating intermediate maps as needed
+func setPath(m map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[key] = next
+		}
+		m = next
+	}
+
+	last := path[len(path)-1]
+	if values, ok := value.(map[string]interface{}); ok {
+		if existing, ok := m[last].(map[string]interface{}); ok {
+			for k, v := range values {
+				setPath(existing, []string{strings.ToLower(k)}, v)
+			}
+			return
+		}
+	}
+	m[last] = value
+}
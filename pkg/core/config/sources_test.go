@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const layerBaseConfig = `
+client:
+  organization: Org1
+  logging:
+    level: info
+  peer:
+    timeout:
+      connection: 3s
+      queryResponse: 45s
+peers:
+  peer0.org1.example.com:
+    url: grpc://peer0.org1.example.com:7051
+    eventUrl: grpc://peer0.org1.example.com:7053
+`
+
+const layerOverlayConfig = `
+client:
+  peer:
+    timeout:
+      connection: 10s
+peers:
+  peer0.org1.example.com:
+    url: grpc://peer0.staging.example.com:7051
+`
+
+func TestOverlayAndOverrides(t *testing.T) {
+	c, err := FromRaw([]byte(layerBaseConfig), configType,
+		WithOverlayRaw([]byte(layerOverlayConfig), configType),
+		WithOverrides(map[string]interface{}{
+			"client.peer.timeout.queryResponse": "90s",
+			"client.organization":               "Org2",
+		}),
+	)()
+	if err != nil {
+		t.Fatalf("Failed to load layered config: %s", err)
+	}
+	v := c.(*Config).configViper
+
+	// overlay replaces leaves and merges maps
+	if v.GetString("client.peer.timeout.connection") != "10s" {
+		t.Fatalf("Expected overlay to set connection timeout, got %s", v.GetString("client.peer.timeout.connection"))
+	}
+	if v.GetString("client.logging.level") != "info" {
+		t.Fatal("Expected base value to survive overlay")
+	}
+
+	netConfig, err := c.NetworkConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := netConfig.Peers["peer0.org1.example.com"]
+	if p.URL != "grpc://peer0.staging.example.com:7051" || p.EventURL != "grpc://peer0.org1.example.com:7053" {
+		t.Fatalf("Unexpected peer config after overlay: %+v", p)
+	}
+
+	// overrides are applied after overlays
+	if v.GetString("client.peer.timeout.queryResponse") != "90s" {
+		t.Fatalf("Expected override to set query timeout, got %s", v.GetString("client.peer.timeout.queryResponse"))
+	}
+	if netConfig.Client.Organization != "Org2" {
+		t.Fatalf("Expected override to set client organization, got %s", netConfig.Client.Organization)
+	}
+}
+
+func TestEnvironmentOverridesLayers(t *testing.T) {
+	os.Setenv("FABRIC_SDK_CLIENT_LOGGING_LEVEL", "debug")
+	defer os.Unsetenv("FABRIC_SDK_CLIENT_LOGGING_LEVEL")
+
+	c, err := FromRaw([]byte(layerBaseConfig), configType,
+		WithOverrides(map[string]interface{}{"client.logging.level": "warning"}),
+	)()
+	if err != nil {
+		t.Fatalf("Failed to load layered config: %s", err)
+	}
+
+	if level := c.(*Config).configViper.GetString("client.logging.level"); level != "debug" {
+		t.Fatalf("Expected environment to take precedence over overrides, got %s", level)
+	}
+}
+
+func TestOverlayFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "staging.yaml")
+	if err = ioutil.WriteFile(name, []byte(layerOverlayConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := FromRaw([]byte(layerBaseConfig), configType, WithOverlayFile(name))()
+	if err != nil {
+		t.Fatalf("Failed to load layered config: %s", err)
+	}
+	if c.(*Config).configViper.GetString("client.peer.timeout.connection") != "10s" {
+		t.Fatal("Expected overlay file to be merged")
+	}
+
+	_, err = FromRaw([]byte(layerBaseConfig), configType, WithOverlayFile(filepath.Join(dir, "missing.yaml")))()
+	if err == nil {
+		t.Fatal("Expected error for missing overlay file")
+	}
+
+	_, err = FromRaw([]byte(layerBaseConfig), configType, WithOverlayFile(""))()
+	if err == nil {
+		t.Fatal("Expected error for empty overlay file name")
+	}
+}
+
+func TestOverridesNestedValues(t *testing.T) {
+	c, err := FromRaw([]byte(layerBaseConfig), configType,
+		WithOverrides(map[string]interface{}{
+			"client.peer":                   map[string]interface{}{"timeout": map[string]interface{}{"connection": "7s"}},
+			"client.peer.timeout.discovery": "1s",
+		}),
+	)()
+	if err != nil {
+		t.Fatalf("Failed to load layered config: %s", err)
+	}
+
+	v := c.(*Config).configViper
+	if v.GetString("client.peer.timeout.connection") != "7s" || v.GetString("client.peer.timeout.discovery") != "1s" {
+		t.Fatalf("Unexpected peer timeouts: %v", v.GetStringMap("client.peer.timeout"))
+	}
+	if v.GetString("client.peer.timeout.queryResponse") != "45s" {
+		t.Fatal("Expected base value not covered by override to survive")
+	}
+}
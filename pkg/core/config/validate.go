@@ -0,0 +1,262 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/urlutil"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/multi"
+	"github.com/pkg/errors"
+)
+
+// validator is implemented by configs that support up-front validation
+type validator interface {
+	Validate() error
+}
+
+// Validate loads the configuration from configProvider and checks it as a whole,
+// see Config.Validate. It is intended for tooling, e.g. checking network
+// configuration files in CI before they are deployed.
+func Validate(configProvider core.ConfigProvider) error {
+	config, err := configProvider()
+	if err != nil {
+		return errors.WithMessage(err, "unable to load config")
+	}
+
+	v, ok := config.(validator)
+	if !ok {
+		return errors.Errorf("validation is not supported by config type %T", config)
+	}
+	return v.Validate()
+}
+
+// Validate checks the network configuration and reports every problem found
+// rather than only the first: channels and organizations referring to undefined peers,
+// orderers or CAs, missing or malformed URLs, and TLS CA certs that are not configured,
+// cannot be read or do not hold PEM data.
+// The returned error is a multi.Errors if more than one problem was found.
+func (c *Config) Validate() error {
+	netConfig, err := c.NetworkConfig()
+	if err != nil {
+		return err
+	}
+
+	v := configValidator{
+		netConfig:      netConfig,
+		systemCertPool: c.configViper.GetBool("client.tlsCerts.systemCertPool"),
+	}
+	v.validateClient()
+	v.validateOrganizations()
+	v.validateChannels()
+	v.validatePeers()
+	v.validateOrderers()
+	v.validateCAs()
+
+	return v.errs.ToError()
+}
+
+type configValidator struct {
+	netConfig      *core.NetworkConfig
+	systemCertPool bool
+	errs           multi.Errors
+}
+
+func (v *configValidator) addError(format string, args ...interface{}) {
+	v.errs = append(v.errs, errors.Errorf(format, args...))
+}
+
+func (v *configValidator) validateClient() {
+	client := v.netConfig.Client
+	if client.Organization != "" {
+		if _, ok := v.netConfig.Organizations[strings.ToLower(client.Organization)]; !ok {
+			v.addError("client organization %s is not defined in organizations", client.Organization)
+		}
+	}
+	v.validateCertFile("client TLS key", client.TLSCerts.Client.Key)
+	v.validateCertFile("client TLS cert", client.TLSCerts.Client.Cert)
+}
+
+func (v *configValidator) validateOrganizations() {
+	for _, name := range sortedKeys(v.netConfig.Organizations) {
+		org := v.netConfig.Organizations[name]
+		if org.MspID == "" {
+			v.addError("organization %s has no MSP ID", name)
+		}
+		for _, peer := range org.Peers {
+			if _, ok := v.netConfig.Peers[strings.ToLower(peer)]; !ok {
+				v.addError("organization %s refers to undefined peer %s", name, peer)
+			}
+		}
+		for _, ca := range org.CertificateAuthorities {
+			if _, ok := v.netConfig.CertificateAuthorities[strings.ToLower(ca)]; !ok {
+				v.addError("organization %s refers to undefined certificate authority %s", name, ca)
+			}
+		}
+	}
+}
+
+func (v *configValidator) validateChannels() {
+	for _, name := range sortedKeys(v.netConfig.Channels) {
+		channel := v.netConfig.Channels[name]
+		for _, orderer := range channel.Orderers {
+			if _, ok := v.netConfig.Orderers[strings.ToLower(orderer)]; !ok {
+				v.addError("channel %s refers to undefined orderer %s", name, orderer)
+			}
+		}
+
+		peers := make([]string, 0, len(channel.Peers))
+		for peer := range channel.Peers {
+			peers = append(peers, peer)
+		}
+		sort.Strings(peers)
+		for _, peer := range peers {
+			if _, ok := v.netConfig.Peers[strings.ToLower(peer)]; !ok {
+				v.addError("channel %s refers to undefined peer %s", name, peer)
+			}
+		}
+	}
+}
+
+func (v *configValidator) validatePeers() {
+	for _, name := range sortedKeys(v.netConfig.Peers) {
+		p := v.netConfig.Peers[name]
+		what := "peer " + name
+		v.validateURL(what, "URL", p.URL)
+		v.validateURL(what, "event URL", p.EventURL)
+		v.validateTLSCACert(what, p.URL, p.TLSCACerts)
+	}
+}
+
+func (v *configValidator) validateOrderers() {
+	for _, name := range sortedKeys(v.netConfig.Orderers) {
+		o := v.netConfig.Orderers[name]
+		what := "orderer " + name
+		v.validateURL(what, "URL", o.URL)
+		v.validateTLSCACert(what, o.URL, o.TLSCACerts)
+	}
+}
+
+func (v *configValidator) validateCAs() {
+	for _, name := range sortedKeys(v.netConfig.CertificateAuthorities) {
+		ca := v.netConfig.CertificateAuthorities[name]
+		what := "certificate authority " + name
+		v.validateURL(what, "URL", ca.URL)
+
+		certs := ca.TLSCACerts
+		if urlutil.IsTLSEnabled(ca.URL) && len(certs.Pem) == 0 && certs.Path == "" && !v.systemCertPool {
+			v.addError("%s: TLS CA cert is not configured", what)
+		}
+		for _, p := range certs.Pem {
+			v.validatePem(what+" TLS CA cert", []byte(p))
+		}
+		if certs.Path != "" {
+			// Path is a comma separated list
+			for _, p := range strings.Split(certs.Path, ",") {
+				v.validateCertFile(what+" TLS CA cert", core.TLSConfig{Path: strings.TrimSpace(p)})
+			}
+		}
+		v.validateCertFile(what+" TLS client key", certs.Client.Key)
+		v.validateCertFile(what+" TLS client cert", certs.Client.Cert)
+	}
+}
+
+// validateURL accepts either host:port or scheme://host[:port]
+func (v *configValidator) validateURL(what, field, rawURL string) {
+	if rawURL == "" {
+		v.addError("%s: %s is empty", what, field)
+		return
+	}
+
+	if !urlutil.HasProtocol(rawURL) {
+		if _, _, err := net.SplitHostPort(rawURL); err != nil {
+			v.addError("%s: invalid %s %s: %s", what, field, rawURL, err)
+		}
+		return
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		v.addError("%s: invalid %s %s: %s", what, field, rawURL, err)
+		return
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "grpc", "grpcs", "http", "https":
+	default:
+		v.addError("%s: unsupported scheme in %s %s", what, field, rawURL)
+	}
+	if u.Host == "" {
+		v.addError("%s: %s %s has no host", what, field, rawURL)
+	}
+}
+
+func (v *configValidator) validateTLSCACert(what, rawURL string, cert core.TLSConfig) {
+	if urlutil.IsTLSEnabled(rawURL) && cert.Pem == "" && cert.Path == "" && !v.systemCertPool {
+		v.addError("%s: TLS CA cert is not configured", what)
+		return
+	}
+	if cert.Pem != "" {
+		v.validatePem(what+" TLS CA cert", []byte(cert.Pem))
+		return
+	}
+	v.validateCertFile(what+" TLS CA cert", cert)
+}
+
+// validateCertFile checks that a configured path can be read and holds PEM data
+func (v *configValidator) validateCertFile(what string, cert core.TLSConfig) {
+	if cert.Path == "" {
+		return
+	}
+
+	raw, err := ioutil.ReadFile(substPathVars(cert.Path))
+	if err != nil {
+		v.addError("%s: unable to read %s: %s", what, cert.Path, err)
+		return
+	}
+	v.validatePem(what, raw)
+}
+
+func (v *configValidator) validatePem(what string, raw []byte) {
+	if block, _ := pem.Decode(raw); block == nil {
+		v.addError("%s: no PEM data found", what)
+	}
+}
+
+// sortedKeys returns the keys of a network config map in order, so that errors are reported deterministically
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]core.OrganizationConfig:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]core.ChannelConfig:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]core.PeerConfig:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]core.OrdererConfig:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]core.CAConfig:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/multi"
+)
+
+const invalidNetworkConfig = `
+client:
+  organization: Org3
+channels:
+  mychannel:
+    orderers:
+      - orderer.example.com
+      - orderer2.example.com
+    peers:
+      peer0.org1.example.com:
+        endorsingPeer: true
+      peer9.org1.example.com:
+        endorsingPeer: true
+organizations:
+  Org1:
+    mspid: Org1MSP
+    peers:
+      - peer0.org1.example.com
+      - peer2.org1.example.com
+    certificateAuthorities:
+      - ca.org1.example.com
+orderers:
+  orderer.example.com:
+    url: ftp://orderer.example.com:7050
+peers:
+  peer0.org1.example.com:
+    url: grpcs://peer0.org1.example.com:7051
+    eventUrl: peer0.org1.example.com
+  peer1.org1.example.com:
+    url: grpc://peer1.org1.example.com:7051
+    eventUrl: grpc://peer1.org1.example.com:7053
+    tlsCACerts:
+      path: /does/not/exist.pem
+`
+
+func TestValidate(t *testing.T) {
+	if err := configImpl.Validate(); err != nil {
+		t.Fatalf("Expected test config to be valid: %s", err)
+	}
+
+	if err := Validate(FromFile(configTestFilePath)); err != nil {
+		t.Fatalf("Expected test config to be valid: %s", err)
+	}
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+	c, err := FromRaw([]byte(invalidNetworkConfig), configType)()
+	if err != nil {
+		t.Fatalf("Expected invalid config to load without validation: %s", err)
+	}
+
+	err = c.(*Config).Validate()
+	errs, ok := err.(multi.Errors)
+	if !ok {
+		t.Fatalf("Expected multiple errors, got %v", err)
+	}
+
+	expected := []string{
+		"client organization Org3 is not defined in organizations",
+		"organization org1 refers to undefined peer peer2.org1.example.com",
+		"organization org1 refers to undefined certificate authority ca.org1.example.com",
+		"channel mychannel refers to undefined orderer orderer2.example.com",
+		"channel mychannel refers to undefined peer peer9.org1.example.com",
+		"peer peer0.org1.example.com: invalid event URL peer0.org1.example.com",
+		"peer peer0.org1.example.com: TLS CA cert is not configured",
+		"peer peer1.org1.example.com TLS CA cert: unable to read /does/not/exist.pem",
+		"orderer orderer.example.com: unsupported scheme in URL ftp://orderer.example.com:7050",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %s", len(expected), len(errs), errs)
+	}
+	for i, e := range expected {
+		if !strings.HasPrefix(errs[i].Error(), e) {
+			t.Fatalf("Expected error %d to start with [%s], got [%s]", i, e, errs[i])
+		}
+	}
+}
+
+func TestWithValidation(t *testing.T) {
+	_, err := FromRaw([]byte(invalidNetworkConfig), configType, WithValidation())()
+	if err == nil || !strings.Contains(err.Error(), "network configuration validation failed") {
+		t.Fatalf("Expected validation failure, got %v", err)
+	}
+
+	_, err = FromFile(configTestFilePath, WithValidation())()
+	if err != nil {
+		t.Fatalf("Expected test config to pass validation: %s", err)
+	}
+
+	err = Validate(FromRaw([]byte(invalidNetworkConfig), configType))
+	if _, ok := err.(multi.Errors); !ok {
+		t.Fatalf("Expected multiple errors, got %v", err)
+	}
+}
@@ -7,20 +7,105 @@ SPDX-License-Identifier: Apache-2.0
 package multisuite
 
 import (
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/pkcs11"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
 	"github.com/pkg/errors"
 )
 
-//GetSuiteByConfig returns cryptosuite adaptor for bccsp loaded according to given config
-func GetSuiteByConfig(config core.Config) (core.CryptoSuite, error) {
-	switch config.SecurityProvider() {
-	case "SW":
+// SuiteFactory creates a cryptosuite for a security provider.
+// providerConfig is the provider-specific configuration subtree
+// (client.BCCSP.security.providers.<provider>), nil or empty if not configured.
+type SuiteFactory func(config core.Config, providerConfig map[string]interface{}) (core.CryptoSuite, error)
+
+var (
+	factoriesMutex sync.RWMutex
+	factories      = make(map[string]SuiteFactory)
+)
+
+func init() {
+	mustRegister("SW", func(config core.Config, providerConfig map[string]interface{}) (core.CryptoSuite, error) {
 		return sw.GetSuiteByConfig(config)
-	case "PKCS11":
+	})
+	mustRegister("PKCS11", func(config core.Config, providerConfig map[string]interface{}) (core.CryptoSuite, error) {
 		return pkcs11.GetSuiteByConfig(config)
+	})
+}
+
+// Register makes a cryptosuite factory available under the given security provider name,
+// selected by client.BCCSP.security.default.provider. Provider names are case insensitive.
+// An error is returned if a factory is already registered under that name.
+func Register(provider string, factory SuiteFactory) error {
+	if provider == "" {
+		return errors.New("security provider name is required")
 	}
+	if factory == nil {
+		return errors.Errorf("cryptosuite factory for security provider %s is nil", provider)
+	}
+
+	factoriesMutex.Lock()
+	defer factoriesMutex.Unlock()
+
+	key := strings.ToUpper(provider)
+	if _, ok := factories[key]; ok {
+		return errors.Errorf("cryptosuite factory already registered for security provider %s", provider)
+	}
+	factories[key] = factory
+	return nil
+}
+
+// Unregister removes the cryptosuite factory registered under the given security provider name.
+// An error is returned if no factory is registered under that name.
+func Unregister(provider string) error {
+	factoriesMutex.Lock()
+	defer factoriesMutex.Unlock()
 
-	return nil, errors.Errorf("Unsupported security provider requested: %s", config.SecurityProvider())
+	key := strings.ToUpper(provider)
+	if _, ok := factories[key]; !ok {
+		return errors.Errorf("no cryptosuite factory registered for security provider %s", provider)
+	}
+	delete(factories, key)
+	return nil
+}
+
+// Providers returns the names of the registered security providers, sorted
+func Providers() []string {
+	factoriesMutex.RLock()
+	defer factoriesMutex.RUnlock()
+
+	providers := make([]string, 0, len(factories))
+	for provider := range factories {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+//GetSuiteByConfig returns cryptosuite adaptor for bccsp loaded according to given config
+func GetSuiteByConfig(config core.Config) (core.CryptoSuite, error) {
+	provider := config.SecurityProvider()
+
+	factoriesMutex.RLock()
+	factory, ok := factories[strings.ToUpper(provider)]
+	factoriesMutex.RUnlock()
+
+	if !ok {
+		return nil, errors.Errorf("Unsupported security provider requested: %s", provider)
+	}
+
+	cryptoSuite, err := factory(config, config.SecurityProviderConfig(provider))
+	if err != nil {
+		return nil, errors.WithMessage(err, "cryptosuite creation failed for security provider "+provider)
+	}
+	return cryptoSuite, nil
+}
+
+func mustRegister(provider string, factory SuiteFactory) {
+	if err := Register(provider, factory); err != nil {
+		panic(err)
+	}
 }
@@ -23,7 +23,6 @@ func TestBadConfig(t *testing.T) {
 
 	mockConfig := mock_core.NewMockConfig(mockCtrl)
 	mockConfig.EXPECT().SecurityProvider().Return("UNKNOWN")
-	mockConfig.EXPECT().SecurityProvider().Return("UNKNOWN")
 
 	//Get cryptosuite using config
 	_, err := GetSuiteByConfig(mockConfig)
@@ -39,6 +38,7 @@ func TestCryptoSuiteByConfigSW(t *testing.T) {
 	mockConfig := mock_core.NewMockConfig(mockCtrl)
 	mockConfig.EXPECT().SecurityProvider().Return("SW")
 	mockConfig.EXPECT().SecurityProvider().Return("SW")
+	mockConfig.EXPECT().SecurityProviderConfig("SW").Return(nil)
 	mockConfig.EXPECT().SecurityAlgorithm().Return("SHA2")
 	mockConfig.EXPECT().SecurityLevel().Return(256)
 	mockConfig.EXPECT().KeyStorePath().Return("")
@@ -64,6 +64,7 @@ func TestCryptoSuiteByConfigPKCS11(t *testing.T) {
 	mockConfig := mock_core.NewMockConfig(mockCtrl)
 	mockConfig.EXPECT().SecurityProvider().Return("PKCS11")
 	mockConfig.EXPECT().SecurityProvider().Return("PKCS11")
+	mockConfig.EXPECT().SecurityProviderConfig("PKCS11").Return(nil).Times(2)
 	mockConfig.EXPECT().SecurityAlgorithm().Return("SHA2")
 	mockConfig.EXPECT().SecurityLevel().Return(256)
 	mockConfig.EXPECT().KeyStorePath().Return("")
@@ -82,6 +83,79 @@ func TestCryptoSuiteByConfigPKCS11(t *testing.T) {
 	verifySuiteType(t, c, "*pkcs11.impl")
 }
 
+func TestRegisterCustomProvider(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	expectedSuite := &wrapper.CryptoSuite{}
+	providerConfig := map[string]interface{}{"address": "https://vault.example.com:8200", "mount": "transit"}
+
+	var receivedConfig map[string]interface{}
+	err := Register("TestVault", func(config core.Config, pc map[string]interface{}) (core.CryptoSuite, error) {
+		receivedConfig = pc
+		return expectedSuite, nil
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %s", err)
+	}
+	defer func() {
+		if err := Unregister("TestVault"); err != nil {
+			t.Fatalf("Unregister failed: %s", err)
+		}
+		if containsProvider(Providers(), "TESTVAULT") {
+			t.Fatal("Expected provider to be removed by Unregister")
+		}
+	}()
+
+	err = Register("testvault", func(config core.Config, pc map[string]interface{}) (core.CryptoSuite, error) {
+		return nil, nil
+	})
+	if err == nil {
+		t.Fatal("Expected error registering a provider twice")
+	}
+
+	if err := Register("SW", nil); err == nil {
+		t.Fatal("Expected error registering a nil factory")
+	}
+
+	providers := Providers()
+	for _, provider := range []string{"PKCS11", "SW", "TESTVAULT"} {
+		if !containsProvider(providers, provider) {
+			t.Fatalf("Expected provider %s in registered providers: %v", provider, providers)
+		}
+	}
+
+	mockConfig := mock_core.NewMockConfig(mockCtrl)
+	mockConfig.EXPECT().SecurityProvider().Return("TestVault")
+	mockConfig.EXPECT().SecurityProviderConfig("TestVault").Return(providerConfig)
+
+	c, err := GetSuiteByConfig(mockConfig)
+	if err != nil {
+		t.Fatalf("Not supposed to get error, but got: %v", err)
+	}
+	if c != expectedSuite {
+		t.Fatal("Expected cryptosuite from registered factory")
+	}
+	if !reflect.DeepEqual(receivedConfig, providerConfig) {
+		t.Fatalf("Unexpected provider config passed to factory: %v", receivedConfig)
+	}
+}
+
+func TestUnregisterUnknownProvider(t *testing.T) {
+	if err := Unregister("UNKNOWN"); err == nil {
+		t.Fatal("Expected error unregistering a provider that isn't registered")
+	}
+}
+
+func containsProvider(providers []string, provider string) bool {
+	for _, p := range providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
 func verifySuiteType(t *testing.T, c core.CryptoSuite, expectedType string) {
 	w, ok := c.(*wrapper.CryptoSuite)
 	if !ok {
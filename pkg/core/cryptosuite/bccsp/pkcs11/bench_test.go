@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
+	pkcsFactory "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp/factory/pkcs11"
+)
+
+// The benchmarks run against SoftHSM2 (see test/fixtures/softhsm2), e.g.
+//   go test -run=NONE -bench=PKCS11 ./pkg/core/cryptosuite/bccsp/pkcs11/
+
+func BenchmarkSignPKCS11(b *testing.B) {
+	for _, size := range []int{1, 10, 50} {
+		b.Run(fmt.Sprintf("sessions=%d", size), func(b *testing.B) {
+			csp, key := setupBenchmarkCSP(b, size)
+			digest := sha256.Sum256([]byte("Hello"))
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := csp.Sign(key, digest[:], nil); err != nil {
+					b.Fatalf("Sign failed: %s", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSignPKCS11Parallel(b *testing.B) {
+	for _, size := range []int{1, 10, 50} {
+		b.Run(fmt.Sprintf("sessions=%d", size), func(b *testing.B) {
+			csp, key := setupBenchmarkCSP(b, size)
+			digest := sha256.Sum256([]byte("Hello"))
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := csp.Sign(key, digest[:], nil); err != nil {
+						b.Errorf("Sign failed: %s", err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkGetKeyPKCS11(b *testing.B) {
+	csp, key := setupBenchmarkCSP(b, 10)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := csp.GetKey(key.SKI()); err != nil {
+			b.Fatalf("GetKey failed: %s", err)
+		}
+	}
+}
+
+func setupBenchmarkCSP(b *testing.B, sessionCacheSize int) (bccsp.BCCSP, bccsp.Key) {
+	opts := configurePKCS11Options("SHA2", securityLevel)
+	if opts.Library == "" {
+		b.Skip("PKCS11 library not found")
+	}
+	opts.SessionCacheSize = sessionCacheSize
+
+	csp, err := (&pkcsFactory.PKCS11Factory{}).Get(opts)
+	if err != nil {
+		b.Fatalf("Failed to initialize PKCS11 BCCSP: %s", err)
+	}
+	key, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	if err != nil {
+		b.Fatalf("KeyGen failed: %s", err)
+	}
+	return csp, key
+}
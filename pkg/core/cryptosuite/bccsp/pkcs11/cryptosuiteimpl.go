@@ -14,14 +14,23 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/wrapper"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/pkg/errors"
+	"github.com/spf13/cast"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/cryptosuite/pkcs11")
+
+const (
+	providerName = "PKCS11"
+
+	// sessionCacheSizeKey is the key of the session cache size in the provider-specific
+	// config (client.BCCSP.security.providers.PKCS11.sessionCacheSize)
+	sessionCacheSizeKey = "sessioncachesize"
+)
 
 //GetSuiteByConfig returns cryptosuite adaptor for bccsp loaded according to given config
 func GetSuiteByConfig(config core.Config) (core.CryptoSuite, error) {
 	// TODO: delete this check?
-	if config.SecurityProvider() != "PKCS11" {
+	if config.SecurityProvider() != providerName {
 		return nil, errors.Errorf("Unsupported BCCSP Provider: %s", config.SecurityProvider())
 	}
 
@@ -57,7 +66,23 @@ func getOptsByConfig(c core.Config) *pkcs11.PKCS11Opts {
 		Label:        c.SecurityProviderLabel(),
 		SoftVerify:   c.SoftVerify(),
 	}
+	opts.SessionCacheSize = sessionCacheSize(c.SecurityProviderConfig(providerName))
 	logger.Debug("Initialized PKCS11 cryptosuite")
 
 	return opts
 }
+
+// sessionCacheSize returns the configured number of idle PKCS11 sessions to keep open,
+// or 0 for the default. It doesn't limit the number of sessions in use at once.
+func sessionCacheSize(providerConfig map[string]interface{}) int {
+	value, ok := providerConfig[sessionCacheSizeKey]
+	if !ok {
+		return 0
+	}
+	size, err := cast.ToIntE(value)
+	if err != nil || size < 0 {
+		logger.Warnf("Invalid PKCS11 session cache size [%v], using default", value)
+		return 0
+	}
+	return size
+}
@@ -62,6 +62,7 @@ func TestCryptoSuiteByConfigPKCS11(t *testing.T) {
 	mockConfig.EXPECT().SecurityProviderLabel().Return(softHSMTokenLabel)
 	mockConfig.EXPECT().SecurityProviderPin().Return(softHSMPin)
 	mockConfig.EXPECT().SoftVerify().Return(true)
+	mockConfig.EXPECT().SecurityProviderConfig("PKCS11").Return(nil)
 
 	//Get cryptosuite using config
 	c, err := GetSuiteByConfig(mockConfig)
@@ -88,6 +89,7 @@ func TestCryptoSuiteByConfigPKCS11Failure(t *testing.T) {
 	mockConfig.EXPECT().SecurityProviderLabel().Return("")
 	mockConfig.EXPECT().SecurityProviderPin().Return("")
 	mockConfig.EXPECT().SoftVerify().Return(true)
+	mockConfig.EXPECT().SecurityProviderConfig("PKCS11").Return(nil)
 
 	//Get cryptosuite using config
 	samplecryptoSuite, err := GetSuiteByConfig(mockConfig)
@@ -175,3 +177,21 @@ func verifyHashFn(t *testing.T, c core.CryptoSuite) {
 		t.Fatalf("Expected SHA 256 hash function")
 	}
 }
+
+func TestSessionCacheSize(t *testing.T) {
+	tests := []struct {
+		config   map[string]interface{}
+		expected int
+	}{
+		{nil, 0},
+		{map[string]interface{}{"sessioncachesize": 50}, 50},
+		{map[string]interface{}{"sessioncachesize": "20"}, 20},
+		{map[string]interface{}{"sessioncachesize": "many"}, 0},
+		{map[string]interface{}{"sessioncachesize": -1}, 0},
+	}
+	for _, test := range tests {
+		if size := sessionCacheSize(test.config); size != test.expected {
+			t.Fatalf("Expected session cache size %d for %v, got %d", test.expected, test.config, size)
+		}
+	}
+}
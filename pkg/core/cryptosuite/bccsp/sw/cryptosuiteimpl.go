@@ -9,13 +9,15 @@ package sw
 import (
 	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
 	bccspSw "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp/factory/sw"
+	bccspSwImpl "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/wrapper"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/cryptosuite/sw")
 
 //GetSuiteByConfig returns cryptosuite adaptor for bccsp loaded according to given config
 func GetSuiteByConfig(config core.Config) (core.CryptoSuite, error) {
@@ -25,11 +27,36 @@ func GetSuiteByConfig(config core.Config) (core.CryptoSuite, error) {
 	}
 
 	opts := getOptsByConfig(config)
-	bccsp, err := getBCCSPFromOpts(opts)
+	ks, err := getKeyStoreFromOpts(opts)
 	if err != nil {
 		return nil, err
 	}
-	return wrapper.NewCryptoSuite(bccsp), nil
+	csp, err := bccspSwImpl.New(opts.SecLevel, opts.HashFamily, ks)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not initialize BCCSP SW")
+	}
+	return wrapper.NewCryptoSuiteWithKeyStore(csp, ks), nil
+}
+
+//GetSuiteWithKVStore returns cryptosuite adaptor for bccsp keeping its keys in the given KVStore
+//instead of the keystore directory, e.g. in the same store as user certificates.
+//Keys are stored as PEM encoded values ([]byte) under the names used in keystore directories.
+func GetSuiteWithKVStore(config core.Config, store api.KVStore) (core.CryptoSuite, error) {
+	if config.SecurityProvider() != "SW" {
+		return nil, errors.Errorf("Unsupported BCCSP Provider: %s", config.SecurityProvider())
+	}
+
+	ks, err := bccspSwImpl.NewKVBasedKeyStore(nil, store, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize KV key store")
+	}
+	csp, err := bccspSwImpl.New(config.SecurityLevel(), config.SecurityAlgorithm(), ks)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not initialize BCCSP SW")
+	}
+	logger.Debug("Initialized SW cryptosuite with KV key store")
+
+	return wrapper.NewCryptoSuiteWithKeyStore(csp, ks), nil
 }
 
 //GetSuiteWithDefaultEphemeral returns cryptosuite adaptor for bccsp with default ephemeral options (intended to aid testing)
@@ -53,6 +80,18 @@ func getBCCSPFromOpts(config *bccspSw.SwOpts) (bccsp.BCCSP, error) {
 	return csp, nil
 }
 
+// getKeyStoreFromOpts returns the key store selected by the opts, as the SW factory would
+func getKeyStoreFromOpts(opts *bccspSw.SwOpts) (bccsp.KeyStore, error) {
+	if opts.Ephemeral || opts.FileKeystore == nil {
+		return bccspSwImpl.NewDummyKeyStore(), nil
+	}
+	ks, err := bccspSwImpl.NewFileBasedKeyStore(nil, opts.FileKeystore.KeyStorePath, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize software key store")
+	}
+	return ks, nil
+}
+
 //GetOptsByConfig Returns Factory opts for given SDK config
 func getOptsByConfig(c core.Config) *bccspSw.SwOpts {
 	opts := &bccspSw.SwOpts{
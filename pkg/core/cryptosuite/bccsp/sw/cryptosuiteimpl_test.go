@@ -9,12 +9,15 @@ package sw
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/keyvaluestore"
 )
 
 func TestBadConfig(t *testing.T) {
@@ -78,6 +81,84 @@ func TestCryptoSuiteDefaultEphemeral(t *testing.T) {
 	verifyHashFn(t, c)
 }
 
+func TestCryptoSuiteWithKVStore(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfig := mock_core.NewMockConfig(mockCtrl)
+	mockConfig.EXPECT().SecurityProvider().Return("SW").AnyTimes()
+	mockConfig.EXPECT().SecurityAlgorithm().Return("SHA2").AnyTimes()
+	mockConfig.EXPECT().SecurityLevel().Return(256).AnyTimes()
+
+	store := keyvaluestore.NewMemKeyValueStore(nil)
+
+	c, err := GetSuiteWithKVStore(mockConfig, store)
+	if err != nil {
+		t.Fatalf("Not supposed to get error, but got: %v", err)
+	}
+	verifyHashFn(t, c)
+
+	key, err := c.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	if err != nil {
+		t.Fatalf("Key generation failed: %v", err)
+	}
+	raw, err := store.Load(hex.EncodeToString(key.SKI()) + "_sk")
+	if err != nil {
+		t.Fatalf("Expected private key in KV store: %v", err)
+	}
+	if !strings.Contains(string(raw.([]byte)), "PRIVATE KEY") {
+		t.Fatalf("Expected PEM encoded private key, got %s", raw)
+	}
+
+	// A new suite on the same store finds the key
+	c2, err := GetSuiteWithKVStore(mockConfig, store)
+	if err != nil {
+		t.Fatalf("Not supposed to get error, but got: %v", err)
+	}
+	loaded, err := c2.GetKey(key.SKI())
+	if err != nil {
+		t.Fatalf("Expected key to be loaded from KV store: %v", err)
+	}
+	if !loaded.Private() || !bytes.Equal(loaded.SKI(), key.SKI()) {
+		t.Fatal("Loaded key doesn't match generated key")
+	}
+
+	pub, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed getting public key: %v", err)
+	}
+	digest, err := c.Hash([]byte("Hello"), &bccsp.SHA256Opts{})
+	if err != nil {
+		t.Fatalf("Hash failed: %v", err)
+	}
+	signature, err := c2.Sign(loaded, digest, nil)
+	if err != nil {
+		t.Fatalf("Sign with loaded key failed: %v", err)
+	}
+	valid, err := c.Verify(pub, signature, digest, nil)
+	if err != nil || !valid {
+		t.Fatalf("Signature by loaded key should be valid: %v", err)
+	}
+
+	if _, err := c2.GetKey([]byte("unknown")); err == nil {
+		t.Fatal("Expected error for unknown SKI")
+	}
+
+	deleter, ok := c2.(core.KeyDeleter)
+	if !ok {
+		t.Fatal("Expected crypto suite with KV store to support deleting keys")
+	}
+	if err := deleter.DeleteKey(key.SKI()); err != nil {
+		t.Fatalf("DeleteKey failed: %v", err)
+	}
+	if _, err := c2.GetKey(key.SKI()); err == nil {
+		t.Fatal("Expected deleted key to be removed from KV store")
+	}
+	if err := deleter.DeleteKey(key.SKI()); err != nil {
+		t.Fatalf("Deleting a missing key should succeed: %v", err)
+	}
+}
+
 func verifyHashFn(t *testing.T, c core.CryptoSuite) {
 	msg := []byte("Hello")
 	e := sha256.Sum256(msg)
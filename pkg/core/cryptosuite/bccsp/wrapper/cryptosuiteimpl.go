@@ -12,9 +12,10 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/cryptosuite/wrapper")
 
 //NewCryptoSuite returns cryptosuite adaptor for given bccsp.BCCSP implementation
 func NewCryptoSuite(bccsp bccsp.BCCSP) core.CryptoSuite {
@@ -23,6 +24,15 @@ func NewCryptoSuite(bccsp bccsp.BCCSP) core.CryptoSuite {
 	}
 }
 
+//NewCryptoSuiteWithKeyStore returns cryptosuite adaptor for given bccsp.BCCSP implementation
+//and the key store it keeps its keys in, so that keys can be deleted (see DeleteKey)
+func NewCryptoSuiteWithKeyStore(bccsp bccsp.BCCSP, keyStore bccsp.KeyStore) core.CryptoSuite {
+	return &CryptoSuite{
+		BCCSP:    bccsp,
+		keyStore: keyStore,
+	}
+}
+
 //GetKey returns implementation of of cryptosuite.Key
 func GetKey(newkey bccsp.Key) core.Key {
 	return &key{newkey}
@@ -30,7 +40,13 @@ func GetKey(newkey bccsp.Key) core.Key {
 
 // CryptoSuite provides a wrapper of BCCSP
 type CryptoSuite struct {
-	BCCSP bccsp.BCCSP
+	BCCSP    bccsp.BCCSP
+	keyStore bccsp.KeyStore
+}
+
+// keyDeleter is implemented by key stores that support removing keys
+type keyDeleter interface {
+	DeleteKey(ski []byte) error
 }
 
 // KeyGen is a wrapper of BCCSP.KeyGen
@@ -71,6 +87,16 @@ func (c *CryptoSuite) Verify(k core.Key, signature, digest []byte, opts core.Sig
 	return c.BCCSP.Verify(k.(*key).key, signature, digest, opts)
 }
 
+// DeleteKey removes the key identified by ski from the key store of the BCCSP.
+// An error is returned if the key store doesn't support removing keys.
+func (c *CryptoSuite) DeleteKey(ski []byte) error {
+	ks, ok := c.keyStore.(keyDeleter)
+	if !ok {
+		return errors.New("key store does not support deleting keys")
+	}
+	return ks.DeleteKey(ski)
+}
+
 type key struct {
 	key bccsp.Key
 }
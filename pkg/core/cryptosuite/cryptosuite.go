@@ -10,6 +10,7 @@ import (
 	"sync/atomic"
 
 	"errors"
+	"fmt"
 
 	"sync"
 
@@ -19,7 +20,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/cryptosuite")
 
 var initOnce sync.Once
 var defaultCryptoSuite core.CryptoSuite
@@ -72,6 +73,11 @@ func GetSHA256Opts() core.HashOpts {
 	return &bccsp.SHA256Opts{}
 }
 
+//GetSHA384Opts returns options relating to SHA-384.
+func GetSHA384Opts() core.HashOpts {
+	return &bccsp.SHA384Opts{}
+}
+
 //GetSHAOpts returns options for computing SHA.
 func GetSHAOpts() core.HashOpts {
 	return &bccsp.SHAOpts{}
@@ -81,3 +87,33 @@ func GetSHAOpts() core.HashOpts {
 func GetECDSAP256KeyGenOpts(ephemeral bool) core.KeyGenOpts {
 	return &bccsp.ECDSAP256KeyGenOpts{Temporary: ephemeral}
 }
+
+//GetECDSAP384KeyGenOpts returns options for ECDSA key generation with curve P-384.
+func GetECDSAP384KeyGenOpts(ephemeral bool) core.KeyGenOpts {
+	return &bccsp.ECDSAP384KeyGenOpts{Temporary: ephemeral}
+}
+
+//GetECDSAKeyGenOpts returns options for ECDSA key generation with the curve matching
+//the security level (256 for P-256, 384 for P-384).
+func GetECDSAKeyGenOpts(securityLevel int, ephemeral bool) (core.KeyGenOpts, error) {
+	switch securityLevel {
+	case 256:
+		return GetECDSAP256KeyGenOpts(ephemeral), nil
+	case 384:
+		return GetECDSAP384KeyGenOpts(ephemeral), nil
+	}
+	return nil, fmt.Errorf("unsupported security level for ECDSA: %d", securityLevel)
+}
+
+//GetSignatureHashOpts returns options for the hash function that MSPs use to compute
+//the digest of a signed message, given by the signature hash family (SHA2 for SHA-256,
+//SHA3 for SHA3-256). The digest doesn't depend on the security level of the key.
+func GetSignatureHashOpts(hashFamily string) (core.HashOpts, error) {
+	switch hashFamily {
+	case bccsp.SHA2:
+		return GetSHA256Opts(), nil
+	case bccsp.SHA3:
+		return &bccsp.SHA3_256Opts{}, nil
+	}
+	return nil, fmt.Errorf("unsupported signature hash family: %s", hashFamily)
+}
@@ -18,7 +18,9 @@ import (
 const (
 	shaHashOptsAlgorithm       = "SHA"
 	sha256HashOptsAlgorithm    = "SHA256"
+	sha384HashOptsAlgorithm    = "SHA384"
 	ecdsap256KeyGenOpts        = "ECDSAP256"
+	ecdsap384KeyGenOpts        = "ECDSAP384"
 	setDefAlreadySetErrorMsg   = "default crypto suite is already set"
 	InvalidDefSuiteSetErrorMsg = "attempting to set invalid default suite"
 )
@@ -84,6 +86,29 @@ func TestHashOpts(t *testing.T) {
 	testutils.VerifyNotEmpty(t, hashOpts, "Not supposed to be empty sha256HashOpts")
 	testutils.VerifyTrue(t, hashOpts.Algorithm() == sha256HashOptsAlgorithm, "Unexpected SHA hash opts, expected [%v], got [%v]", sha256HashOptsAlgorithm, hashOpts.Algorithm())
 
+	//Get CryptoSuite SHA384 Opts
+	hashOpts = GetSHA384Opts()
+	testutils.VerifyNotEmpty(t, hashOpts, "Not supposed to be empty sha384HashOpts")
+	testutils.VerifyTrue(t, hashOpts.Algorithm() == sha384HashOptsAlgorithm, "Unexpected SHA hash opts, expected [%v], got [%v]", sha384HashOptsAlgorithm, hashOpts.Algorithm())
+
+}
+
+func TestSignatureHashOpts(t *testing.T) {
+	tests := []struct {
+		hashFamily string
+		expected   string
+	}{
+		{"SHA2", "SHA256"},
+		{"SHA3", "SHA3_256"},
+	}
+	for _, test := range tests {
+		hashOpts, err := GetSignatureHashOpts(test.hashFamily)
+		testutils.VerifyEmpty(t, err, "Not supposed to get error for %s: %s", test.hashFamily, err)
+		testutils.VerifyTrue(t, hashOpts.Algorithm() == test.expected, "Unexpected hash opts, expected [%v], got [%v]", test.expected, hashOpts.Algorithm())
+	}
+
+	_, err := GetSignatureHashOpts("MD5")
+	testutils.VerifyNotEmpty(t, err, "Supposed to get error for unsupported hash family")
 }
 
 func TestKeyGenOpts(t *testing.T) {
@@ -98,4 +123,20 @@ func TestKeyGenOpts(t *testing.T) {
 	testutils.VerifyFalse(t, keygenOpts.Ephemeral(), "Expected keygenOpts.Ephemeral() ==> false")
 	testutils.VerifyTrue(t, keygenOpts.Algorithm() == ecdsap256KeyGenOpts, "Unexpected SHA hash opts, expected [%v], got [%v]", ecdsap256KeyGenOpts, keygenOpts.Algorithm())
 
+	keygenOpts = GetECDSAP384KeyGenOpts(true)
+	testutils.VerifyNotEmpty(t, keygenOpts, "Not supposed to be empty ECDSAP384KeyGenOpts")
+	testutils.VerifyTrue(t, keygenOpts.Ephemeral(), "Expected keygenOpts.Ephemeral() ==> true")
+	testutils.VerifyTrue(t, keygenOpts.Algorithm() == ecdsap384KeyGenOpts, "Unexpected key gen opts, expected [%v], got [%v]", ecdsap384KeyGenOpts, keygenOpts.Algorithm())
+
+	keygenOpts, err := GetECDSAKeyGenOpts(384, false)
+	testutils.VerifyEmpty(t, err, "Not supposed to get error for security level 384: %s", err)
+	testutils.VerifyFalse(t, keygenOpts.Ephemeral(), "Expected keygenOpts.Ephemeral() ==> false")
+	testutils.VerifyTrue(t, keygenOpts.Algorithm() == ecdsap384KeyGenOpts, "Unexpected key gen opts, expected [%v], got [%v]", ecdsap384KeyGenOpts, keygenOpts.Algorithm())
+
+	keygenOpts, err = GetECDSAKeyGenOpts(256, true)
+	testutils.VerifyEmpty(t, err, "Not supposed to get error for security level 256: %s", err)
+	testutils.VerifyTrue(t, keygenOpts.Algorithm() == ecdsap256KeyGenOpts, "Unexpected key gen opts, expected [%v], got [%v]", ecdsap256KeyGenOpts, keygenOpts.Algorithm())
+
+	_, err = GetECDSAKeyGenOpts(521, true)
+	testutils.VerifyNotEmpty(t, err, "Supposed to get error for unsupported security level")
 }
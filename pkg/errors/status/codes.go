@@ -41,6 +41,13 @@ const (
 
 	// MultipleErrors multiple errors occurred
 	MultipleErrors Code = 7
+
+	// ValidationCodeMismatch is returned when peers report different validation codes for a transaction
+	ValidationCodeMismatch Code = 8
+
+	// BlockEventsNotPermitted is returned when block events are requested from an event client
+	// that isn't permitted to receive them
+	BlockEventsNotPermitted Code = 9
 )
 
 // CodeName maps the codes in this packages to human-readable strings
@@ -53,6 +60,8 @@ var CodeName = map[int32]string{
 	5: "TIMEOUT",
 	6: "NO_PEERS_FOUND",
 	7: "MULTIPLE_ERRORS",
+	8: "VALIDATION_CODE_MISMATCH",
+	9: "BLOCK_EVENTS_NOT_PERMITTED",
 }
 
 // ToInt32 cast to int32
@@ -72,20 +72,24 @@ const (
 	OrdererClientStatus
 	// ClientStatus is a generic client status
 	ClientStatus
+
+	// DeliverServerStatus status returned by the peer's deliver service
+	DeliverServerStatus
 )
 
 // GroupName maps the groups in this packages to human-readable strings
 var GroupName = map[int32]string{
-	0: "Unknown",
-	1: "gRPC Transport Status",
-	2: "HTTP Transport Status",
-	3: "Endorser Server Status",
-	4: "Event Server Status",
-	5: "Orderer Server Status",
-	6: "Fabric CA Server Status",
-	7: "Endorser Client Status",
-	8: "Orderer Client Status",
-	9: "Client Status",
+	0:  "Unknown",
+	1:  "gRPC Transport Status",
+	2:  "HTTP Transport Status",
+	3:  "Endorser Server Status",
+	4:  "Event Server Status",
+	5:  "Orderer Server Status",
+	6:  "Fabric CA Server Status",
+	7:  "Endorser Client Status",
+	8:  "Orderer Client Status",
+	9:  "Client Status",
+	10: "Deliver Server Status",
 }
 
 func (g Group) String() string {
@@ -123,7 +127,7 @@ func (s *Status) codeString() string {
 	switch s.Group {
 	case GRPCTransportStatus:
 		return ToGRPCStatusCode(s.Code).String()
-	case EndorserServerStatus, OrdererServerStatus:
+	case EndorserServerStatus, OrdererServerStatus, DeliverServerStatus:
 		return ToFabricCommonStatusCode(s.Code).String()
 	case EventServerStatus:
 		return ToTransactionValidationCode(s.Code).String()
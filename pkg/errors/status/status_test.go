@@ -108,6 +108,9 @@ func TestStatusCodeString(t *testing.T) {
 	s = Status{Group: EventServerStatus, Code: int32(pb.TxValidationCode_BAD_CHANNEL_HEADER)}
 	assert.Equal(t, pb.TxValidationCode_BAD_CHANNEL_HEADER.String(), s.codeString())
 
+	s = Status{Group: DeliverServerStatus, Code: int32(common.Status_FORBIDDEN)}
+	assert.Equal(t, common.Status_FORBIDDEN.String(), s.codeString())
+
 	unknownCode45779 := 45779
 	s = Status{Code: int32(unknownCode45779)}
 	assert.Equal(t, Unknown.String(), s.codeString())
@@ -0,0 +1,379 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package blockdecoder decodes blocks and transaction envelopes into typed structures,
+// sparing event consumers from unmarshalling the nested protobuf messages themselves.
+package blockdecoder
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	ledgerutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+)
+
+// Block is a decoded block
+type Block struct {
+	Number       uint64
+	PreviousHash []byte
+	DataHash     []byte
+	Transactions []*Transaction
+	// ChannelConfig is set if this is a config block
+	ChannelConfig fab.ChannelCfg
+}
+
+// Transaction is a decoded transaction envelope
+type Transaction struct {
+	// Index is the position of the transaction within its block
+	Index     int
+	TxID      string
+	ChannelID string
+	Type      cb.HeaderType
+	Timestamp time.Time
+	Creator   *Identity
+	// ValidationCode is the code set by the committing peer. Blocks that carry
+	// no validation flags (e.g. blocks delivered by an orderer) leave it VALID.
+	ValidationCode pb.TxValidationCode
+	// Actions is set for endorser transactions
+	Actions []*Action
+	// ChannelConfig is set for config transactions
+	ChannelConfig fab.ChannelCfg
+}
+
+// Identity is a serialized identity of a transaction creator or endorser
+type Identity struct {
+	MSPID   string
+	IDBytes []byte
+}
+
+// Endorsement is an endorser's signature on a proposal response
+type Endorsement struct {
+	Endorser  *Identity
+	Signature []byte
+}
+
+// Action is a chaincode action of an endorser transaction
+type Action struct {
+	ChaincodeID  *pb.ChaincodeID
+	Input        *pb.ChaincodeInput
+	Response     *pb.Response
+	RWSets       []*NsRWSet
+	Event        *pb.ChaincodeEvent
+	Endorsements []*Endorsement
+}
+
+// NsRWSet is the read/write set of a single namespace (chaincode)
+type NsRWSet struct {
+	Namespace        string
+	Reads            []*kvrwset.KVRead
+	Writes           []*kvrwset.KVWrite
+	RangeQueries     []*kvrwset.RangeQueryInfo
+	CollectionHashes []*CollectionHashes
+}
+
+// CollectionHashes holds the hashed read/write set of a private data collection
+type CollectionHashes struct {
+	Collection   string
+	HashedReads  []*kvrwset.KVReadHash
+	HashedWrites []*kvrwset.KVWriteHash
+	PvtRWSetHash []byte
+}
+
+// Certificate returns the X509 certificate of the identity
+func (id *Identity) Certificate() (*x509.Certificate, error) {
+	block, _ := pem.Decode(id.IDBytes)
+	if block == nil {
+		return nil, errors.Errorf("identity of %s is not PEM encoded", id.MSPID)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// DecodeBlock decodes the given block
+func DecodeBlock(block *cb.Block) (*Block, error) {
+	if block == nil || block.Header == nil || block.Data == nil {
+		return nil, errors.New("block is missing header or data")
+	}
+
+	var txFilter ledgerutil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txFilter = ledgerutil.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	decoded := &Block{
+		Number:       block.Header.Number,
+		PreviousHash: block.Header.PreviousHash,
+		DataHash:     block.Header.DataHash,
+	}
+
+	for i, data := range block.Data.Data {
+		tx, err := DecodeTransaction(data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error decoding transaction")
+		}
+		tx.Index = i
+		if i < len(txFilter) {
+			tx.ValidationCode = txFilter.Flag(i)
+		}
+		if tx.ChannelConfig != nil {
+			decoded.ChannelConfig = tx.ChannelConfig
+		}
+		decoded.Transactions = append(decoded.Transactions, tx)
+	}
+
+	return decoded, nil
+}
+
+// DecodeTransaction decodes a marshalled transaction envelope, as held in block data.
+// The validation code is not part of the envelope so it is left VALID.
+func DecodeTransaction(data []byte) (*Transaction, error) {
+	tx, payload, err := decodeHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload.Header.SignatureHeader) > 0 {
+		signatureHeader, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			return nil, errors.Wrap(err, "error extracting SignatureHeader from payload")
+		}
+		if tx.Creator, err = decodeIdentity(signatureHeader.Creator); err != nil {
+			return nil, errors.WithMessage(err, "invalid transaction creator")
+		}
+	}
+
+	switch tx.Type {
+	case cb.HeaderType_ENDORSER_TRANSACTION:
+		if tx.Actions, err = decodeActions(payload.Data); err != nil {
+			return nil, errors.WithMessage(err, "error decoding transaction actions")
+		}
+	case cb.HeaderType_CONFIG:
+		configEnvelope := &cb.ConfigEnvelope{}
+		if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+			return nil, errors.Wrap(err, "error extracting ConfigEnvelope from payload")
+		}
+		if tx.ChannelConfig, err = chconfig.FromConfigEnvelope(tx.ChannelID, configEnvelope); err != nil {
+			return nil, errors.WithMessage(err, "error extracting channel config")
+		}
+	}
+
+	return tx, nil
+}
+
+// DecodeTransactionEvents decodes only the channel header and the chaincode events of a
+// marshalled transaction envelope, skipping the creator, inputs, endorsements and read/write
+// sets. For endorser transactions the returned transaction has one action per chaincode
+// event, holding only the event. If the header decodes but the events don't, the
+// transaction is returned along with the error so that its status can still be reported.
+func DecodeTransactionEvents(data []byte) (*Transaction, error) {
+	tx, payload, err := decodeHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type != cb.HeaderType_ENDORSER_TRANSACTION {
+		return tx, nil
+	}
+
+	transaction, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return tx, errors.Wrap(err, "error unmarshalling transaction payload")
+	}
+	for _, txAction := range transaction.Actions {
+		event, err := decodeEvent(txAction)
+		if err != nil {
+			return tx, err
+		}
+		if event != nil {
+			tx.Actions = append(tx.Actions, &Action{Event: event})
+		}
+	}
+	return tx, nil
+}
+
+// decodeHeader decodes the envelope's payload and channel header
+func decodeHeader(data []byte) (*Transaction, *cb.Payload, error) {
+	env, err := utils.GetEnvelopeFromBlock(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error extracting Envelope from block")
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error extracting Payload from envelope")
+	}
+	if payload.Header == nil {
+		return nil, nil, errors.New("payload header is missing")
+	}
+	channelHeader, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error extracting ChannelHeader from payload")
+	}
+
+	tx := &Transaction{
+		TxID:      channelHeader.TxId,
+		ChannelID: channelHeader.ChannelId,
+		Type:      cb.HeaderType(channelHeader.Type),
+	}
+	if channelHeader.Timestamp != nil {
+		if tx.Timestamp, err = ptypes.Timestamp(channelHeader.Timestamp); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid transaction timestamp")
+		}
+	}
+	return tx, payload, nil
+}
+
+func decodeActions(data []byte) ([]*Action, error) {
+	tx, err := utils.GetTransaction(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling transaction payload")
+	}
+
+	var actions []*Action
+	for _, txAction := range tx.Actions {
+		action, err := decodeAction(txAction)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+func decodeAction(txAction *pb.TransactionAction) (*Action, error) {
+	chaincodeActionPayload, err := utils.GetChaincodeActionPayload(txAction.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode action payload")
+	}
+	if chaincodeActionPayload.Action == nil {
+		return nil, errors.New("chaincode endorsed action is missing")
+	}
+
+	action := &Action{}
+
+	if action.Input, err = decodeInput(chaincodeActionPayload.ChaincodeProposalPayload); err != nil {
+		return nil, err
+	}
+
+	for _, endorsement := range chaincodeActionPayload.Action.Endorsements {
+		endorser, err := decodeIdentity(endorsement.Endorser)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid endorser")
+		}
+		action.Endorsements = append(action.Endorsements, &Endorsement{Endorser: endorser, Signature: endorsement.Signature})
+	}
+
+	propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling response payload")
+	}
+	ccAction, err := utils.GetChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode action")
+	}
+	action.ChaincodeID = ccAction.ChaincodeId
+	action.Response = ccAction.Response
+
+	if action.RWSets, err = decodeRWSets(ccAction.Results); err != nil {
+		return nil, err
+	}
+
+	if len(ccAction.Events) > 0 {
+		if action.Event, err = utils.GetChaincodeEvents(ccAction.Events); err != nil {
+			return nil, errors.Wrap(err, "error getting chaincode events")
+		}
+	}
+
+	return action, nil
+}
+
+func decodeEvent(txAction *pb.TransactionAction) (*pb.ChaincodeEvent, error) {
+	chaincodeActionPayload, err := utils.GetChaincodeActionPayload(txAction.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode action payload")
+	}
+	if chaincodeActionPayload.Action == nil {
+		return nil, errors.New("chaincode endorsed action is missing")
+	}
+	propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling response payload")
+	}
+	ccAction, err := utils.GetChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode action")
+	}
+	if len(ccAction.Events) == 0 {
+		return nil, nil
+	}
+	event, err := utils.GetChaincodeEvents(ccAction.Events)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting chaincode events")
+	}
+	return event, nil
+}
+
+func decodeInput(proposalPayload []byte) (*pb.ChaincodeInput, error) {
+	chaincodeProposalPayload, err := utils.GetChaincodeProposalPayload(proposalPayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode proposal payload")
+	}
+	invocationSpec := &pb.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(chaincodeProposalPayload.Input, invocationSpec); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode invocation spec")
+	}
+	if invocationSpec.ChaincodeSpec == nil {
+		return nil, nil
+	}
+	return invocationSpec.ChaincodeSpec.Input, nil
+}
+
+func decodeRWSets(results []byte) ([]*NsRWSet, error) {
+	txRwSet := &rwsetutil.TxRwSet{}
+	if err := txRwSet.FromProtoBytes(results); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling read/write set")
+	}
+
+	var nsRWSets []*NsRWSet
+	for _, nsRwSet := range txRwSet.NsRwSets {
+		nsRWSet := &NsRWSet{Namespace: nsRwSet.NameSpace}
+		if nsRwSet.KvRwSet != nil {
+			nsRWSet.Reads = nsRwSet.KvRwSet.Reads
+			nsRWSet.Writes = nsRwSet.KvRwSet.Writes
+			nsRWSet.RangeQueries = nsRwSet.KvRwSet.RangeQueriesInfo
+		}
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			hashes := &CollectionHashes{
+				Collection:   collHashedRwSet.CollectionName,
+				PvtRWSetHash: collHashedRwSet.PvtRwSetHash,
+			}
+			if collHashedRwSet.HashedRwSet != nil {
+				hashes.HashedReads = collHashedRwSet.HashedRwSet.HashedReads
+				hashes.HashedWrites = collHashedRwSet.HashedRwSet.HashedWrites
+			}
+			nsRWSet.CollectionHashes = append(nsRWSet.CollectionHashes, hashes)
+		}
+		nsRWSets = append(nsRWSets, nsRWSet)
+	}
+	return nsRWSets, nil
+}
+
+func decodeIdentity(serialized []byte) (*Identity, error) {
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serialized, identity); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling serialized identity")
+	}
+	return &Identity{MSPID: identity.Mspid, IDBytes: identity.IdBytes}, nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockdecoder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	ledgerutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+const (
+	channelID = "mychannel"
+	txID      = "txid1"
+	ccID      = "examplecc"
+)
+
+func TestDecodeBlock(t *testing.T) {
+	timestamp := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	block := &cb.Block{
+		Header: &cb.BlockHeader{Number: 7, PreviousHash: []byte("prev"), DataHash: []byte("data")},
+		Data:   &cb.BlockData{Data: [][]byte{newEndorserTxEnvelope(t, timestamp), newEndorserTxEnvelope(t, timestamp)}},
+		Metadata: &cb.BlockMetadata{Metadata: [][]byte{nil, nil,
+			ledgerutil.TxValidationFlags{uint8(pb.TxValidationCode_VALID), uint8(pb.TxValidationCode_MVCC_READ_CONFLICT)},
+		}},
+	}
+
+	decoded, err := DecodeBlock(block)
+	if err != nil {
+		t.Fatalf("Failed to decode block: %s", err)
+	}
+
+	if decoded.Number != 7 || string(decoded.PreviousHash) != "prev" || string(decoded.DataHash) != "data" {
+		t.Fatalf("Unexpected block header: %+v", decoded)
+	}
+	if decoded.ChannelConfig != nil {
+		t.Fatal("Expected no channel config for endorser block")
+	}
+	if len(decoded.Transactions) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(decoded.Transactions))
+	}
+	if decoded.Transactions[1].Index != 1 || decoded.Transactions[1].ValidationCode != pb.TxValidationCode_MVCC_READ_CONFLICT {
+		t.Fatalf("Unexpected validation code for transaction 1: %s", decoded.Transactions[1].ValidationCode)
+	}
+
+	tx := decoded.Transactions[0]
+	if tx.TxID != txID || tx.ChannelID != channelID || tx.Type != cb.HeaderType_ENDORSER_TRANSACTION || tx.ValidationCode != pb.TxValidationCode_VALID {
+		t.Fatalf("Unexpected transaction: %+v", tx)
+	}
+	if !tx.Timestamp.Equal(timestamp) {
+		t.Fatalf("Unexpected timestamp: %s", tx.Timestamp)
+	}
+	if tx.Creator == nil || tx.Creator.MSPID != "Org1MSP" {
+		t.Fatalf("Unexpected creator: %+v", tx.Creator)
+	}
+	cert, err := tx.Creator.Certificate()
+	if err != nil || cert.Subject.CommonName != "example.com" {
+		t.Fatalf("Unexpected creator certificate: %v, %v", cert, err)
+	}
+
+	if len(tx.Actions) != 1 {
+		t.Fatalf("Expected 1 action, got %d", len(tx.Actions))
+	}
+	action := tx.Actions[0]
+	if action.ChaincodeID.Name != ccID || action.Response.Status != 200 {
+		t.Fatalf("Unexpected chaincode action: %+v", action)
+	}
+	if action.Input == nil || len(action.Input.Args) != 2 || string(action.Input.Args[0]) != "invoke" {
+		t.Fatalf("Unexpected chaincode input: %v", action.Input)
+	}
+	if len(action.Endorsements) != 2 || action.Endorsements[1].Endorser.MSPID != "Org2MSP" || string(action.Endorsements[1].Signature) != "sig2" {
+		t.Fatalf("Unexpected endorsements: %v", action.Endorsements)
+	}
+	if action.Event == nil || action.Event.EventName != "event1" || action.Event.TxId != txID {
+		t.Fatalf("Unexpected chaincode event: %v", action.Event)
+	}
+
+	if len(action.RWSets) != 1 {
+		t.Fatalf("Expected 1 namespace read/write set, got %d", len(action.RWSets))
+	}
+	rwSet := action.RWSets[0]
+	if rwSet.Namespace != ccID || len(rwSet.Reads) != 1 || rwSet.Reads[0].Key != "key1" ||
+		len(rwSet.Writes) != 1 || string(rwSet.Writes[0].Value) != "value2" || len(rwSet.RangeQueries) != 1 {
+		t.Fatalf("Unexpected read/write set: %+v", rwSet)
+	}
+	if len(rwSet.CollectionHashes) != 1 || rwSet.CollectionHashes[0].Collection != "coll1" ||
+		len(rwSet.CollectionHashes[0].HashedWrites) != 1 || string(rwSet.CollectionHashes[0].PvtRWSetHash) != "pvthash" {
+		t.Fatalf("Unexpected collection hashes: %+v", rwSet.CollectionHashes)
+	}
+}
+
+func TestDecodeConfigBlock(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP", "Org2MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+		},
+		Index: 3,
+	}
+
+	decoded, err := DecodeBlock(builder.Build())
+	if err != nil {
+		t.Fatalf("Failed to decode config block: %s", err)
+	}
+	if decoded.Number != 3 || len(decoded.Transactions) != 1 || decoded.Transactions[0].Type != cb.HeaderType_CONFIG {
+		t.Fatalf("Unexpected config block: %+v", decoded)
+	}
+	if decoded.ChannelConfig == nil {
+		t.Fatal("Expected channel config")
+	}
+	msps := make(map[string]bool)
+	for _, mspConfig := range decoded.ChannelConfig.Msps() {
+		fabricMSPConfig := &msp.FabricMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, fabricMSPConfig); err != nil {
+			t.Fatal(err)
+		}
+		msps[fabricMSPConfig.Name] = true
+	}
+	if !msps["Org1MSP"] || !msps["Org2MSP"] {
+		t.Fatalf("Expected MSPs of both orgs, got %v", msps)
+	}
+	orderers := decoded.ChannelConfig.Orderers()
+	if len(orderers) != 1 || orderers[0] != "localhost:7054" {
+		t.Fatalf("Unexpected orderers: %v", orderers)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, err := DecodeBlock(nil); err == nil {
+		t.Fatal("Expected error for nil block")
+	}
+
+	block := &cb.Block{
+		Header: &cb.BlockHeader{},
+		Data:   &cb.BlockData{Data: [][]byte{[]byte("invalid")}},
+	}
+	if _, err := DecodeBlock(block); err == nil {
+		t.Fatal("Expected error for invalid envelope")
+	}
+
+	if _, err := DecodeTransaction(marshal(t, &cb.Envelope{Payload: marshal(t, &cb.Payload{})})); err == nil {
+		t.Fatal("Expected error for missing payload header")
+	}
+}
+
+func TestDecodeTransactionEvents(t *testing.T) {
+	tx, err := DecodeTransactionEvents(newEndorserTxEnvelope(t, time.Now()))
+	if err != nil {
+		t.Fatalf("Failed to decode transaction events: %s", err)
+	}
+	if tx.TxID != txID || tx.ChannelID != channelID || tx.Type != cb.HeaderType_ENDORSER_TRANSACTION {
+		t.Fatalf("Unexpected transaction: %+v", tx)
+	}
+	if tx.Creator != nil {
+		t.Fatalf("Expected creator not to be decoded: %+v", tx.Creator)
+	}
+	if len(tx.Actions) != 1 {
+		t.Fatalf("Expected 1 action, got %d", len(tx.Actions))
+	}
+	action := tx.Actions[0]
+	if action.Event == nil || action.Event.EventName != "event1" || action.Event.TxId != txID {
+		t.Fatalf("Unexpected chaincode event: %v", action.Event)
+	}
+	if action.Input != nil || action.Endorsements != nil || action.RWSets != nil {
+		t.Fatalf("Expected only the chaincode event to be decoded: %+v", action)
+	}
+
+	// A transaction whose body can't be decoded is still returned with its header
+	payload := &cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader: marshal(t, &cb.ChannelHeader{
+				Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+				ChannelId: channelID,
+				TxId:      txID,
+			}),
+		},
+		Data: []byte("invalid"),
+	}
+	tx, err = DecodeTransactionEvents(marshal(t, &cb.Envelope{Payload: marshal(t, payload)}))
+	if err == nil {
+		t.Fatal("Expected error for invalid transaction body")
+	}
+	if tx == nil || tx.TxID != txID || tx.ChannelID != channelID || len(tx.Actions) != 0 {
+		t.Fatalf("Expected transaction header to be returned: %+v", tx)
+	}
+
+	if tx, err := DecodeTransactionEvents([]byte("invalid")); err == nil || tx != nil {
+		t.Fatal("Expected error and no transaction for invalid envelope")
+	}
+}
+
+func newEndorserTxEnvelope(t *testing.T, timestamp time.Time) []byte {
+	ts, err := ptypes.TimestampProto(timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txRwSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{{
+			NameSpace: ccID,
+			KvRwSet: &kvrwset.KVRWSet{
+				Reads:            []*kvrwset.KVRead{{Key: "key1", Version: &kvrwset.Version{BlockNum: 1, TxNum: 1}}},
+				RangeQueriesInfo: []*kvrwset.RangeQueryInfo{{StartKey: "a", EndKey: "z", ItrExhausted: true}},
+				Writes:           []*kvrwset.KVWrite{{Key: "key2", Value: []byte("value2")}},
+			},
+			CollHashedRwSets: []*rwsetutil.CollHashedRwSet{{
+				CollectionName: "coll1",
+				HashedRwSet: &kvrwset.HashedRWSet{
+					HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte("keyhash"), ValueHash: []byte("valuehash")}},
+				},
+				PvtRwSetHash: []byte("pvthash"),
+			}},
+		}},
+	}
+	results, err := txRwSet.ToProtoBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ccAction := &pb.ChaincodeAction{
+		ChaincodeId: &pb.ChaincodeID{Name: ccID, Version: "v1"},
+		Response:    &pb.Response{Status: 200},
+		Results:     results,
+		Events:      marshal(t, &pb.ChaincodeEvent{ChaincodeId: ccID, TxId: txID, EventName: "event1", Payload: []byte("payload")}),
+	}
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: ccID},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke"), []byte("arg1")}},
+		},
+	}
+	ccActionPayload := &pb.ChaincodeActionPayload{
+		ChaincodeProposalPayload: marshal(t, &pb.ChaincodeProposalPayload{Input: marshal(t, cis)}),
+		Action: &pb.ChaincodeEndorsedAction{
+			ProposalResponsePayload: marshal(t, &pb.ProposalResponsePayload{Extension: marshal(t, ccAction)}),
+			Endorsements: []*pb.Endorsement{
+				{Endorser: marshal(t, &msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte(validRootCA)}), Signature: []byte("sig1")},
+				{Endorser: marshal(t, &msp.SerializedIdentity{Mspid: "Org2MSP", IdBytes: []byte(validRootCA)}), Signature: []byte("sig2")},
+			},
+		},
+	}
+	tx := &pb.Transaction{
+		Actions: []*pb.TransactionAction{{Payload: marshal(t, ccActionPayload)}},
+	}
+
+	payload := &cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader: marshal(t, &cb.ChannelHeader{
+				Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+				ChannelId: channelID,
+				TxId:      txID,
+				Timestamp: ts,
+			}),
+			SignatureHeader: marshal(t, &cb.SignatureHeader{
+				Creator: marshal(t, &msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte(validRootCA)}),
+			}),
+		},
+		Data: marshal(t, tx),
+	}
+	return marshal(t, &cb.Envelope{Payload: marshal(t, payload)})
+}
+
+func marshal(t *testing.T, msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+var validRootCA = `-----BEGIN CERTIFICATE-----
+MIICYjCCAgmgAwIBAgIUB3CTDOU47sUC5K4kn/Caqnh114YwCgYIKoZIzj0EAwIw
+fzELMAkGA1UEBhMCVVMxEzARBgNVBAgTCkNhbGlmb3JuaWExFjAUBgNVBAcTDVNh
+biBGcmFuY2lzY28xHzAdBgNVBAoTFkludGVybmV0IFdpZGdldHMsIEluYy4xDDAK
+BgNVBAsTA1dXVzEUMBIGA1UEAxMLZXhhbXBsZS5jb20wHhcNMTYxMDEyMTkzMTAw
+WhcNMjExMDExMTkzMTAwWjB/MQswCQYDVQQGEwJVUzETMBEGA1UECBMKQ2FsaWZv
+cm5pYTEWMBQGA1UEBxMNU2FuIEZyYW5jaXNjbzEfMB0GA1UEChMWSW50ZXJuZXQg
+V2lkZ2V0cywgSW5jLjEMMAoGA1UECxMDV1dXMRQwEgYDVQQDEwtleGFtcGxlLmNv
+bTBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABKIH5b2JaSmqiQXHyqC+cmknICcF
+i5AddVjsQizDV6uZ4v6s+PWiJyzfA/rTtMvYAPq/yeEHpBUB1j053mxnpMujYzBh
+MA4GA1UdDwEB/wQEAwIBBjAPBgNVHRMBAf8EBTADAQH/MB0GA1UdDgQWBBQXZ0I9
+qp6CP8TFHZ9bw5nRtZxIEDAfBgNVHSMEGDAWgBQXZ0I9qp6CP8TFHZ9bw5nRtZxI
+EDAKBggqhkjOPQQDAgNHADBEAiAHp5Rbp9Em1G/UmKn8WsCbqDfWecVbZPQj3RK4
+oG5kQQIgQAe4OOKYhJdh3f7URaKfGTf492/nmRmtK+ySKjpHSrU=
+-----END CERTIFICATE-----
+`
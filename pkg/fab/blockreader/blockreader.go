@@ -0,0 +1,252 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package blockreader reads a bounded range of blocks from the deliver service of a peer
+// or an orderer. Blocks are received over a single stream rather than queried one at a time.
+package blockreader
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
+	fabcontext "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	ccomm "github.com/hyperledger/fabric-sdk-go/pkg/core/config/comm"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
+	deliverconn "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/connection"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/hyperledger/fabric-sdk-go/pkg/options"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// Iterator iterates over the blocks of a closed range [from, to]. A block is only
+// received from the server when Next is called, so a slow caller holds back the
+// server rather than causing blocks to be buffered.
+//
+// The range is read with FAIL_IF_NOT_READY semantics: if a block in the range hasn't
+// been committed then Next returns an error instead of waiting for it.
+type Iterator struct {
+	stream blockStream
+	next   uint64
+	to     uint64
+	err    error
+}
+
+// blockStream is a stream of the blocks requested by a seek
+type blockStream interface {
+	// recv returns the next block. A nil block and nil error indicate that
+	// the server has successfully delivered all of the requested blocks.
+	recv() (*cb.Block, error)
+	close()
+}
+
+// FromPeer returns an iterator over the blocks [from, to] of the channel, read from the
+// Deliver service of the given peer. The caller must have permission to receive blocks.
+// The options are passed to the connection (see fab/comm).
+func FromPeer(ctx fabcontext.Context, channelID string, peer fab.Peer, from, to uint64, opts ...options.Opt) (*Iterator, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	conn, err := deliverconn.New(ctx, channelID, deliverconn.Deliver, peer.URL(), opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to connect to deliver service")
+	}
+	if err := conn.Send(seek.InfoRange(from, to)); err != nil {
+		conn.Close()
+		return nil, errors.WithMessage(err, "failed to send seek request")
+	}
+
+	return newIterator(&peerStream{conn: conn}, from, to), nil
+}
+
+// FromOrderer returns an iterator over the blocks [from, to] of the channel, read from the
+// Deliver service of the given orderer. The orderer's connection timeout (core.OrdererConnection)
+// only applies to connecting; the stream stays open until the range has been read or the
+// iterator is closed.
+func FromOrderer(ctx fabcontext.Context, channelID string, orderer fab.Orderer, from, to uint64) (*Iterator, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	envelope, err := seekEnvelope(ctx, channelID, seek.InfoRange(from, to))
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, errs, cancel := orderer.SendDeliverContext(context.Background(), envelope)
+	return newIterator(&ordererStream{blocks: blocks, errs: errs, cancel: cancel}, from, to), nil
+}
+
+func newIterator(stream blockStream, from, to uint64) *Iterator {
+	return &Iterator{stream: stream, next: from, to: to}
+}
+
+func validateRange(from, to uint64) error {
+	if from > to {
+		return errors.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	return nil
+}
+
+// Next returns the next block in the range. io.EOF is returned once all of the blocks in
+// the range have been returned. If an error is returned then all subsequent calls return
+// the same error.
+func (it *Iterator) Next() (*cb.Block, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	block, err := it.stream.recv()
+	if err != nil {
+		return nil, it.fail(errors.WithMessage(err, fmt.Sprintf("failed to read block %d", it.next)))
+	}
+	if block == nil {
+		return nil, it.fail(errors.Errorf("deliver service completed before block %d", it.next))
+	}
+
+	var number uint64
+	if block.Header != nil {
+		number = block.Header.Number
+	}
+	if number != it.next {
+		return nil, it.fail(errors.Errorf("expecting block %d but received block %d", it.next, number))
+	}
+
+	if it.next == it.to {
+		it.fail(io.EOF)
+	} else {
+		it.next++
+	}
+	return block, nil
+}
+
+// Close releases the resources of the iterator. It must be called if the iterator
+// isn't read to the end.
+func (it *Iterator) Close() {
+	it.stream.close()
+	if it.err == nil {
+		it.err = errors.New("iterator is closed")
+	}
+}
+
+func (it *Iterator) fail(err error) error {
+	it.err = err
+	it.stream.close()
+	return err
+}
+
+// peerStream receives blocks from a peer's Deliver service
+type peerStream struct {
+	conn *deliverconn.DeliverConnection
+}
+
+func (s *peerStream) recv() (*cb.Block, error) {
+	response := &pb.DeliverResponse{}
+	if err := s.conn.Stream().RecvMsg(response); err != nil {
+		return nil, errors.Wrap(err, "receive from deliver service failed")
+	}
+
+	switch t := response.Type.(type) {
+	case *pb.DeliverResponse_Block:
+		return t.Block, nil
+	case *pb.DeliverResponse_Status:
+		if t.Status != cb.Status_SUCCESS {
+			return nil, status.New(status.DeliverServerStatus, int32(t.Status), "received error status from deliver service", nil)
+		}
+		// The peer sends SUCCESS only once all of the requested blocks have been sent
+		return nil, nil
+	default:
+		return nil, errors.Errorf("unexpected response from deliver service: %T", t)
+	}
+}
+
+func (s *peerStream) close() {
+	s.conn.Close()
+}
+
+// ordererStream receives blocks from an orderer's Deliver service
+type ordererStream struct {
+	blocks   chan *cb.Block
+	errs     chan error
+	cancel   func()
+	finished bool
+	closed   bool
+}
+
+func (s *ordererStream) recv() (*cb.Block, error) {
+	select {
+	case block, ok := <-s.blocks:
+		if !ok {
+			s.finished = true
+			return nil, nil
+		}
+		return block, nil
+	case err := <-s.errs:
+		s.finished = true
+		return nil, err
+	}
+}
+
+func (s *ordererStream) close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.cancel()
+
+	if s.finished {
+		return
+	}
+	// The orderer may be waiting to deliver a block. Discard it so that the stream can exit.
+	go func() {
+		for {
+			select {
+			case _, ok := <-s.blocks:
+				if !ok {
+					return
+				}
+			case <-s.errs:
+				return
+			}
+		}
+	}()
+}
+
+// seekEnvelope returns the signed envelope of a seek request on the given channel
+func seekEnvelope(ctx fabcontext.Context, channelID string, seekInfo *ab.SeekInfo) (*fab.SignedEnvelope, error) {
+	txh, err := txn.NewHeader(ctx, channelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to calculate transaction id")
+	}
+
+	seekInfoBytes, err := proto.Marshal(seekInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling of seek info failed")
+	}
+
+	channelHeader, err := txn.CreateChannelHeader(cb.HeaderType_DELIVER_SEEK_INFO, txn.ChannelHeaderOpts{
+		TxnHeader:   txh,
+		TLSCertHash: ccomm.TLSCertHash(ctx.Config()),
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "CreateChannelHeader failed")
+	}
+
+	payload, err := txn.CreatePayload(txh, channelHeader, seekInfoBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "CreatePayload failed")
+	}
+
+	return txn.SignPayload(ctx, payload)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockreader
+
+import (
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
+	eventmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/mocks"
+	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+)
+
+const channelID = "mychannel"
+
+func TestFromPeer(t *testing.T) {
+	deliverPeer := startDeliverPeer(t, 5)
+	defer deliverPeer.Stop()
+
+	it, err := FromPeer(newMockContext(), channelID, fabmocks.NewMockPeer("peer1", deliverPeer.URL()), 1, 3)
+	if err != nil {
+		t.Fatalf("error creating iterator: %s", err)
+	}
+	defer it.Close()
+
+	checkBlocks(t, it, 1, 3)
+	if _, err := it.Next(); err != io.EOF {
+		t.Fatalf("expecting io.EOF after the last block but got: %v", err)
+	}
+
+	seeks := deliverPeer.SeekRequests()
+	if len(seeks) != 1 {
+		t.Fatalf("expecting one seek request but got %d", len(seeks))
+	}
+	if seeks[0].Behavior != ab.SeekInfo_FAIL_IF_NOT_READY || seeks[0].Stop.GetSpecified().GetNumber() != 3 {
+		t.Fatalf("expecting bounded FAIL_IF_NOT_READY seek but got %v", seeks[0])
+	}
+}
+
+func TestFromPeerNotReady(t *testing.T) {
+	deliverPeer := startDeliverPeer(t, 5)
+	defer deliverPeer.Stop()
+
+	it, err := FromPeer(newMockContext(), channelID, fabmocks.NewMockPeer("peer1", deliverPeer.URL()), 3, 7)
+	if err != nil {
+		t.Fatalf("error creating iterator: %s", err)
+	}
+	defer it.Close()
+
+	checkBlocks(t, it, 3, 4)
+
+	_, err = it.Next()
+	s, ok := status.FromError(err)
+	if !ok || s.Group != status.DeliverServerStatus || s.Code != int32(cb.Status_NOT_FOUND) {
+		t.Fatalf("expecting NOT_FOUND status for uncommitted block but got: %v", err)
+	}
+	if _, err2 := it.Next(); err2 != err {
+		t.Fatalf("expecting the same error on subsequent calls but got: %v", err2)
+	}
+}
+
+func TestFromPeerEarlySuccess(t *testing.T) {
+	deliverPeer := startDeliverPeer(t, 5)
+	defer deliverPeer.Stop()
+
+	// SUCCESS before any block ends the range
+	deliverPeer.SetSeekAck(true)
+
+	it, err := FromPeer(newMockContext(), channelID, fabmocks.NewMockPeer("peer1", deliverPeer.URL()), 1, 3)
+	if err != nil {
+		t.Fatalf("error creating iterator: %s", err)
+	}
+	defer it.Close()
+
+	if _, err := it.Next(); err == nil || err == io.EOF {
+		t.Fatalf("expecting error for deliver service completing before the first block but got: %v", err)
+	}
+}
+
+func TestInvalidRange(t *testing.T) {
+	if _, err := FromPeer(newMockContext(), channelID, fabmocks.NewMockPeer("peer1", "grpc://localhost:9999"), 2, 1); err == nil {
+		t.Fatalf("expecting error for invalid range")
+	}
+	if _, err := FromOrderer(newMockContext(), channelID, fabmocks.NewMockOrderer("", nil), 2, 1); err == nil {
+		t.Fatalf("expecting error for invalid range")
+	}
+}
+
+func TestFromOrderer(t *testing.T) {
+	orderer := fabmocks.NewMockOrderer("", nil).(fabmocks.MockOrderer)
+	for i := uint64(5); i <= 7; i++ {
+		orderer.EnqueueForSendDeliver(newBlock(i))
+	}
+
+	it, err := FromOrderer(newMockContext(), channelID, orderer, 5, 7)
+	if err != nil {
+		t.Fatalf("error creating iterator: %s", err)
+	}
+	defer it.Close()
+
+	checkBlocks(t, it, 5, 7)
+	if _, err := it.Next(); err != io.EOF {
+		t.Fatalf("expecting io.EOF after the last block but got: %v", err)
+	}
+}
+
+func TestFromOrdererErrors(t *testing.T) {
+	// Block out of sequence
+	orderer := fabmocks.NewMockOrderer("", nil).(fabmocks.MockOrderer)
+	orderer.EnqueueForSendDeliver(newBlock(1))
+
+	it, err := FromOrderer(newMockContext(), channelID, orderer, 0, 1)
+	if err != nil {
+		t.Fatalf("error creating iterator: %s", err)
+	}
+	if _, err := it.Next(); err == nil {
+		t.Fatalf("expecting error for block out of sequence")
+	}
+	it.Close()
+
+	// Error from the orderer
+	orderer = fabmocks.NewMockOrderer("", nil).(fabmocks.MockOrderer)
+	orderer.EnqueueForSendDeliver(errors.New("error status from ordering service NOT_FOUND"))
+
+	it, err = FromOrderer(newMockContext(), channelID, orderer, 0, 1)
+	if err != nil {
+		t.Fatalf("error creating iterator: %s", err)
+	}
+	defer it.Close()
+	if _, err := it.Next(); err == nil {
+		t.Fatalf("expecting error from orderer")
+	}
+}
+
+func startDeliverPeer(t *testing.T, height int) *eventmocks.MockDeliverPeer {
+	deliverPeer := eventmocks.NewMockDeliverPeer(channelID)
+	for i := 0; i < height; i++ {
+		deliverPeer.AddTransactions()
+	}
+	if err := deliverPeer.Start("localhost:0"); err != nil {
+		t.Fatalf("error starting mock deliver peer: %s", err)
+	}
+	return deliverPeer
+}
+
+func checkBlocks(t *testing.T, it *Iterator, from, to uint64) {
+	for expected := from; expected <= to; expected++ {
+		block, err := it.Next()
+		if err != nil {
+			t.Fatalf("error reading block %d: %s", expected, err)
+		}
+		if block.Header.Number != expected {
+			t.Fatalf("expecting block %d but got %d", expected, block.Header.Number)
+		}
+	}
+}
+
+func newBlock(number uint64) *cb.Block {
+	return &cb.Block{Header: &cb.BlockHeader{Number: number}, Data: &cb.BlockData{}}
+}
+
+func newMockContext() *fabmocks.MockContext {
+	return fabmocks.NewMockContext(fabmocks.NewMockUser("user1"))
+}
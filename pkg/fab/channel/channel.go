@@ -25,7 +25,7 @@ import (
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/channel")
 
 // Channel  captures settings for a channel, which is created by
 // the orderers to isolate transactions delivery to peers participating on channel.
@@ -67,7 +67,7 @@ func New(ctx context.Context, cfg fab.ChannelCfg) (*Channel, error) {
 
 	mspManager := msp.NewMSPManager()
 	if len(cfg.Msps()) > 0 {
-		msps, err := loadMSPs(cfg.Msps(), ctx.CryptoSuite())
+		msps, err := c.loadMSPs(cfg.Msps(), ctx.CryptoSuite())
 		if err != nil {
 			return nil, errors.WithMessage(err, "load MSPs from config failed")
 		}
@@ -100,9 +100,9 @@ func New(ctx context.Context, cfg fab.ChannelCfg) (*Channel, error) {
 
 		var o *orderer.Orderer
 		if oCfg == nil {
-			o, err = orderer.New(ctx.Config(), orderer.WithURL(name), orderer.WithServerName(resolveOrdererAddress(name)))
+			o, err = orderer.New(ctx.Config(), orderer.WithURL(name), orderer.WithServerName(resolveOrdererAddress(name)), orderer.WithLoggerProvider(ctx.LoggerProvider()))
 		} else {
-			o, err = orderer.New(ctx.Config(), orderer.FromOrdererConfig(oCfg))
+			o, err = orderer.New(ctx.Config(), orderer.FromOrdererConfig(oCfg), orderer.WithLoggerProvider(ctx.LoggerProvider()))
 		}
 
 		if err != nil {
@@ -112,7 +112,7 @@ func New(ctx context.Context, cfg fab.ChannelCfg) (*Channel, error) {
 		c.orderers[o.URL()] = o
 	}
 
-	logger.Debugf("Constructed channel instance for channel %s: %v", c.name, c)
+	c.logger().Debugf("Constructed channel instance for channel %s: %v", c.name, c)
 
 	return &c, nil
 }
@@ -122,6 +122,11 @@ func (c *Channel) Name() string {
 	return c.name
 }
 
+// logger returns the package logger bound to the logger provider of the client context
+func (c *Channel) logger() *logging.Logger {
+	return logger.ForProvider(c.clientContext.LoggerProvider())
+}
+
 // AddPeer adds a peer endpoint to channel.
 // It returns error if the peer with that url already exists.
 func (c *Channel) AddPeer(peer fab.Peer) error {
@@ -138,7 +143,7 @@ func (c *Channel) RemovePeer(peer fab.Peer) {
 	url := peer.URL()
 	if c.peers[url] != nil {
 		delete(c.peers, url)
-		logger.Debugf("Removed peer with URL %s", url)
+		c.logger().Debugf("Removed peer with URL %s", url)
 	}
 }
 
@@ -189,7 +194,7 @@ func (c *Channel) PrimaryPeer() fab.Peer {
 	// When no primary peer has been set default to the first peer
 	// from map range - order is not guaranteed
 	for _, peer := range c.peers {
-		logger.Debugf("Primary peer was not set, using %s", peer.URL())
+		c.logger().Debugf("Primary peer was not set, using %s", peer.URL())
 		return peer
 	}
 
@@ -218,7 +223,7 @@ func (c *Channel) RemoveOrderer(orderer fab.Orderer) {
 	url := orderer.URL()
 	if c.orderers[url] != nil {
 		delete(c.orderers, url)
-		logger.Debugf("Removed orderer with URL %s", url)
+		c.logger().Debugf("Removed orderer with URL %s", url)
 	}
 }
 
@@ -250,14 +255,14 @@ func (c *Channel) OrganizationUnits() ([]string, error) {
 	channelMSPManager := c.MSPManager()
 	msps, err := channelMSPManager.GetMSPs()
 	if err != nil {
-		logger.Info("Cannot get channel manager")
+		c.logger().Info("Cannot get channel manager")
 		return nil, errors.WithMessage(err, "organization units were not set")
 	}
 	var orgIdentifiers []string
 	for _, v := range msps {
 		orgName, err := v.GetIdentifier()
 		if err != nil {
-			logger.Info("Organization does not have an identifier")
+			c.logger().Info("Organization does not have an identifier")
 		}
 		orgIdentifiers = append(orgIdentifiers, orgName)
 	}
@@ -23,21 +23,21 @@ import (
 // @see /protos/orderer/ab.proto
 // @see /protos/common/configtx.proto
 func (c *Channel) ChannelConfig() (*common.ConfigEnvelope, error) {
-	logger.Debugf("channelConfig - start for channel %s", c.name)
+	c.logger().Debugf("channelConfig - start for channel %s", c.name)
 
 	// Get the newest block
 	block, err := c.block(newNewestSeekPosition())
 	if err != nil {
 		return nil, err
 	}
-	logger.Debugf("channelConfig - Retrieved newest block number: %d\n", block.Header.Number)
+	c.logger().Debugf("channelConfig - Retrieved newest block number: %d\n", block.Header.Number)
 
 	// Get the index of the last config block
 	lastConfig, err := getLastConfigFromBlock(block)
 	if err != nil {
 		return nil, errors.Wrap(err, "GetLastConfigFromBlock failed")
 	}
-	logger.Debugf("channelConfig - Last config index: %d\n", lastConfig.Index)
+	c.logger().Debugf("channelConfig - Last config index: %d\n", lastConfig.Index)
 
 	// Get the last config block
 	block, err = c.block(newSpecificSeekPosition(lastConfig.Index))
@@ -45,7 +45,7 @@ func (c *Channel) ChannelConfig() (*common.ConfigEnvelope, error) {
 	if err != nil {
 		return nil, errors.WithMessage(err, "retrieve block failed")
 	}
-	logger.Debugf("channelConfig - Last config block number %d, Number of tx: %d", block.Header.Number, len(block.Data.Data))
+	c.logger().Debugf("channelConfig - Last config block number %d, Number of tx: %d", block.Header.Number, len(block.Data.Data))
 
 	if len(block.Data.Data) != 1 {
 		return nil, errors.New("apiconfig block must contain one transaction")
@@ -80,8 +80,8 @@ func createConfigEnvelope(data []byte) (*common.ConfigEnvelope, error) {
 	return configEnvelope, nil
 }
 
-func loadMSPs(mspConfigs []*mb.MSPConfig, cs core.CryptoSuite) ([]msp.MSP, error) {
-	logger.Debugf("loadMSPs - start number of msps=%d", len(mspConfigs))
+func (c *Channel) loadMSPs(mspConfigs []*mb.MSPConfig, cs core.CryptoSuite) ([]msp.MSP, error) {
+	c.logger().Debugf("loadMSPs - start number of msps=%d", len(mspConfigs))
 
 	msps := []msp.MSP{}
 	for _, config := range mspConfigs {
@@ -114,7 +114,7 @@ func loadMSPs(mspConfigs []*mb.MSPConfig, cs core.CryptoSuite) ([]msp.MSP, error
 		var orgs []string
 		orgUnits := fabricConfig.OrganizationalUnitIdentifiers
 		for _, orgUnit := range orgUnits {
-			logger.Debugf("loadMSPs - found org of :: %s", orgUnit.OrganizationalUnitIdentifier)
+			c.logger().Debugf("loadMSPs - found org of :: %s", orgUnit.OrganizationalUnitIdentifier)
 			orgs = append(orgs, orgUnit.OrganizationalUnitIdentifier)
 		}
 
@@ -130,12 +130,12 @@ func loadMSPs(mspConfigs []*mb.MSPConfig, cs core.CryptoSuite) ([]msp.MSP, error
 		}
 
 		mspID, _ := newMSP.GetIdentifier()
-		logger.Debugf("loadMSPs - adding msp=%s", mspID)
+		c.logger().Debugf("loadMSPs - adding msp=%s", mspID)
 
 		msps = append(msps, newMSP)
 	}
 
-	logger.Debugf("loadMSPs - loaded %d MSPs", len(msps))
+	c.logger().Debugf("loadMSPs - loaded %d MSPs", len(msps))
 	return msps, nil
 }
 
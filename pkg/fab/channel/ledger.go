@@ -38,7 +38,7 @@ func NewLedger(ctx context.Context, chName string) (*Ledger, error) {
 // QueryInfo queries for various useful information on the state of the channel
 // (height, known peers).
 func (c *Ledger) QueryInfo(targets []fab.ProposalProcessor) ([]*common.BlockchainInfo, error) {
-	logger.Debug("queryInfo - start")
+	logger.ForProvider(c.ctx.LoggerProvider()).Debug("queryInfo - start")
 
 	cir := createChannelInfoInvokeRequest(c.chName)
 	tprs, errs := queryChaincode(c.ctx, fab.SystemChannel, cir, targets)
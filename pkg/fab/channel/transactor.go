@@ -59,7 +59,7 @@ func orderersFromChannelCfg(ctx context.Context, cfg fab.ChannelCfg) ([]fab.Orde
 
 		if !ok {
 			// TODO: need default options
-			o, err := orderer.New(ctx.Config(), orderer.WithURL(target))
+			o, err := orderer.New(ctx.Config(), orderer.WithURL(target), orderer.WithLoggerProvider(ctx.LoggerProvider()))
 			// TODO: should we fail hard if we cannot configure a default orderer?
 			//if err != nil {
 			//	return nil, errors.WithMessage(err, "failed to create orderer from defaults")
@@ -68,7 +68,7 @@ func orderersFromChannelCfg(ctx context.Context, cfg fab.ChannelCfg) ([]fab.Orde
 				orderers = append(orderers, o)
 			}
 		} else {
-			o, err := orderer.New(ctx.Config(), orderer.FromOrdererConfig(&oCfg))
+			o, err := orderer.New(ctx.Config(), orderer.FromOrdererConfig(&oCfg), orderer.WithLoggerProvider(ctx.LoggerProvider()))
 			if err != nil {
 				return nil, errors.WithMessage(err, "failed to create orderer from config")
 			}
@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	reqContext "context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// waitPollInterval is the interval at which the targets are queried while waiting
+var waitPollInterval = 500 * time.Millisecond
+
+// WaitForBlockHeight waits until the ledger of each of the targets has at least the given height,
+// i.e. has committed block height-1. The targets are polled with QueryInfo.
+// An error is returned if ctx is done before all of the targets have reached the height.
+func WaitForBlockHeight(ctx reqContext.Context, ledger fab.ChannelLedger, height uint64, targets []fab.ProposalProcessor) error {
+	return waitForTargets(ctx, targets, fmt.Sprintf("block height %d", height), func(target fab.ProposalProcessor) bool {
+		infos, err := ledger.QueryInfo([]fab.ProposalProcessor{target})
+		if err != nil || len(infos) == 0 {
+			logger.Debugf("QueryInfo on %s failed: %v", targetName(target), err)
+			return false
+		}
+		return infos[0].Height >= height
+	}, allCommitted)
+}
+
+// WaitForTransaction waits until each of the targets has committed the given transaction
+// (whether or not the transaction is valid). The targets are polled with QueryTransaction.
+// An error is returned if ctx is done before all of the targets have committed the transaction.
+func WaitForTransaction(ctx reqContext.Context, ledger fab.ChannelLedger, txID fab.TransactionID, targets []fab.ProposalProcessor) error {
+	committed := transactionCommitted(ledger, txID, make(map[string]pb.TxValidationCode))
+	return waitForTargets(ctx, targets, fmt.Sprintf("transaction %s", txID), committed, allCommitted)
+}
+
+// WaitForTransactionCommits waits until the targets that have committed the given transaction satisfy
+// confirmed, which is called with the validation code reported by each of those targets (by URL).
+// The targets are polled with QueryTransaction. If confirmed returns an error then the wait ends with
+// that error. An error is also returned if ctx is done before the commits are confirmed.
+func WaitForTransactionCommits(ctx reqContext.Context, ledger fab.ChannelLedger, txID fab.TransactionID, targets []fab.ProposalProcessor, confirmed func(codes map[string]pb.TxValidationCode) (bool, error)) error {
+	codes := make(map[string]pb.TxValidationCode)
+	committed := transactionCommitted(ledger, txID, codes)
+	return waitForTargets(ctx, targets, fmt.Sprintf("transaction %s", txID), committed, func(pending []fab.ProposalProcessor) (bool, error) {
+		return confirmed(codes)
+	})
+}
+
+// transactionCommitted returns a function that queries a target for the transaction and records
+// the validation code reported by the target in codes
+func transactionCommitted(ledger fab.ChannelLedger, txID fab.TransactionID, codes map[string]pb.TxValidationCode) func(target fab.ProposalProcessor) bool {
+	return func(target fab.ProposalProcessor) bool {
+		txs, err := ledger.QueryTransaction(txID, []fab.ProposalProcessor{target})
+		if err != nil || len(txs) == 0 {
+			logger.Debugf("QueryTransaction %s on %s failed: %v", txID, targetName(target), err)
+			return false
+		}
+		codes[targetName(target)] = pb.TxValidationCode(txs[0].ValidationCode)
+		return true
+	}
+}
+
+// waitForTargets polls the targets that have not yet committed until done returns true (or an error)
+// for the targets that are still pending, or until ctx is done
+func waitForTargets(ctx reqContext.Context, targets []fab.ProposalProcessor, what string, committed func(target fab.ProposalProcessor) bool, done func(pending []fab.ProposalProcessor) (bool, error)) error {
+	if len(targets) == 0 {
+		return errors.New("targets are required")
+	}
+
+	pending := targets
+	for {
+		var remaining []fab.ProposalProcessor
+		for _, target := range pending {
+			if !committed(target) {
+				remaining = append(remaining, target)
+			}
+		}
+		pending = remaining
+
+		ok, err := done(pending)
+		if err != nil || ok {
+			return err
+		}
+		if len(pending) == 0 {
+			return errors.Errorf("%s committed on all targets without being confirmed", what)
+		}
+
+		select {
+		case <-ctx.Done():
+			names := make([]string, len(pending))
+			for i, target := range pending {
+				names[i] = targetName(target)
+			}
+			return errors.Wrapf(ctx.Err(), "%s not committed on [%s]", what, strings.Join(names, ", "))
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+// allCommitted is done once all of the targets have committed
+func allCommitted(pending []fab.ProposalProcessor) (bool, error) {
+	return len(pending) == 0, nil
+}
+
+func targetName(target fab.ProposalProcessor) string {
+	if peer, ok := target.(fab.Peer); ok {
+		return peer.URL()
+	}
+	return fmt.Sprintf("%T", target)
+}
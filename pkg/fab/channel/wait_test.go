@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	reqContext "context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestWaitForBlockHeight(t *testing.T) {
+	waitPollInterval = 10 * time.Millisecond
+
+	peer1 := mocks.NewMockPeer("Peer1", "http://peer1.com")
+	peer2 := mocks.NewMockPeer("Peer2", "http://peer2.com")
+	targets := []fab.ProposalProcessor{peer1, peer2}
+
+	ledger := mocks.NewMockChannelLedger()
+	ledger.SetHeight(peer1, 5)
+	ledger.SetHeight(peer2, 3)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ledger.SetHeight(peer2, 5)
+	}()
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+	if err := WaitForBlockHeight(ctx, ledger, 5, targets); err != nil {
+		t.Fatalf("WaitForBlockHeight failed: %s", err)
+	}
+
+	ctx, cancel = reqContext.WithTimeout(reqContext.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := WaitForBlockHeight(ctx, ledger, 6, targets)
+	if err == nil {
+		t.Fatalf("expecting WaitForBlockHeight to time out")
+	}
+	if !strings.Contains(err.Error(), "http://peer1.com") || !strings.Contains(err.Error(), "http://peer2.com") {
+		t.Fatalf("expecting error to contain the pending peers but got: %s", err)
+	}
+
+	if err := WaitForBlockHeight(ctx, ledger, 1, nil); err == nil {
+		t.Fatalf("expecting error without targets")
+	}
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	waitPollInterval = 10 * time.Millisecond
+
+	peer1 := mocks.NewMockPeer("Peer1", "http://peer1.com")
+	peer2 := mocks.NewMockPeer("Peer2", "http://peer2.com")
+	targets := []fab.ProposalProcessor{peer1, peer2}
+
+	ledger := mocks.NewMockChannelLedger()
+	ledger.CommitTransaction(peer1, "txid", pb.TxValidationCode_VALID)
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := WaitForTransaction(ctx, ledger, "txid", targets)
+	if err == nil {
+		t.Fatalf("expecting WaitForTransaction to time out")
+	}
+	if strings.Contains(err.Error(), "http://peer1.com") || !strings.Contains(err.Error(), "http://peer2.com") {
+		t.Fatalf("expecting error to contain only the pending peer but got: %s", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ledger.CommitTransaction(peer2, "txid", pb.TxValidationCode_MVCC_READ_CONFLICT)
+	}()
+
+	ctx, cancel = reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+	if err := WaitForTransaction(ctx, ledger, "txid", targets); err != nil {
+		t.Fatalf("WaitForTransaction failed: %s", err)
+	}
+}
+
+func TestWaitForTransactionCommits(t *testing.T) {
+	waitPollInterval = 10 * time.Millisecond
+
+	peer1 := mocks.NewMockPeer("Peer1", "http://peer1.com")
+	peer2 := mocks.NewMockPeer("Peer2", "http://peer2.com")
+	targets := []fab.ProposalProcessor{peer1, peer2}
+
+	ledger := mocks.NewMockChannelLedger()
+	ledger.CommitTransaction(peer2, "txid", pb.TxValidationCode_MVCC_READ_CONFLICT)
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+
+	// A single commit is enough
+	var confirmed map[string]pb.TxValidationCode
+	err := WaitForTransactionCommits(ctx, ledger, "txid", targets, func(codes map[string]pb.TxValidationCode) (bool, error) {
+		confirmed = codes
+		return len(codes) > 0, nil
+	})
+	if err != nil {
+		t.Fatalf("WaitForTransactionCommits failed: %s", err)
+	}
+	if len(confirmed) != 1 || confirmed["http://peer2.com"] != pb.TxValidationCode_MVCC_READ_CONFLICT {
+		t.Fatalf("unexpected validation codes: %v", confirmed)
+	}
+
+	// The wait ends with the error of the confirmation
+	err = WaitForTransactionCommits(ctx, ledger, "txid", targets, func(codes map[string]pb.TxValidationCode) (bool, error) {
+		return false, errors.New("mismatch")
+	})
+	if err == nil || err.Error() != "mismatch" {
+		t.Fatalf("expecting confirmation error but got: %v", err)
+	}
+}
@@ -25,7 +25,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/chconfig")
 
 const (
 	defaultMinResponses = 1
@@ -131,7 +131,7 @@ func (c *ChannelConfig) queryPeers() (*ChannelCfg, error) {
 		}
 
 		for _, p := range chPeers {
-			newPeer, err := peer.New(c.ctx.Config(), peer.FromPeerConfig(&p.NetworkPeer))
+			newPeer, err := peer.New(c.ctx.Config(), peer.FromPeerConfig(&p.NetworkPeer), peer.WithLoggerProvider(c.ctx.LoggerProvider()))
 			if err != nil || newPeer == nil {
 				return nil, errors.WithMessage(err, "NewPeer failed")
 			}
@@ -153,7 +153,7 @@ func (c *ChannelConfig) queryPeers() (*ChannelCfg, error) {
 		return nil, errors.WithMessage(err, "QueryBlockConfig failed")
 	}
 
-	return extractConfig(c.channelID, configEnvelope)
+	return extractConfig(logger.ForProvider(c.ctx.LoggerProvider()), c.channelID, configEnvelope)
 }
 
 func (c *ChannelConfig) queryOrderer() (*ChannelCfg, error) {
@@ -168,7 +168,7 @@ func (c *ChannelConfig) queryOrderer() (*ChannelCfg, error) {
 		return nil, errors.WithMessage(err, "ChannelConfig() failed")
 	}
 
-	return extractConfig(c.channelID, configEnvelope)
+	return extractConfig(logger.ForProvider(c.ctx.LoggerProvider()), c.channelID, configEnvelope)
 }
 
 // WithPeers encapsulates peers to Option
@@ -207,7 +207,16 @@ func prepareOpts(options ...Option) (Opts, error) {
 	return opts, nil
 }
 
-func extractConfig(channel string, configEnvelope *common.ConfigEnvelope) (*ChannelCfg, error) {
+// FromConfigEnvelope returns the channel configuration held in a config envelope,
+// e.g. the payload data of a config block's transaction
+func FromConfigEnvelope(channelID string, configEnvelope *common.ConfigEnvelope) (fab.ChannelCfg, error) {
+	if configEnvelope == nil || configEnvelope.Config == nil {
+		return nil, errors.New("config envelope has no config")
+	}
+	return extractConfig(logger, channelID, configEnvelope)
+}
+
+func extractConfig(logger *logging.Logger, channel string, configEnvelope *common.ConfigEnvelope) (*ChannelCfg, error) {
 
 	group := configEnvelope.Config.ChannelGroup
 
@@ -223,7 +232,7 @@ func extractConfig(channel string, configEnvelope *common.ConfigEnvelope) (*Chan
 		versions:    versions,
 	}
 
-	err := loadConfig(config, config.versions.Channel, group, "base", "", true)
+	err := loadConfig(logger, config, config.versions.Channel, group, "base", "", true)
 	if err != nil {
 		return nil, errors.WithMessage(err, "load config items from config group failed")
 	}
@@ -234,7 +243,7 @@ func extractConfig(channel string, configEnvelope *common.ConfigEnvelope) (*Chan
 
 }
 
-func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, group *common.ConfigGroup, name string, org string, top bool) error {
+func loadConfig(logger *logging.Logger, configItems *ChannelCfg, versionsGroup *common.ConfigGroup, group *common.ConfigGroup, name string, org string, top bool) error {
 	logger.Debugf("loadConfigGroup - %s - START groups Org: %s", name, org)
 	if group == nil {
 		return nil
@@ -251,7 +260,7 @@ func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, grou
 			logger.Debugf("loadConfigGroup - %s - found config group ==> %s", name, key)
 			// The Application group is where config settings are that we want to find
 			versionsGroup.Groups[key] = &common.ConfigGroup{}
-			loadConfig(configItems, versionsGroup.Groups[key], configGroup, name+"."+key, key, false)
+			loadConfig(logger, configItems, versionsGroup.Groups[key], configGroup, name+"."+key, key, false)
 		}
 	} else {
 		logger.Debugf("loadConfigGroup - %s - no groups", name)
@@ -264,7 +273,7 @@ func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, grou
 		versionsGroup.Values = make(map[string]*common.ConfigValue)
 		for key, configValue := range values {
 			versionsGroup.Values[key] = &common.ConfigValue{}
-			loadConfigValue(configItems, key, versionsGroup.Values[key], configValue, name, org)
+			loadConfigValue(logger, configItems, key, versionsGroup.Values[key], configValue, name, org)
 		}
 	} else {
 		logger.Debugf("loadConfigGroup - %s - no values", name)
@@ -277,7 +286,7 @@ func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, grou
 		versionsGroup.Policies = make(map[string]*common.ConfigPolicy)
 		for key, configPolicy := range policies {
 			versionsGroup.Policies[key] = &common.ConfigPolicy{}
-			loadConfigPolicy(configItems, key, versionsGroup.Policies[key], configPolicy, name, org)
+			loadConfigPolicy(logger, configItems, key, versionsGroup.Policies[key], configPolicy, name, org)
 		}
 	} else {
 		logger.Debugf("loadConfigGroup - %s - no policies", name)
@@ -287,16 +296,16 @@ func loadConfig(configItems *ChannelCfg, versionsGroup *common.ConfigGroup, grou
 	return nil
 }
 
-func loadConfigPolicy(configItems *ChannelCfg, key string, versionsPolicy *common.ConfigPolicy, configPolicy *common.ConfigPolicy, groupName string, org string) error {
+func loadConfigPolicy(logger *logging.Logger, configItems *ChannelCfg, key string, versionsPolicy *common.ConfigPolicy, configPolicy *common.ConfigPolicy, groupName string, org string) error {
 	logger.Debugf("loadConfigPolicy - %s - name: %s", groupName, key)
 	logger.Debugf("loadConfigPolicy - %s - version: %d", groupName, configPolicy.Version)
 	logger.Debugf("loadConfigPolicy - %s - mod_policy: %s", groupName, configPolicy.ModPolicy)
 
 	versionsPolicy.Version = configPolicy.Version
-	return loadPolicy(configItems, versionsPolicy, key, configPolicy.Policy, groupName, org)
+	return loadPolicy(logger, configItems, versionsPolicy, key, configPolicy.Policy, groupName, org)
 }
 
-func loadPolicy(configItems *ChannelCfg, versionsPolicy *common.ConfigPolicy, key string, policy *common.Policy, groupName string, org string) error {
+func loadPolicy(logger *logging.Logger, configItems *ChannelCfg, versionsPolicy *common.ConfigPolicy, key string, policy *common.Policy, groupName string, org string) error {
 
 	policyType := common.Policy_PolicyType(policy.Type)
 
@@ -332,7 +341,7 @@ func loadPolicy(configItems *ChannelCfg, versionsPolicy *common.ConfigPolicy, ke
 	return nil
 }
 
-func loadConfigValue(configItems *ChannelCfg, key string, versionsValue *common.ConfigValue, configValue *common.ConfigValue, groupName string, org string) error {
+func loadConfigValue(logger *logging.Logger, configItems *ChannelCfg, key string, versionsValue *common.ConfigValue, configValue *common.ConfigValue, groupName string, org string) error {
 	logger.Debugf("loadConfigValue - %s - START value name: %s", groupName, key)
 	logger.Debugf("loadConfigValue - %s   - version: %d", groupName, configValue.Version)
 	logger.Debugf("loadConfigValue - %s   - modPolicy: %s", groupName, configValue.ModPolicy)
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 )
 
 const (
@@ -72,6 +73,43 @@ func TestChannelConfigWithOrdererError(t *testing.T) {
 
 }
 
+func TestFromConfigEnvelope(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+		},
+	}
+	block := builder.Build()
+
+	env := &common.Envelope{}
+	payload := &common.Payload{}
+	configEnvelope := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(block.Data.Data[0], env); err != nil {
+		t.Fatal(err)
+	}
+	if err := proto.Unmarshal(env.Payload, payload); err != nil {
+		t.Fatal(err)
+	}
+	if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := FromConfigEnvelope(channelID, configEnvelope)
+	if err != nil {
+		t.Fatalf("Failed to extract channel config: %s", err)
+	}
+	if cfg.Name() != channelID || len(cfg.Orderers()) != 1 || cfg.Orderers()[0] != "localhost:7054" {
+		t.Fatalf("Unexpected channel config: %s, %v", cfg.Name(), cfg.Orderers())
+	}
+
+	if _, err := FromConfigEnvelope(channelID, &common.ConfigEnvelope{}); err == nil {
+		t.Fatal("Expected error for config envelope without config")
+	}
+}
+
 func setupTestChannel(name string) (*channel.Channel, error) {
 	ctx := setupTestContext()
 	return channel.New(ctx, mocks.NewMockChannelCfg(name))
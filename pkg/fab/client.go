@@ -22,10 +22,13 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	metricsApi "github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+	tracingApi "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/fab")
 
 // Client enables access to a Fabric network.
 type Client struct {
@@ -35,6 +38,7 @@ type Client struct {
 	signingIdentity context.IdentityContext
 	config          config.Config
 	signingManager  contextApi.SigningManager
+	loggerProvider  logApi.LoggerProvider
 }
 
 type fabContext struct {
@@ -126,6 +130,32 @@ func (c *Client) SigningManager() contextApi.SigningManager {
 	return c.signingManager
 }
 
+// LoggerProvider returns the logger provider set by SetLoggerProvider, or the
+// process-wide logger provider if none was set.
+func (c *Client) LoggerProvider() logApi.LoggerProvider {
+	if c.loggerProvider == nil {
+		return logging.LoggerProvider()
+	}
+	return c.loggerProvider
+}
+
+// SetLoggerProvider sets the logger provider used by the objects created by this client.
+//
+// Deprecated: see fabsdk package.
+func (c *Client) SetLoggerProvider(loggerProvider logApi.LoggerProvider) {
+	c.loggerProvider = loggerProvider
+}
+
+// MetricsProvider returns nil; the client doesn't record metrics.
+func (c *Client) MetricsProvider() metricsApi.Provider {
+	return nil
+}
+
+// Tracer returns nil; the client doesn't record spans.
+func (c *Client) Tracer() tracingApi.Tracer {
+	return nil
+}
+
 // SetSigningManager is a convenience method to set signing manager
 //
 // Deprecated: see fabsdk package.
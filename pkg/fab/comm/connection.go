@@ -22,7 +22,7 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/comm")
 
 // StreamProvider creates a GRPC stream
 type StreamProvider func(conn *grpc.ClientConn) (grpc.ClientStream, error)
@@ -35,6 +35,7 @@ type GRPCConnection struct {
 	context     fabcontext.Context
 	tlsCertHash []byte
 	done        int32
+	logger      *logging.Logger
 }
 
 // NewConnection creates a new connection
@@ -46,7 +47,8 @@ func NewConnection(ctx fabcontext.Context, channelID string, streamProvider Stre
 	params := defaultParams()
 	options.Apply(params, opts)
 
-	dialOpts, err := newDialOpts(ctx.Config(), url, params)
+	connLogger := logger.ForProvider(ctx.LoggerProvider())
+	dialOpts, err := newDialOpts(connLogger, ctx.Config(), url, params)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +65,7 @@ func NewConnection(ctx fabcontext.Context, channelID string, streamProvider Stre
 	stream, err := streamProvider(grpcconn)
 	if err != nil {
 		if err := grpcconn.Close(); err != nil {
-			logger.Warnf("error closing GRPC connection: %s", err)
+			connLogger.Warnf("error closing GRPC connection: %s", err)
 		}
 		return nil, errors.Wrapf(err, "could not create stream to %s", url)
 	}
@@ -78,6 +80,7 @@ func NewConnection(ctx fabcontext.Context, channelID string, streamProvider Stre
 		stream:      stream,
 		context:     ctx,
 		tlsCertHash: comm.TLSCertHash(ctx.Config()),
+		logger:      connLogger,
 	}, nil
 }
 
@@ -89,18 +92,18 @@ func (c *GRPCConnection) ChannelID() string {
 // Close closes the connection
 func (c *GRPCConnection) Close() {
 	if !c.setClosed() {
-		logger.Debugf("Already closed")
+		c.logger.Debugf("Already closed")
 		return
 	}
 
-	logger.Debugf("Closing stream....")
+	c.logger.Debugf("Closing stream....")
 	if err := c.stream.CloseSend(); err != nil {
-		logger.Warnf("error closing GRPC stream: %s", err)
+		c.logger.Warnf("error closing GRPC stream: %s", err)
 	}
 
-	logger.Debugf("Closing connection....")
+	c.logger.Debugf("Closing connection....")
 	if err := c.conn.Close(); err != nil {
-		logger.Warnf("error closing GRPC connection: %s", err)
+		c.logger.Warnf("error closing GRPC connection: %s", err)
 	}
 }
 
@@ -128,7 +131,7 @@ func (c *GRPCConnection) Context() fabcontext.Context {
 	return c.context
 }
 
-func newDialOpts(config core.Config, url string, params *params) ([]grpc.DialOption, error) {
+func newDialOpts(logger *logging.Logger, config core.Config, url string, params *params) ([]grpc.DialOption, error) {
 	var dialOpts []grpc.DialOption
 
 	if params.keepAliveParams.Time > 0 || params.keepAliveParams.Timeout > 0 {
@@ -0,0 +1,401 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bridge forwards channel events to external sinks, such as webhooks and files,
+// for services that want to consume events without using the SDK.
+package bridge
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/blockdecoder"
+	esdispatcher "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/options"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+var logger = logging.NewLogger("fabric_sdk_go/events/bridge")
+
+// EventType is the type of a forwarded event
+type EventType string
+
+const (
+	// BlockEventType is the type of block events
+	BlockEventType EventType = "block"
+	// TxStatusEventType is the type of transaction status events
+	TxStatusEventType EventType = "txstatus"
+	// ChaincodeEventType is the type of chaincode events
+	ChaincodeEventType EventType = "chaincode"
+)
+
+// Event is an event forwarded to the sinks. For each block, a block event is followed
+// by a transaction status event for each transaction, each followed by the transaction's
+// chaincode events.
+type Event struct {
+	Type        EventType `json:"type"`
+	ChannelID   string    `json:"channelId,omitempty"`
+	BlockNumber uint64    `json:"blockNumber"`
+	// Index is the position of the event among the events of its block
+	Index int `json:"index"`
+	// TxCount is the number of transactions in the block (block events only)
+	TxCount int `json:"txCount,omitempty"`
+	// Block is the marshalled block (block events only, if the bridge receives full blocks)
+	Block            []byte `json:"block,omitempty"`
+	TxID             string `json:"txId,omitempty"`
+	TxValidationCode string `json:"txValidationCode,omitempty"`
+	ChaincodeID      string `json:"chaincodeId,omitempty"`
+	EventName        string `json:"eventName,omitempty"`
+	// Payload is the chaincode event payload (only if the bridge receives full blocks)
+	Payload []byte `json:"payload,omitempty"`
+}
+
+// ID uniquely identifies the event within the network
+func (e *Event) ID() string {
+	return fmt.Sprintf("%s/%d/%d", e.ChannelID, e.BlockNumber, e.Index)
+}
+
+// consumerPolicyService is implemented by event services that support per-registration consumer policies
+type consumerPolicyService interface {
+	WithConsumerPolicy(policy esdispatcher.ConsumerPolicy) fab.EventService
+}
+
+// Bridge forwards the events of an event service to sinks. Events are delivered to each sink
+// in order and at least once: a failed delivery is retried, with backoff, until it succeeds.
+// After an event has been delivered to all sinks, its position is saved as the checkpoint.
+//
+// While a sink is failing, the bridge stops receiving events and they are buffered (see
+// WithBufferSize) so that other consumers of the event service are not held up. If the buffer
+// fills up then the bridge is disconnected from the event service, and it stops once the buffered
+// events have been delivered (see Done). The events that were dropped are after the checkpoint, so
+// the event client may be reconnected from the checkpoint and a new bridge started.
+type Bridge struct {
+	params
+	eventService fab.EventService
+	sinks        []Sink
+	checkpoint   *Checkpoint
+	lock         sync.RWMutex
+	reg          fab.Registration
+	done         chan struct{}
+	stopOnce     sync.Once
+	stopped      chan struct{}
+	logger       *logging.Logger
+}
+
+// New returns a new bridge that forwards the events of the given event service to the given sinks.
+// If a Checkpointer is provided then the last checkpoint is loaded from it.
+// The event service must support consumer policies (e.g. the event client's service) since
+// the bridge has to know when it misses events; otherwise an error is returned.
+func New(eventService fab.EventService, sinks []Sink, opts ...options.Opt) (*Bridge, error) {
+	if len(sinks) == 0 {
+		return nil, errors.New("at least one sink is required")
+	}
+
+	params := defaultParams()
+	options.Apply(params, opts)
+
+	b := &Bridge{
+		params:       *params,
+		eventService: eventService,
+		sinks:        sinks,
+		done:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+		logger:       logger.ForProvider(params.loggerProvider),
+	}
+
+	if b.bufferSize == 0 {
+		return nil, errors.New("buffer size must be greater than 0")
+	}
+
+	if b.checkpointer != nil {
+		checkpoint, err := b.checkpointer.Load()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to load checkpoint")
+		}
+		b.checkpoint = checkpoint
+	}
+
+	policyService, ok := eventService.(consumerPolicyService)
+	if !ok {
+		return nil, errors.Errorf("event service %T does not support consumer policies", eventService)
+	}
+	b.eventService = policyService.WithConsumerPolicy(esdispatcher.ConsumerPolicy{
+		BufferSize: b.bufferSize,
+		Overflow:   esdispatcher.OverflowDisconnect,
+	})
+
+	return b, nil
+}
+
+// Checkpoint returns the position of the last event that was delivered to all sinks, or nil
+// if no event has been delivered. Events at or before the checkpoint are not delivered again,
+// so, after a restart, the event client may be connected from the checkpoint's block
+// (e.g. with deliverclient.WithBlockNum) to resume forwarding where it left off.
+func (b *Bridge) Checkpoint() *Checkpoint {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.checkpoint == nil {
+		return nil
+	}
+	checkpoint := *b.checkpoint
+	return &checkpoint
+}
+
+// Start registers with the event service and starts forwarding events
+func (b *Bridge) Start() error {
+	if b.blockEvents {
+		reg, eventch, err := b.eventService.RegisterBlockEvent()
+		if err != nil {
+			return errors.WithMessage(err, "failed to register for block events")
+		}
+		b.reg = reg
+		go b.forwardBlocks(eventch)
+		return nil
+	}
+
+	reg, eventch, err := b.eventService.RegisterFilteredBlockEvent()
+	if err != nil {
+		return errors.WithMessage(err, "failed to register for filtered block events")
+	}
+	b.reg = reg
+	go b.forwardFilteredBlocks(eventch)
+	return nil
+}
+
+// Stop stops forwarding events and unregisters from the event service.
+// Delivery of an event that is being retried is abandoned, and no later events are delivered.
+func (b *Bridge) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.done)
+		if b.reg != nil {
+			b.eventService.Unregister(b.reg)
+			<-b.stopped
+		}
+	})
+}
+
+// Done returns a channel that is closed when the bridge stops forwarding events, either because
+// Stop was called or because the bridge fell too far behind and was disconnected from the event service.
+func (b *Bridge) Done() <-chan struct{} {
+	return b.stopped
+}
+
+func (b *Bridge) forwardBlocks(eventch <-chan *fab.BlockEvent) {
+	defer close(b.stopped)
+	for {
+		select {
+		case <-b.done:
+			// Keep receiving until the channel is closed so that the event service isn't blocked
+			for range eventch {
+			}
+			return
+		case event, ok := <-eventch:
+			if !ok {
+				b.logger.Errorf("Block event channel closed. Event bridge stopped. Events after the checkpoint were not forwarded.")
+				return
+			}
+			b.forward(b.eventsFromBlock(event.Block))
+		}
+	}
+}
+
+func (b *Bridge) forwardFilteredBlocks(eventch <-chan *fab.FilteredBlockEvent) {
+	defer close(b.stopped)
+	for {
+		select {
+		case <-b.done:
+			// Keep receiving until the channel is closed so that the event service isn't blocked
+			for range eventch {
+			}
+			return
+		case event, ok := <-eventch:
+			if !ok {
+				b.logger.Errorf("Filtered block event channel closed. Event bridge stopped. Events after the checkpoint were not forwarded.")
+				return
+			}
+			b.forward(filteredBlockEvents(event.FilteredBlock))
+		}
+	}
+}
+
+func (b *Bridge) forward(events []*Event) {
+	for _, event := range events {
+		if b.stopping() {
+			// An event that wasn't delivered may have been abandoned, so the
+			// following events mustn't be delivered past it
+			return
+		}
+		if !b.eventTypes[event.Type] {
+			continue
+		}
+		if checkpoint := b.Checkpoint(); checkpoint != nil && checkpoint.covers(event) {
+			b.logger.Debugf("Event %s was already delivered", event.ID())
+			continue
+		}
+
+		for _, sink := range b.sinks {
+			if !b.send(sink, event) {
+				return
+			}
+		}
+
+		b.setCheckpoint(Checkpoint{BlockNumber: event.BlockNumber, Index: event.Index})
+	}
+}
+
+// send sends the event to the sink, retrying until it succeeds or the bridge is stopped
+func (b *Bridge) send(sink Sink, event *Event) bool {
+	backoff := b.initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := sink.Send(event)
+		if err == nil {
+			return true
+		}
+
+		b.logger.Warnf("Attempt #%d to deliver event %s failed: %s. Retrying in %s.", attempt, event.ID(), err, backoff)
+		select {
+		case <-b.done:
+			return false
+		case <-time.After(backoff):
+		}
+
+		backoff = time.Duration(float64(backoff) * b.backoffFactor)
+		if backoff > b.maxBackoff {
+			backoff = b.maxBackoff
+		}
+	}
+}
+
+// stopping returns true once Stop has been called
+func (b *Bridge) stopping() bool {
+	select {
+	case <-b.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *Bridge) setCheckpoint(checkpoint Checkpoint) {
+	b.lock.Lock()
+	b.checkpoint = &checkpoint
+	b.lock.Unlock()
+
+	if b.checkpointer != nil {
+		if err := b.checkpointer.Save(checkpoint); err != nil {
+			// The event may be delivered again after a restart
+			b.logger.Warnf("Failed to save checkpoint: %s", err)
+		}
+	}
+}
+
+func filteredBlockEvents(fblock *pb.FilteredBlock) []*Event {
+	events := []*Event{{
+		Type:        BlockEventType,
+		ChannelID:   fblock.ChannelId,
+		BlockNumber: fblock.Number,
+		TxCount:     len(fblock.FilteredTx),
+	}}
+
+	for _, tx := range fblock.FilteredTx {
+		events = append(events, &Event{
+			Type:             TxStatusEventType,
+			ChannelID:        fblock.ChannelId,
+			BlockNumber:      fblock.Number,
+			TxID:             tx.Txid,
+			TxValidationCode: tx.TxValidationCode.String(),
+		})
+
+		txActions := tx.GetTransactionActions()
+		if txActions == nil {
+			continue
+		}
+		for _, action := range txActions.ChaincodeActions {
+			if action.CcEvent == nil {
+				continue
+			}
+			events = append(events, &Event{
+				Type:             ChaincodeEventType,
+				ChannelID:        fblock.ChannelId,
+				BlockNumber:      fblock.Number,
+				TxID:             tx.Txid,
+				TxValidationCode: tx.TxValidationCode.String(),
+				ChaincodeID:      action.CcEvent.ChaincodeId,
+				EventName:        action.CcEvent.EventName,
+				Payload:          action.CcEvent.Payload,
+			})
+		}
+	}
+
+	return index(events)
+}
+
+func (b *Bridge) eventsFromBlock(block *cb.Block) []*Event {
+	blockEvent := &Event{
+		Type:        BlockEventType,
+		BlockNumber: block.Header.Number,
+	}
+	raw, err := proto.Marshal(block)
+	if err != nil {
+		b.logger.Warnf("Failed to marshal block #%d: %s", block.Header.Number, err)
+	}
+	blockEvent.Block = raw
+
+	decoded, err := blockdecoder.DecodeBlock(block)
+	if err != nil {
+		b.logger.Warnf("Failed to decode block #%d. Only the block event is forwarded: %s", block.Header.Number, err)
+		return index([]*Event{blockEvent})
+	}
+
+	blockEvent.TxCount = len(decoded.Transactions)
+	if len(decoded.Transactions) > 0 {
+		blockEvent.ChannelID = decoded.Transactions[0].ChannelID
+	}
+
+	events := []*Event{blockEvent}
+	for _, tx := range decoded.Transactions {
+		events = append(events, &Event{
+			Type:             TxStatusEventType,
+			ChannelID:        tx.ChannelID,
+			BlockNumber:      decoded.Number,
+			TxID:             tx.TxID,
+			TxValidationCode: tx.ValidationCode.String(),
+		})
+
+		for _, action := range tx.Actions {
+			if action.Event == nil {
+				continue
+			}
+			events = append(events, &Event{
+				Type:             ChaincodeEventType,
+				ChannelID:        tx.ChannelID,
+				BlockNumber:      decoded.Number,
+				TxID:             tx.TxID,
+				TxValidationCode: tx.ValidationCode.String(),
+				ChaincodeID:      action.Event.ChaincodeId,
+				EventName:        action.Event.EventName,
+				Payload:          action.Event.Payload,
+			})
+		}
+	}
+
+	return index(events)
+}
+
+func index(events []*Event) []*Event {
+	for i, event := range events {
+		event.Index = i
+	}
+	return events
+}
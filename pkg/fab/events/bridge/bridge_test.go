@@ -0,0 +1,456 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bridge
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
+	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+const channelID = "mychannel"
+
+var testBackoff = WithBackoff(10*time.Millisecond, 50*time.Millisecond, 2)
+
+func TestWebhook(t *testing.T) {
+	secret := []byte("secret")
+
+	var mutex sync.Mutex
+	var requests int
+	var received []*Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		requests++
+		if requests <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("error reading request body: %s", err)
+			return
+		}
+		if r.Header.Get(SignatureHeader) != "sha256="+Sign(secret, body) {
+			t.Errorf("invalid signature: %s", r.Header.Get(SignatureHeader))
+		}
+
+		event := &Event{}
+		if err := json.Unmarshal(body, event); err != nil {
+			t.Errorf("error unmarshalling event: %s", err)
+			return
+		}
+		if r.Header.Get(EventIDHeader) != event.ID() {
+			t.Errorf("expecting event ID %s but got %s", event.ID(), r.Header.Get(EventIDHeader))
+		}
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "bridge")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	checkpointer := NewFileCheckpointer(filepath.Join(dir, "checkpoint"))
+
+	eventService := newEventService(t)
+	defer eventService.Stop()
+
+	bridge, err := New(eventService, []Sink{NewWebhookSink(server.URL, secret)}, WithCheckpointer(checkpointer), testBackoff)
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err)
+	}
+	if bridge.Checkpoint() != nil {
+		t.Fatalf("expecting no checkpoint")
+	}
+	if err := bridge.Start(); err != nil {
+		t.Fatalf("error starting bridge: %s", err)
+	}
+	defer bridge.Stop()
+
+	producer := servicemocks.NewBlockProducer()
+	eventService.Submit(producer.NewFilteredBlock(channelID,
+		servicemocks.NewFilteredTxWithCCEvent("txid1", "mycc", "event1"),
+		servicemocks.NewFilteredTx("txid2", pb.TxValidationCode_MVCC_READ_CONFLICT),
+	))
+
+	waitFor(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(received) == 4
+	})
+
+	expected := []Event{
+		{Type: BlockEventType, ChannelID: channelID, Index: 0, TxCount: 2},
+		{Type: TxStatusEventType, ChannelID: channelID, Index: 1, TxID: "txid1", TxValidationCode: "VALID"},
+		{Type: ChaincodeEventType, ChannelID: channelID, Index: 2, TxID: "txid1", TxValidationCode: "VALID", ChaincodeID: "mycc", EventName: "event1"},
+		{Type: TxStatusEventType, ChannelID: channelID, Index: 3, TxID: "txid2", TxValidationCode: "MVCC_READ_CONFLICT"},
+	}
+	mutex.Lock()
+	for i, event := range received {
+		if !reflect.DeepEqual(*event, expected[i]) {
+			t.Fatalf("expecting event %+v but got %+v", expected[i], *event)
+		}
+	}
+	mutex.Unlock()
+
+	checkpoint, err := checkpointer.Load()
+	if err != nil {
+		t.Fatalf("error loading checkpoint: %s", err)
+	}
+	if checkpoint == nil || *checkpoint != (Checkpoint{BlockNumber: 0, Index: 3}) {
+		t.Fatalf("expecting checkpoint 0/3 but got %+v", checkpoint)
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	checkpointer := &memCheckpointer{checkpoint: &Checkpoint{BlockNumber: 0, Index: 1}}
+
+	eventService := newEventService(t)
+	defer eventService.Stop()
+
+	eventch := make(chan *Event, 10)
+	sink := SinkFunc(func(event *Event) error {
+		eventch <- event
+		return nil
+	})
+
+	bridge, err := New(eventService, []Sink{sink}, WithCheckpointer(checkpointer), WithEventTypes(TxStatusEventType))
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err)
+	}
+	if err := bridge.Start(); err != nil {
+		t.Fatalf("error starting bridge: %s", err)
+	}
+	defer bridge.Stop()
+
+	producer := servicemocks.NewBlockProducer()
+	eventService.Submit(producer.NewFilteredBlock(channelID,
+		servicemocks.NewFilteredTxWithCCEvent("txid1", "mycc", "event1"),
+		servicemocks.NewFilteredTx("txid2", pb.TxValidationCode_VALID),
+	))
+	eventService.Submit(producer.NewFilteredBlock(channelID,
+		servicemocks.NewFilteredTx("txid3", pb.TxValidationCode_VALID),
+	))
+
+	// txid1 was delivered before the checkpoint was saved
+	checkEvent(t, eventch, "mychannel/0/3", "txid2")
+	checkEvent(t, eventch, "mychannel/1/1", "txid3")
+
+	waitFor(t, func() bool {
+		checkpoint := bridge.Checkpoint()
+		return checkpoint != nil && *checkpoint == Checkpoint{BlockNumber: 1, Index: 1}
+	})
+}
+
+func TestBlockEvents(t *testing.T) {
+	eventService := newEventService(t)
+	defer eventService.Stop()
+
+	eventch := make(chan *Event, 10)
+	sink := SinkFunc(func(event *Event) error {
+		eventch <- event
+		return nil
+	})
+
+	bridge, err := New(eventService, []Sink{sink}, WithBlockEvents(), WithEventTypes(BlockEventType, ChaincodeEventType))
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err)
+	}
+	if err := bridge.Start(); err != nil {
+		t.Fatalf("error starting bridge: %s", err)
+	}
+	defer bridge.Stop()
+
+	producer := servicemocks.NewBlockProducer()
+	eventService.Submit(producer.NewBlock(channelID,
+		servicemocks.NewTransactionWithCCEvent("txid1", pb.TxValidationCode_VALID, "mycc", "event1"),
+	))
+
+	blockEvent := checkEvent(t, eventch, "mychannel/0/0", "")
+	if blockEvent.Type != BlockEventType || len(blockEvent.Block) == 0 || blockEvent.TxCount != 1 {
+		t.Fatalf("expecting block event with the raw block but got %+v", blockEvent)
+	}
+	ccEvent := checkEvent(t, eventch, "mychannel/0/2", "txid1")
+	if ccEvent.Type != ChaincodeEventType || ccEvent.ChaincodeID != "mycc" || ccEvent.EventName != "event1" {
+		t.Fatalf("expecting chaincode event but got %+v", ccEvent)
+	}
+}
+
+func TestStopWhileRetrying(t *testing.T) {
+	eventService := newEventService(t)
+	defer eventService.Stop()
+
+	attempts := make(chan struct{}, 100)
+	sink := SinkFunc(func(event *Event) error {
+		attempts <- struct{}{}
+		return errors.New("unavailable")
+	})
+
+	bridge, err := New(eventService, []Sink{sink}, testBackoff)
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err)
+	}
+	if err := bridge.Start(); err != nil {
+		t.Fatalf("error starting bridge: %s", err)
+	}
+
+	producer := servicemocks.NewBlockProducer()
+	for i := 0; i < 3; i++ {
+		eventService.Submit(producer.NewFilteredBlock(channelID))
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-attempts:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for delivery attempt")
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		bridge.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out stopping bridge")
+	}
+
+	if bridge.Checkpoint() != nil {
+		t.Fatalf("expecting no checkpoint since no event was delivered")
+	}
+}
+
+func TestStopWithBufferedBlocks(t *testing.T) {
+	eventService := newEventService(t)
+	defer eventService.Stop()
+
+	var mutex sync.Mutex
+	var delivered []string
+	attempts := make(chan struct{}, 100)
+	sink := SinkFunc(func(event *Event) error {
+		if event.ID() == channelID+"/1/1" {
+			attempts <- struct{}{}
+			return errors.New("unavailable")
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		delivered = append(delivered, event.ID())
+		return nil
+	})
+
+	bridge, err := New(eventService, []Sink{sink}, testBackoff)
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err)
+	}
+	if err := bridge.Start(); err != nil {
+		t.Fatalf("error starting bridge: %s", err)
+	}
+
+	// The bridge is retrying the first transaction of block #1 while blocks #2 and #3 are buffered
+	producer := servicemocks.NewBlockProducer()
+	for i := 0; i < 4; i++ {
+		eventService.Submit(producer.NewFilteredBlock(channelID,
+			servicemocks.NewFilteredTx("txid1", pb.TxValidationCode_VALID),
+			servicemocks.NewFilteredTx("txid2", pb.TxValidationCode_VALID),
+		))
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-attempts:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for delivery attempt")
+		}
+	}
+
+	bridge.Stop()
+
+	if checkpoint := bridge.Checkpoint(); checkpoint == nil || checkpoint.BlockNumber != 1 || checkpoint.Index != 0 {
+		t.Fatalf("expecting checkpoint at block #1, index 0 but got %+v", checkpoint)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	expected := []string{channelID + "/0/0", channelID + "/0/1", channelID + "/0/2", channelID + "/1/0"}
+	if !reflect.DeepEqual(delivered, expected) {
+		t.Fatalf("expecting events %v to be delivered but got %v", expected, delivered)
+	}
+}
+
+func TestDisconnectWhenBufferFull(t *testing.T) {
+	eventService := newEventService(t)
+	defer eventService.Stop()
+
+	var mutex sync.Mutex
+	available := false
+	attempts := make(chan struct{}, 100)
+	sink := SinkFunc(func(event *Event) error {
+		attempts <- struct{}{}
+		mutex.Lock()
+		defer mutex.Unlock()
+		if !available {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+
+	bridge, err := New(eventService, []Sink{sink}, testBackoff, WithBufferSize(1))
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err)
+	}
+	if err := bridge.Start(); err != nil {
+		t.Fatalf("error starting bridge: %s", err)
+	}
+	defer bridge.Stop()
+
+	// Other consumers aren't held up by the failing sink
+	reg, eventch, err := eventService.RegisterFilteredBlockEvent()
+	if err != nil {
+		t.Fatalf("error registering for filtered block events: %s", err)
+	}
+	defer eventService.Unregister(reg)
+
+	// The bridge is retrying block #0 while block #1 is buffered and the bridge is disconnected at block #2
+	producer := servicemocks.NewBlockProducer()
+	eventService.Submit(producer.NewFilteredBlock(channelID))
+	select {
+	case <-attempts:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for delivery attempt")
+	}
+	for i := 1; i < 4; i++ {
+		eventService.Submit(producer.NewFilteredBlock(channelID))
+	}
+	for i := 0; i < 4; i++ {
+		select {
+		case <-eventch:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for filtered block event #%d", i)
+		}
+	}
+
+	mutex.Lock()
+	available = true
+	mutex.Unlock()
+
+	select {
+	case <-bridge.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for bridge to stop after it was disconnected")
+	}
+
+	if checkpoint := bridge.Checkpoint(); checkpoint == nil || checkpoint.BlockNumber != 1 {
+		t.Fatalf("expecting checkpoint at block #1 but got %+v", checkpoint)
+	}
+}
+
+func TestStopTwice(t *testing.T) {
+	eventService := newEventService(t)
+	defer eventService.Stop()
+
+	bridge, err := New(eventService, []Sink{SinkFunc(func(event *Event) error { return nil })})
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err)
+	}
+	if err := bridge.Start(); err != nil {
+		t.Fatalf("error starting bridge: %s", err)
+	}
+
+	bridge.Stop()
+	bridge.Stop()
+
+	select {
+	case <-bridge.Done():
+	default:
+		t.Fatalf("expecting bridge to be done after it was stopped")
+	}
+}
+
+func TestEventServiceWithoutConsumerPolicies(t *testing.T) {
+	eventService := newEventService(t)
+	defer eventService.Stop()
+
+	if _, err := New(struct{ fab.EventService }{eventService}, []Sink{SinkFunc(func(event *Event) error { return nil })}); err == nil {
+		t.Fatalf("expecting error creating bridge for event service without consumer policies")
+	}
+}
+
+func TestNoSinks(t *testing.T) {
+	if _, err := New(nil, nil); err == nil {
+		t.Fatalf("expecting error creating bridge without sinks")
+	}
+}
+
+type memCheckpointer struct {
+	mutex      sync.Mutex
+	checkpoint *Checkpoint
+}
+
+func (c *memCheckpointer) Load() (*Checkpoint, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.checkpoint, nil
+}
+
+func (c *memCheckpointer) Save(checkpoint Checkpoint) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.checkpoint = &checkpoint
+	return nil
+}
+
+func newEventService(t *testing.T) *service.Service {
+	eventService := service.New(dispatcher.New())
+	if err := eventService.Start(); err != nil {
+		t.Fatalf("error starting event service: %s", err)
+	}
+	return eventService
+}
+
+func checkEvent(t *testing.T, eventch <-chan *Event, expectedID, expectedTxID string) *Event {
+	select {
+	case event := <-eventch:
+		if event.ID() != expectedID || event.TxID != expectedTxID {
+			t.Fatalf("expecting event %s for TxID [%s] but got event %s for TxID [%s]", expectedID, expectedTxID, event.ID(), event.TxID)
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for event %s", expectedID)
+	}
+	return nil
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
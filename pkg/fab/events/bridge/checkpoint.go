@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bridge
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Checkpoint is the position of the last event that was delivered to all sinks
+type Checkpoint struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	Index       int    `json:"index"`
+}
+
+// covers returns true if the event is at or before the checkpoint
+func (cp *Checkpoint) covers(event *Event) bool {
+	return event.BlockNumber < cp.BlockNumber || (event.BlockNumber == cp.BlockNumber && event.Index <= cp.Index)
+}
+
+// Checkpointer stores the bridge's checkpoint
+type Checkpointer interface {
+	// Load returns the stored checkpoint or nil if there is none
+	Load() (*Checkpoint, error)
+	// Save stores the checkpoint
+	Save(checkpoint Checkpoint) error
+}
+
+// FileCheckpointer stores the checkpoint as JSON in a file
+type FileCheckpointer struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileCheckpointer returns a Checkpointer that stores the checkpoint in the given file
+func NewFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{path: path}
+}
+
+// Load reads the checkpoint from the file. Nil is returned if the file does not exist.
+func (c *FileCheckpointer) Load() (*Checkpoint, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read checkpoint file %s", c.path)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, errors.Wrapf(err, "invalid checkpoint file %s", c.path)
+	}
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint to a temporary file and renames it over the
+// checkpoint file, so that the file always holds a complete checkpoint
+func (c *FileCheckpointer) Save(checkpoint Checkpoint) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal checkpoint")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path))
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary checkpoint file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write checkpoint")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to sync checkpoint")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to close checkpoint file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), c.path), "failed to replace checkpoint file")
+}
@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bridge
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/retry"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/options"
+)
+
+const defaultBufferSize = 100
+
+type params struct {
+	blockEvents    bool
+	eventTypes     map[EventType]bool
+	checkpointer   Checkpointer
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	backoffFactor  float64
+	bufferSize     uint
+	loggerProvider logApi.LoggerProvider
+}
+
+func defaultParams() *params {
+	return &params{
+		eventTypes: map[EventType]bool{
+			BlockEventType:     true,
+			TxStatusEventType:  true,
+			ChaincodeEventType: true,
+		},
+		initialBackoff: retry.DefaultInitialBackoff,
+		maxBackoff:     retry.DefaultMaxBackoff,
+		backoffFactor:  retry.DefaultBackoffFactor,
+		bufferSize:     defaultBufferSize,
+	}
+}
+
+// WithBlockEvents indicates that the bridge registers for full blocks rather than filtered blocks.
+// Full blocks provide chaincode event payloads and the raw block, but the event service
+// must permit block events.
+func WithBlockEvents() options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(blockEventsSetter); ok {
+			setter.SetBlockEvents(true)
+		}
+	}
+}
+
+// WithEventTypes sets the types of events that are forwarded. By default all types are forwarded.
+func WithEventTypes(eventTypes ...EventType) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(eventTypesSetter); ok {
+			setter.SetEventTypes(eventTypes)
+		}
+	}
+}
+
+// WithCheckpointer sets the Checkpointer that records the last event delivered to all sinks.
+// Without a Checkpointer, the checkpoint is only held in memory.
+func WithCheckpointer(value Checkpointer) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(checkpointerSetter); ok {
+			setter.SetCheckpointer(value)
+		}
+	}
+}
+
+// WithBackoff sets the backoff between attempts to deliver an event to a sink. The backoff
+// starts at initial and is multiplied by factor after each failed attempt, up to max.
+func WithBackoff(initial, max time.Duration, factor float64) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(backoffSetter); ok {
+			setter.SetBackoff(initial, max, factor)
+		}
+	}
+}
+
+// WithBufferSize sets the number of blocks that are buffered while a sink is failing. If the
+// buffer fills up then the bridge is disconnected from the event service. The default is 100.
+func WithBufferSize(value uint) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(bufferSizeSetter); ok {
+			setter.SetBufferSize(value)
+		}
+	}
+}
+
+// WithLoggerProvider sets the logger provider of the bridge (e.g. the logger provider of
+// an SDK instance). If not set then the process-wide logger provider is used.
+func WithLoggerProvider(value logApi.LoggerProvider) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(loggerProviderSetter); ok {
+			setter.SetLoggerProvider(value)
+		}
+	}
+}
+
+type blockEventsSetter interface {
+	SetBlockEvents(value bool)
+}
+
+type eventTypesSetter interface {
+	SetEventTypes(value []EventType)
+}
+
+type checkpointerSetter interface {
+	SetCheckpointer(value Checkpointer)
+}
+
+type backoffSetter interface {
+	SetBackoff(initial, max time.Duration, factor float64)
+}
+
+type bufferSizeSetter interface {
+	SetBufferSize(value uint)
+}
+
+type loggerProviderSetter interface {
+	SetLoggerProvider(value logApi.LoggerProvider)
+}
+
+func (p *params) SetBlockEvents(value bool) {
+	logger.Debugf("BlockEvents: %t", value)
+	p.blockEvents = value
+}
+
+func (p *params) SetEventTypes(value []EventType) {
+	logger.Debugf("EventTypes: %v", value)
+	p.eventTypes = make(map[EventType]bool)
+	for _, eventType := range value {
+		p.eventTypes[eventType] = true
+	}
+}
+
+func (p *params) SetCheckpointer(value Checkpointer) {
+	logger.Debugf("Checkpointer: %T", value)
+	p.checkpointer = value
+}
+
+func (p *params) SetBackoff(initial, max time.Duration, factor float64) {
+	logger.Debugf("Backoff: initial %s, max %s, factor %f", initial, max, factor)
+	p.initialBackoff = initial
+	p.maxBackoff = max
+	p.backoffFactor = factor
+}
+
+func (p *params) SetBufferSize(value uint) {
+	logger.Debugf("BufferSize: %d", value)
+	p.bufferSize = value
+}
+
+func (p *params) SetLoggerProvider(value logApi.LoggerProvider) {
+	p.loggerProvider = value
+}
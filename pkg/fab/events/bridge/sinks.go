@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bridge
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader is the webhook request header that holds the HMAC-SHA256 signature
+	// of the request body, in the form "sha256=<hex>"
+	SignatureHeader = "X-Fabric-Event-Signature"
+	// EventIDHeader is the webhook request header that holds the event ID.
+	// Since events may be delivered more than once, receivers may use it to detect duplicates.
+	EventIDHeader = "X-Fabric-Event-ID"
+
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// Sink receives the events forwarded by the bridge. Send is called for one event
+// at a time, in order. If Send returns an error then the same event is sent again.
+type Sink interface {
+	Send(event *Event) error
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(event *Event) error
+
+// Send calls f(event)
+func (f SinkFunc) Send(event *Event) error {
+	return f(event)
+}
+
+// WebhookSink posts each event as JSON to an HTTP endpoint
+type WebhookSink struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink that posts events to the given URL. If secret is not empty,
+// requests are signed with HMAC-SHA256 of the body in the SignatureHeader header.
+// Any response other than 2xx is an error.
+func NewWebhookSink(url string, secret []byte) *WebhookSink {
+	return NewWebhookSinkWithClient(url, secret, &http.Client{Timeout: defaultWebhookTimeout})
+}
+
+// NewWebhookSinkWithClient returns a webhook sink that uses the given HTTP client
+func NewWebhookSinkWithClient(url string, secret []byte, client *http.Client) *WebhookSink {
+	return &WebhookSink{url: url, secret: secret, client: client}
+}
+
+// Send posts the event to the webhook
+func (s *WebhookSink) Send(event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, event.ID())
+	if len(s.secret) > 0 {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post event to %s", s.url)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body) // nolint: errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("webhook %s returned status %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of body, as sent by WebhookSink
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body) // nolint: errcheck
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// FileSink appends each event as a line of JSON to a file
+type FileSink struct {
+	file  *os.File
+	mutex sync.Mutex
+}
+
+// NewFileSink opens (or creates) the given file for appending events
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open event file %s", path)
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Send appends the event to the file and syncs it to disk
+func (s *FileSink) Send(event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := fmt.Fprintf(s.file, "%s\n", line); err != nil {
+		return errors.Wrap(err, "failed to write event")
+	}
+	return errors.Wrap(s.file.Sync(), "failed to sync event file")
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bridge")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.json")
+
+	events := []*Event{
+		{Type: BlockEventType, ChannelID: channelID, BlockNumber: 5, Index: 0, TxCount: 1},
+		{Type: TxStatusEventType, ChannelID: channelID, BlockNumber: 5, Index: 1, TxID: "txid1", TxValidationCode: "VALID"},
+	}
+
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("error creating file sink: %s", err)
+	}
+	for _, event := range events {
+		if err := sink.Send(event); err != nil {
+			t.Fatalf("error sending event: %s", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("error closing file sink: %s", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("error opening event file: %s", err)
+	}
+	defer file.Close()
+
+	var i int
+	scanner := bufio.NewScanner(file)
+	for ; scanner.Scan(); i++ {
+		event := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			t.Fatalf("error unmarshalling line %d: %s", i, err)
+		}
+		if i >= len(events) || !reflect.DeepEqual(event, events[i]) {
+			t.Fatalf("unexpected event on line %d: %+v", i, event)
+		}
+	}
+	if i != len(events) {
+		t.Fatalf("expecting %d events but got %d", len(events), i)
+	}
+}
+
+func TestWebhookStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(SignatureHeader) != "" {
+			t.Errorf("expecting no signature without a secret")
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookSink(server.URL, nil).Send(&Event{Type: BlockEventType}); err == nil {
+		t.Fatalf("expecting error for status %d", http.StatusBadRequest)
+	}
+}
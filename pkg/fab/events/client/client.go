@@ -12,14 +12,16 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/dispatcher"
 	eventservice "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service"
+	esdispatcher "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/client")
 
 // ConnectionState is the state of the client connection
 type ConnectionState int32
@@ -47,6 +49,7 @@ type Client struct {
 	permitBlockEvents bool
 	afterConnect      handler
 	beforeReconnect   handler
+	logger            *logging.Logger
 }
 
 type handler func() error
@@ -62,6 +65,7 @@ func New(permitBlockEvents bool, dispatcher eventservice.Dispatcher, opts ...opt
 		connEvent:         make(chan *fab.ConnectionEvent),
 		connectionState:   int32(Disconnected),
 		permitBlockEvents: permitBlockEvents,
+		logger:            logger.ForProvider(params.loggerProvider),
 	}
 }
 
@@ -107,39 +111,39 @@ func (c *Client) Connect() error {
 // Close closes the connection to the event server and deallocates all resources.
 // Once this function is invoked the client may no longer be used.
 func (c *Client) Close() {
-	logger.Debugf("Attempting to close event client...")
+	c.logger.Debugf("Attempting to close event client...")
 
 	if !c.setStoppped() {
 		// Already stopped
-		logger.Debugf("Client already stopped")
+		c.logger.Debugf("Client already stopped")
 		return
 	}
 
-	logger.Debugf("Stopping client...")
+	c.logger.Debugf("Stopping client...")
 
 	if c.connEventCh != nil {
 		close(c.connEventCh)
 	}
 
-	logger.Debugf("Sending disconnect request...")
+	c.logger.Debugf("Sending disconnect request...")
 
 	errch := make(chan error)
 	c.Submit(dispatcher.NewDisconnectEvent(errch))
 	err := <-errch
 
 	if err != nil {
-		logger.Warnf("Received error from disconnect request: %s", err)
+		c.logger.Warnf("Received error from disconnect request: %s", err)
 	} else {
-		logger.Debugf("Received success from disconnect request")
+		c.logger.Debugf("Received success from disconnect request")
 	}
 
-	logger.Debugf("Stopping dispatcher...")
+	c.logger.Debugf("Stopping dispatcher...")
 
 	c.Stop()
 
 	c.mustSetConnectionState(Disconnected)
 
-	logger.Debugf("... event client is stopped")
+	c.logger.Debugf("... event client is stopped")
 }
 
 func (c *Client) connect() error {
@@ -151,7 +155,7 @@ func (c *Client) connect() error {
 		return errors.Errorf("unable to connect event client since client is [%s]. Expecting client to be in state [%s]", c.ConnectionState(), Disconnected)
 	}
 
-	logger.Debugf("Submitting connection request...")
+	c.logger.Debugf("Submitting connection request...")
 
 	errch := make(chan error)
 	c.Submit(dispatcher.NewConnectEvent(errch))
@@ -160,15 +164,15 @@ func (c *Client) connect() error {
 
 	if err != nil {
 		c.mustSetConnectionState(Disconnected)
-		logger.Debugf("... got error in connection response: %s", err)
+		c.logger.Debugf("... got error in connection response: %s", err)
 		return err
 	}
 
 	c.registerOnce.Do(func() {
-		logger.Debugf("Submitting connection event registration...")
+		c.logger.Debugf("Submitting connection event registration...")
 		_, eventch, err := c.RegisterConnectionEvent()
 		if err != nil {
-			logger.Errorf("Error registering for connection events: %s", err)
+			c.logger.Errorf("Error registering for connection events: %s", err)
 			c.Close()
 		}
 		c.connEvent = eventch
@@ -178,30 +182,30 @@ func (c *Client) connect() error {
 	handler := c.afterConnectHandler()
 	if handler != nil {
 		if err := handler(); err != nil {
-			logger.Warnf("Error invoking afterConnect handler: %s. Disconnecting...", err)
+			c.logger.Warnf("Error invoking afterConnect handler: %s. Disconnecting...", err)
 
 			c.Submit(dispatcher.NewDisconnectEvent(errch))
 
 			select {
 			case disconnErr := <-errch:
 				if disconnErr != nil {
-					logger.Warnf("Received error from disconnect request: %s", disconnErr)
+					c.logger.Warnf("Received error from disconnect request: %s", disconnErr)
 				} else {
-					logger.Debugf("Received success from disconnect request")
+					c.logger.Debugf("Received success from disconnect request")
 				}
 			case <-time.After(c.respTimeout):
-				logger.Warnf("Timed out waiting for disconnect response")
+				c.logger.Warnf("Timed out waiting for disconnect response")
 			}
 
 			c.setConnectionState(Connecting, Disconnected)
 
-			return errors.Errorf("unable to register for events: %s", err)
+			return errors.WithMessage(err, "unable to register for events")
 		}
 	}
 
 	c.setConnectionState(Connecting, Connected)
 
-	logger.Debugf("Submitting connected event")
+	c.logger.Debugf("Submitting connected event")
 	c.Submit(dispatcher.NewConnectedEvent())
 
 	return err
@@ -218,30 +222,71 @@ func (c *Client) connectWithRetry(maxAttempts uint, timeBetweenAttempts time.Dur
 	var attempts uint
 	for {
 		attempts++
-		logger.Debugf("Attempt #%d to connect...", attempts)
+		c.logger.Debugf("Attempt #%d to connect...", attempts)
 		if err := c.connect(); err != nil {
-			logger.Warnf("... connection attempt failed: %s", err)
+			c.logger.Warnf("... connection attempt failed: %s", err)
 			if maxAttempts > 0 && attempts >= maxAttempts {
-				logger.Warnf("maximum connect attempts exceeded")
+				c.logger.Warnf("maximum connect attempts exceeded")
 				return errors.New("maximum connect attempts exceeded")
 			}
 			time.Sleep(timeBetweenAttempts)
 		} else {
-			logger.Debugf("... connect succeeded.")
+			c.logger.Debugf("... connect succeeded.")
 			return nil
 		}
 	}
 }
 
+// BlockEventsPermitted returns true if the client may register for block events.
+// If false then only filtered block, chaincode and transaction status events are available.
+func (c *Client) BlockEventsPermitted() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.permitBlockEvents
+}
+
+// SetBlockEventsPermitted sets whether or not the client may register for block events.
+// This allows an event client implementation to revoke block events if it discovers
+// that the event server won't deliver them.
+func (c *Client) SetBlockEventsPermitted(permit bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.permitBlockEvents = permit
+}
+
 // RegisterBlockEvent registers for block events. If the client is not authorized to receive
-// block events then an error is returned.
+// block events then a status error with code BlockEventsNotPermitted is returned.
 func (c *Client) RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registration, <-chan *fab.BlockEvent, error) {
-	if !c.permitBlockEvents {
-		return nil, nil, errors.New("block events are not permitted")
+	if !c.BlockEventsPermitted() {
+		return nil, nil, errBlockEventsNotPermitted()
 	}
 	return c.Service.RegisterBlockEvent(filter...)
 }
 
+func errBlockEventsNotPermitted() error {
+	return status.New(status.ClientStatus, status.BlockEventsNotPermitted.ToInt32(),
+		"block events are not permitted; only filtered block events are available", nil)
+}
+
+// WithConsumerPolicy returns an event service whose registrations buffer events according to the
+// given policy instead of the client defaults
+func (c *Client) WithConsumerPolicy(policy esdispatcher.ConsumerPolicy) fab.EventService {
+	return &policyClient{EventService: c.Service.WithConsumerPolicy(policy), client: c}
+}
+
+// policyClient applies the client's block event permission to registrations made with a consumer policy
+type policyClient struct {
+	fab.EventService
+	client *Client
+}
+
+func (pc *policyClient) RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registration, <-chan *fab.BlockEvent, error) {
+	if !pc.client.BlockEventsPermitted() {
+		return nil, nil, errBlockEventsNotPermitted()
+	}
+	return pc.EventService.RegisterBlockEvent(filter...)
+}
+
 // RegisterConnectionEvent registers a connection event. The returned
 // ConnectionEvent channel will be called whenever the client clients or disconnects
 // from the event server
@@ -289,59 +334,59 @@ func (c *Client) mustSetConnectionState(newState ConnectionState) {
 }
 
 func (c *Client) monitorConnection() {
-	logger.Debugf("Monitoring connection")
+	c.logger.Debugf("Monitoring connection")
 	for {
 		event, ok := <-c.connEvent
 		if !ok {
-			logger.Debugln("Connection has closed.")
+			c.logger.Debugln("Connection has closed.")
 			break
 		}
 
 		if c.Stopped() {
-			logger.Debugln("Event client has been stopped.")
+			c.logger.Debugln("Event client has been stopped.")
 			break
 		}
 
 		if c.connEventCh != nil {
-			logger.Debugln("Sending connection event to subscriber.")
+			c.logger.Debugln("Sending connection event to subscriber.")
 			c.connEventCh <- event
 		}
 
 		if event.Connected {
-			logger.Debugf("Event client has connected")
+			c.logger.Debugf("Event client has connected")
 		} else if c.reconn {
-			logger.Warnf("Event client has disconnected. Details: %s", event.Err)
+			c.logger.Warnf("Event client has disconnected. Details: %s", event.Err)
 			if c.setConnectionState(Connected, Disconnected) {
-				logger.Warnf("Attempting to reconnect...")
+				c.logger.Warnf("Attempting to reconnect...")
 				go c.reconnect()
 			} else if c.setConnectionState(Connecting, Disconnected) {
-				logger.Warnf("Reconnect already in progress. Setting state to disconnected")
+				c.logger.Warnf("Reconnect already in progress. Setting state to disconnected")
 			}
 		} else {
-			logger.Debugf("Event client has disconnected. Terminating: %s", event.Err)
+			c.logger.Debugf("Event client has disconnected. Terminating: %s", event.Err)
 			go c.Close()
 			break
 		}
 	}
-	logger.Debugf("Exiting connection monitor")
+	c.logger.Debugf("Exiting connection monitor")
 }
 
 func (c *Client) reconnect() {
-	logger.Debugf("Waiting %s before attempting to reconnect event client...", c.reconnInitialDelay)
+	c.logger.Debugf("Waiting %s before attempting to reconnect event client...", c.reconnInitialDelay)
 	time.Sleep(c.reconnInitialDelay)
 
-	logger.Debugf("Attempting to reconnect event client...")
+	c.logger.Debugf("Attempting to reconnect event client...")
 
 	handler := c.beforeReconnectHandler()
 	if handler != nil {
 		if err := handler(); err != nil {
-			logger.Errorf("Error invoking beforeReconnect handler: %s", err)
+			c.logger.Errorf("Error invoking beforeReconnect handler: %s", err)
 			return
 		}
 	}
 
 	if err := c.connectWithRetry(c.maxReconnAttempts, c.timeBetweenConnAttempts); err != nil {
-		logger.Warnf("Could not reconnect event client: %s. Closing.", err)
+		c.logger.Warnf("Could not reconnect event client: %s. Closing.", err)
 		c.Close()
 	}
 }
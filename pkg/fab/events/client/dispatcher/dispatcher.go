@@ -8,19 +8,23 @@ package dispatcher
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 
 	contextapi "github.com/hyperledger/fabric-sdk-go/pkg/context/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/lbp"
 	esdispatcher "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/client/dispatcher")
 
 // Dispatcher is responsible for handling all events, including connection and registration events originating from the client,
 // and events originating from the event server. All events are processed in a single Go routine
@@ -36,6 +40,13 @@ type Dispatcher struct {
 	connection             api.Connection
 	connectionRegistration *ConnectionReg
 	connectionProvider     api.ConnectionProvider
+	metrics                *metrics.Metrics
+	logger                 *logging.Logger
+	connected              bool
+	peer                   fab.Peer
+	peerLock               sync.RWMutex
+	monitorDone            chan struct{}
+	monitorStopped         chan struct{}
 }
 
 type handler func(esdispatcher.Event)
@@ -52,9 +63,25 @@ func New(context context.Context, channelID string, connectionProvider api.Conne
 		discoveryService:   discoveryService,
 		channelID:          channelID,
 		connectionProvider: connectionProvider,
+		metrics:            newMetrics(context),
+		logger:             newLogger(context),
 	}
 }
 
+func newMetrics(context context.Context) *metrics.Metrics {
+	if context == nil {
+		return metrics.New(nil)
+	}
+	return metrics.New(context.MetricsProvider())
+}
+
+func newLogger(context context.Context) *logging.Logger {
+	if context == nil {
+		return logger
+	}
+	return logger.ForProvider(context.LoggerProvider())
+}
+
 // Start starts the dispatcher
 func (ed *Dispatcher) Start() error {
 	ed.registerHandlers()
@@ -62,6 +89,12 @@ func (ed *Dispatcher) Start() error {
 	if err := ed.Dispatcher.Start(); err != nil {
 		return errors.WithMessage(err, "error starting client event dispatcher")
 	}
+
+	if evaluator, ok := ed.loadBalancePolicy.(lbp.PeerEvaluator); ok && ed.peerMonitorPeriod > 0 {
+		ed.monitorDone = make(chan struct{})
+		ed.monitorStopped = make(chan struct{})
+		go ed.monitorPeer(evaluator, ed.monitorDone, ed.monitorStopped)
+	}
 	return nil
 }
 
@@ -75,6 +108,24 @@ func (ed *Dispatcher) Connection() api.Connection {
 	return ed.connection
 }
 
+// ConnectedPeer returns the peer providing events, nil if not connected
+func (ed *Dispatcher) ConnectedPeer() fab.Peer {
+	ed.peerLock.RLock()
+	defer ed.peerLock.RUnlock()
+	return ed.peer
+}
+
+func (ed *Dispatcher) setConnectedPeer(peer fab.Peer) {
+	ed.peerLock.Lock()
+	defer ed.peerLock.Unlock()
+	ed.peer = peer
+}
+
+// Metrics returns the metrics recorded by the dispatcher
+func (ed *Dispatcher) Metrics() *metrics.Metrics {
+	return ed.metrics
+}
+
 // HandleStopEvent handles a Stop event by clearing all registrations
 // and stopping the listener
 func (ed *Dispatcher) HandleStopEvent(e esdispatcher.Event) {
@@ -82,6 +133,14 @@ func (ed *Dispatcher) HandleStopEvent(e esdispatcher.Event) {
 	// so that the client is notified that the registration has been removed
 	ed.clearConnectionRegistration()
 
+	if ed.monitorDone != nil {
+		// Wait for the peer monitor to exit so that it doesn't submit
+		// to the event channel after the channel is closed
+		close(ed.monitorDone)
+		<-ed.monitorStopped
+		ed.monitorDone = nil
+	}
+
 	ed.Dispatcher.HandleStopEvent(e)
 }
 
@@ -120,12 +179,17 @@ func (ed *Dispatcher) HandleConnectEvent(e esdispatcher.Event) {
 
 	conn, err := ed.connectionProvider(ed.channelID, ed.context, peer)
 	if err != nil {
-		logger.Warnf("error creating connection: %s", err)
+		ed.logger.Warnf("error creating connection: %s", err)
 		evt.ErrCh <- errors.WithMessage(err, fmt.Sprintf("could not create client conn"))
 		return
 	}
 
 	ed.connection = conn
+	ed.setConnectedPeer(peer)
+	if ed.connected {
+		ed.metrics.EventReconnects.With(metrics.ChannelLabel, ed.channelID).Add(1)
+	}
+	ed.connected = true
 
 	go ed.connection.Receive(eventch)
 
@@ -141,10 +205,11 @@ func (ed *Dispatcher) HandleDisconnectEvent(e esdispatcher.Event) {
 		return
 	}
 
-	logger.Debugf("Closing connection...")
+	ed.logger.Debugf("Closing connection...")
 
 	ed.connection.Close()
 	ed.connection = nil
+	ed.setConnectedPeer(nil)
 
 	evt.Errch <- nil
 }
@@ -166,13 +231,13 @@ func (ed *Dispatcher) HandleRegisterConnectionEvent(e esdispatcher.Event) {
 func (ed *Dispatcher) HandleConnectedEvent(e esdispatcher.Event) {
 	evt := e.(*ConnectedEvent)
 
-	logger.Debugf("Handling connected event: %v", evt)
+	ed.logger.Debugf("Handling connected event: %v", evt)
 
 	if ed.connectionRegistration != nil && ed.connectionRegistration.Eventch != nil {
 		select {
 		case ed.connectionRegistration.Eventch <- &fab.ConnectionEvent{Connected: true}:
 		default:
-			logger.Warnf("Unable to send to connection event channel.")
+			ed.logger.Warnf("Unable to send to connection event channel.")
 		}
 	}
 }
@@ -181,22 +246,89 @@ func (ed *Dispatcher) HandleConnectedEvent(e esdispatcher.Event) {
 func (ed *Dispatcher) HandleDisconnectedEvent(e esdispatcher.Event) {
 	evt := e.(*DisconnectedEvent)
 
-	logger.Debugf("Disconnecting from event server: %s", evt.Err)
+	ed.logger.Debugf("Disconnecting from event server: %s", evt.Err)
 
 	if ed.connection != nil {
 		ed.connection.Close()
 		ed.connection = nil
 	}
+	ed.setConnectedPeer(nil)
 
 	if ed.connectionRegistration != nil {
-		logger.Debugf("Disconnected from event server: %s", evt.Err)
+		ed.logger.Debugf("Disconnected from event server: %s", evt.Err)
 		select {
 		case ed.connectionRegistration.Eventch <- &fab.ConnectionEvent{Connected: false, Err: evt.Err}:
 		default:
-			logger.Warnf("Unable to send to connection event channel.")
+			ed.logger.Warnf("Unable to send to connection event channel.")
 		}
 	} else {
-		logger.Warnf("Disconnected from event server: %s", evt.Err)
+		ed.logger.Warnf("Disconnected from event server: %s", evt.Err)
+	}
+}
+
+// handleSwitchPeerEvent disconnects from a peer that the load-balance policy has found
+// to be worse than another. The disconnect is handled like a lost connection so the
+// client reconnects, and resumes from the last block received, if it is configured to.
+func (ed *Dispatcher) handleSwitchPeerEvent(e esdispatcher.Event) {
+	evt := e.(*switchPeerEvent)
+
+	if ed.connection == nil || ed.peer != evt.peer {
+		ed.logger.Debugf("No longer connected to peer %s - ignoring switch request", evt.peer.URL())
+		return
+	}
+
+	ed.logger.Infof("Disconnecting from peer %s since a better event source is available", evt.peer.URL())
+	ed.HandleEvent(NewDisconnectedEvent(errors.Errorf("switching event source from peer %s", evt.peer.URL())))
+}
+
+// monitorPeer periodically asks the policy whether the connected peer should be replaced.
+// Peer heights are queried here rather than in the dispatcher's Go routine so that
+// event processing is not held up. stopped is closed when the monitor exits.
+func (ed *Dispatcher) monitorPeer(evaluator lbp.PeerEvaluator, done <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+
+	ticker := time.NewTicker(ed.peerMonitorPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			ed.logger.Debugf("Exiting peer monitor")
+			return
+		case <-ticker.C:
+		}
+
+		peer := ed.ConnectedPeer()
+		if peer == nil {
+			continue
+		}
+
+		peers, err := ed.discoveryService.GetPeers()
+		if err != nil {
+			ed.logger.Warnf("Unable to get peers to evaluate event source: %s", err)
+			continue
+		}
+
+		if evaluator.ShouldSwitch(peer, peers) {
+			ed.submit(&switchPeerEvent{peer: peer}, done)
+		}
+	}
+}
+
+// submit posts an event from the peer monitor. The event is dropped if done is closed
+// first, i.e. the dispatcher is stopping. The dispatcher waits for the monitor to exit
+// before closing its event channel, so the channel is still open here.
+func (ed *Dispatcher) submit(e esdispatcher.Event, done <-chan struct{}) {
+	eventch, err := ed.EventCh()
+	if err != nil {
+		ed.logger.Debugf("Unable to submit event: %s", err)
+		return
+	}
+
+	select {
+	case eventch <- e:
+	case <-done:
+		ed.logger.Debugf("Dispatcher is stopping - event not submitted")
 	}
 }
 
@@ -210,11 +342,12 @@ func (ed *Dispatcher) registerHandlers() {
 	ed.RegisterHandler(&ConnectedEvent{}, ed.HandleConnectedEvent)
 	ed.RegisterHandler(&DisconnectedEvent{}, ed.HandleDisconnectedEvent)
 	ed.RegisterHandler(&RegisterConnectionEvent{}, ed.HandleRegisterConnectionEvent)
+	ed.RegisterHandler(&switchPeerEvent{}, ed.handleSwitchPeerEvent)
 }
 
 func (ed *Dispatcher) clearConnectionRegistration() {
 	if ed.connectionRegistration != nil {
-		logger.Debugf("Closing connection registration event channel.")
+		ed.logger.Debugf("Closing connection registration event channel.")
 		close(ed.connectionRegistration.Eventch)
 		ed.connectionRegistration = nil
 	}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -241,6 +243,190 @@ func TestConnectionEvent(t *testing.T) {
 	}
 }
 
+func TestSwitchLaggingPeer(t *testing.T) {
+	heights := &mockHeights{heights: map[fab.Peer]uint64{peer1: 100, peer2: 100}}
+
+	dispatcher := New(
+		newMockContext(), "testchannel",
+		clientmocks.NewProviderFactory().Provider(
+			clientmocks.NewMockConnection(
+				clientmocks.WithLedger(
+					servicemocks.NewMockLedger(servicemocks.FilteredBlockEventFactory),
+				),
+			),
+		),
+		clientmocks.NewDiscoveryService(peer1, peer2),
+		WithLoadBalancePolicy(lbp.NewBlockHeight(heights.get, 5)),
+		WithPeerMonitorPeriod(100*time.Millisecond),
+	)
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	connch := make(chan *fab.ConnectionEvent, 10)
+	regerrch := make(chan error)
+	regch := make(chan fab.Registration)
+	dispatcherEventch <- NewRegisterConnectionEvent(connch, regch, regerrch)
+	select {
+	case <-regch:
+	case err := <-regerrch:
+		t.Fatalf("Error registering for connection events: %s", err)
+	}
+
+	errch := make(chan error)
+	dispatcherEventch <- NewConnectEvent(errch)
+	if err := <-errch; err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	laggingPeer := dispatcher.ConnectedPeer()
+	if laggingPeer == nil {
+		t.Fatalf("Expecting to be connected to a peer")
+	}
+
+	// Peers are in sync, so no switch is expected
+	select {
+	case event := <-connch:
+		t.Fatalf("Unexpected connection event: %+v", event)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	heights.set(laggingPeer, 50)
+
+	select {
+	case event := <-connch:
+		if event.Connected || event.Err == nil || !strings.Contains(event.Err.Error(), "switching event source") {
+			t.Fatalf("Expecting disconnected event due to switching event source but got %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for lagging peer to be disconnected")
+	}
+
+	if dispatcher.ConnectedPeer() != nil {
+		t.Fatalf("Expecting no connected peer after switch")
+	}
+
+	// Reconnect - the lagging peer should not be chosen
+	dispatcherEventch <- NewConnectEvent(errch)
+	if err := <-errch; err != nil {
+		t.Fatalf("Error reconnecting: %s", err)
+	}
+	if dispatcher.ConnectedPeer() == laggingPeer {
+		t.Fatalf("Expecting to reconnect to a peer other than the lagging peer")
+	}
+
+	stopResp := make(chan error)
+	dispatcherEventch <- esdispatcher.NewStopEvent(stopResp)
+	if err := <-stopResp; err != nil {
+		t.Fatalf("Error stopping dispatcher: %s", err)
+	}
+}
+
+func TestStopWhilePeerMonitorSubmits(t *testing.T) {
+	policy := &blockingPolicy{
+		LoadBalancePolicy: lbp.NewRoundRobin(),
+		evaluating:        make(chan struct{}, 1),
+		release:           make(chan struct{}),
+	}
+
+	dispatcher := New(
+		newMockContext(), "testchannel",
+		clientmocks.NewProviderFactory().Provider(
+			clientmocks.NewMockConnection(
+				clientmocks.WithLedger(
+					servicemocks.NewMockLedger(servicemocks.FilteredBlockEventFactory),
+				),
+			),
+		),
+		clientmocks.NewDiscoveryService(peer1, peer2),
+		WithLoadBalancePolicy(policy),
+		WithPeerMonitorPeriod(10*time.Millisecond),
+	)
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	errch := make(chan error)
+	dispatcherEventch <- NewConnectEvent(errch)
+	if err := <-errch; err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	select {
+	case <-policy.evaluating:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for the peer monitor to evaluate the connected peer")
+	}
+
+	// Stop the dispatcher while the monitor is about to submit a switch event
+	stopResp := make(chan error, 1)
+	dispatcherEventch <- esdispatcher.NewStopEvent(stopResp)
+	time.Sleep(100 * time.Millisecond)
+	close(policy.release)
+
+	select {
+	case err := <-stopResp:
+		if err != nil {
+			t.Fatalf("Error stopping dispatcher: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for dispatcher to stop")
+	}
+
+	select {
+	case <-dispatcher.monitorStopped:
+	default:
+		t.Fatalf("Expecting the peer monitor to have exited once the dispatcher is stopped")
+	}
+}
+
+// blockingPolicy always asks for the connected peer to be replaced, once released
+type blockingPolicy struct {
+	lbp.LoadBalancePolicy
+	evaluating chan struct{}
+	release    chan struct{}
+}
+
+func (p *blockingPolicy) ShouldSwitch(current fab.Peer, peers []fab.Peer) bool {
+	select {
+	case p.evaluating <- struct{}{}:
+	default:
+	}
+	<-p.release
+	return true
+}
+
+type mockHeights struct {
+	mutex   sync.RWMutex
+	heights map[fab.Peer]uint64
+}
+
+func (m *mockHeights) get(peer fab.Peer) (uint64, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	h, ok := m.heights[peer]
+	if !ok {
+		return 0, errors.Errorf("peer %s is unavailable", peer.URL())
+	}
+	return h, nil
+}
+
+func (m *mockHeights) set(peer fab.Peer, height uint64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.heights[peer] = height
+}
+
 func newMockContext() context.Context {
 	return fabmocks.NewMockContext(fabmocks.NewMockUser("user1"))
 }
@@ -64,3 +64,9 @@ type DisconnectEvent struct {
 func NewDisconnectEvent(errch chan<- error) *DisconnectEvent {
 	return &DisconnectEvent{Errch: errch}
 }
+
+// switchPeerEvent is a request to disconnect from the given peer so that
+// the client reconnects to a better one
+type switchPeerEvent struct {
+	peer fab.Peer
+}
@@ -7,17 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/lbp"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
 )
 
 type params struct {
 	loadBalancePolicy lbp.LoadBalancePolicy
+	peerMonitorPeriod time.Duration
 }
 
 func defaultParams() *params {
 	return &params{
 		loadBalancePolicy: lbp.NewRoundRobin(),
+		peerMonitorPeriod: 10 * time.Second,
 	}
 }
 
@@ -31,6 +35,19 @@ func WithLoadBalancePolicy(value lbp.LoadBalancePolicy) options.Opt {
 	}
 }
 
+// WithPeerMonitorPeriod sets how often the connected peer is re-evaluated when the
+// load-balance policy is an lbp.PeerEvaluator (e.g. lbp.BlockHeight). If the policy reports that
+// a better peer is available then the client disconnects and, if reconnect is enabled,
+// resumes from the last block received on the peer chosen by the policy.
+// If set to 0 then the connected peer is not re-evaluated.
+func WithPeerMonitorPeriod(value time.Duration) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(peerMonitorPeriodSetter); ok {
+			setter.SetPeerMonitorPeriod(value)
+		}
+	}
+}
+
 type loadBalancePolicySetter interface {
 	SetLoadBalancePolicy(value lbp.LoadBalancePolicy)
 }
@@ -39,3 +56,12 @@ func (p *params) SetLoadBalancePolicy(value lbp.LoadBalancePolicy) {
 	logger.Debugf("LoadBalancePolicy: %#v", value)
 	p.loadBalancePolicy = value
 }
+
+type peerMonitorPeriodSetter interface {
+	SetPeerMonitorPeriod(value time.Duration)
+}
+
+func (p *params) SetPeerMonitorPeriod(value time.Duration) {
+	logger.Debugf("PeerMonitorPeriod: %s", value)
+	p.peerMonitorPeriod = value
+}
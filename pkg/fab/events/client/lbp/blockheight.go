@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lbp
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
+	"github.com/pkg/errors"
+)
+
+// BlockHeightProvider returns the ledger height of the channel on the given peer
+type BlockHeightProvider func(peer fab.Peer) (uint64, error)
+
+// LedgerHeight returns a BlockHeightProvider that queries the peer's ledger (QueryInfo)
+// for the height of the given channel
+func LedgerHeight(ctx context.Context, channelID string) BlockHeightProvider {
+	return func(peer fab.Peer) (uint64, error) {
+		ledger, err := channel.NewLedger(ctx, channelID)
+		if err != nil {
+			return 0, err
+		}
+		infos, err := ledger.QueryInfo([]fab.ProposalProcessor{peer})
+		if err != nil {
+			return 0, err
+		}
+		if len(infos) == 0 {
+			return 0, errors.Errorf("no blockchain info returned from peer %s", peer.URL())
+		}
+		return infos[0].Height, nil
+	}
+}
+
+// BlockHeight is a load-balance policy that prefers peers whose ledger is no more than
+// a given number of blocks behind the highest ledger among the candidate peers.
+// Peers whose height cannot be determined are not chosen, unless the height
+// of none of the peers can be determined.
+//
+// BlockHeight also implements PeerEvaluator so that the event client
+// can move away from a peer that has fallen behind.
+type BlockHeight struct {
+	heightOf    BlockHeightProvider
+	maxBlockLag uint64
+	chooser     LoadBalancePolicy
+}
+
+// NewBlockHeight returns a new BlockHeight load-balance policy. Peers up to maxBlockLag blocks
+// behind the highest peer are eligible and are chosen from in round-robin fashion.
+func NewBlockHeight(heightOf BlockHeightProvider, maxBlockLag uint64) *BlockHeight {
+	return &BlockHeight{
+		heightOf:    heightOf,
+		maxBlockLag: maxBlockLag,
+		chooser:     NewRoundRobin(),
+	}
+}
+
+// Choose chooses a peer among those that are within the allowed block lag
+func (lbp *BlockHeight) Choose(peers []fab.Peer) (fab.Peer, error) {
+	if len(peers) == 0 {
+		logger.Warnf("No peers to choose from!")
+		return nil, nil
+	}
+
+	heights := lbp.heights(peers)
+	maxHeight, ok := highest(heights)
+	if !ok {
+		logger.Warnf("Unable to determine the block height of any peer. Choosing from all peers.")
+		return lbp.chooser.Choose(peers)
+	}
+
+	var eligible []fab.Peer
+	for i, h := range heights {
+		if h.err == nil && h.height+lbp.maxBlockLag >= maxHeight {
+			eligible = append(eligible, peers[i])
+		}
+	}
+
+	logger.Debugf("%d of %d peers are within %d blocks of height %d", len(eligible), len(peers), lbp.maxBlockLag, maxHeight)
+	return lbp.chooser.Choose(eligible)
+}
+
+// ShouldSwitch returns true if current is more than the allowed number of blocks behind the
+// highest of the given peers, or if the height of current cannot be determined while that
+// of another peer can.
+func (lbp *BlockHeight) ShouldSwitch(current fab.Peer, peers []fab.Peer) bool {
+	candidates := []fab.Peer{current}
+	for _, p := range peers {
+		if !samePeer(p, current) {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 1 {
+		return false
+	}
+
+	heights := lbp.heights(candidates)
+	maxHeight, ok := highest(heights)
+	if !ok {
+		return false
+	}
+
+	if heights[0].err != nil {
+		logger.Warnf("Unable to determine block height of peer %s: %s", current.URL(), heights[0].err)
+		return true
+	}
+
+	if heights[0].height+lbp.maxBlockLag < maxHeight {
+		logger.Infof("Peer %s at block height %d is more than %d blocks behind height %d", current.URL(), heights[0].height, lbp.maxBlockLag, maxHeight)
+		return true
+	}
+	return false
+}
+
+type peerHeight struct {
+	height uint64
+	err    error
+}
+
+// heights queries the height of each peer concurrently
+func (lbp *BlockHeight) heights(peers []fab.Peer) []peerHeight {
+	heights := make([]peerHeight, len(peers))
+
+	var wg sync.WaitGroup
+	wg.Add(len(peers))
+	for i, p := range peers {
+		go func(i int, p fab.Peer) {
+			defer wg.Done()
+			h, err := lbp.heightOf(p)
+			if err != nil {
+				logger.Debugf("Error querying block height of peer %s: %s", p.URL(), err)
+			}
+			heights[i] = peerHeight{height: h, err: err}
+		}(i, p)
+	}
+	wg.Wait()
+
+	return heights
+}
+
+func highest(heights []peerHeight) (uint64, bool) {
+	var max uint64
+	found := false
+	for _, h := range heights {
+		if h.err == nil && (!found || h.height > max) {
+			max = h.height
+			found = true
+		}
+	}
+	return max, found
+}
+
+func samePeer(p1, p2 fab.Peer) bool {
+	return p1 == p2 || (p1.URL() != "" && p1.URL() == p2.URL())
+}
@@ -14,3 +14,10 @@ import (
 type LoadBalancePolicy interface {
 	Choose(peers []fab.Peer) (fab.Peer, error)
 }
+
+// PeerEvaluator is implemented by load-balance policies that can tell whether
+// the peer currently providing events should be replaced by a better one.
+type PeerEvaluator interface {
+	// ShouldSwitch returns true if a better peer than current is available among peers
+	ShouldSwitch(current fab.Peer, peers []fab.Peer) bool
+}
@@ -8,10 +8,12 @@ package lbp
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -97,6 +99,95 @@ func TestRoundRobin(t *testing.T) {
 	}
 }
 
+func TestBlockHeight(t *testing.T) {
+	heights := newMockHeights(map[fab.Peer]uint64{p1: 100, p2: 98, p3: 90})
+	lbp := NewBlockHeight(heights.get, 5)
+
+	// Test with an empty set of peers
+	peer, err := lbp.Choose([]fab.Peer{})
+	if err != nil {
+		t.Fatalf("error choosing peer with block-height load-balance policy: %s", err)
+	}
+	if peer != nil {
+		t.Fatalf("expecting chosen peer to be nil with empty set of peers")
+	}
+
+	// p3 is too far behind and p4's height can't be determined
+	peers := []fab.Peer{p1, p2, p3, p4}
+	chosen := make(map[fab.Peer]bool)
+	for i := 0; i < 10; i++ {
+		peer, err := lbp.Choose(peers)
+		if err != nil {
+			t.Fatalf("error choosing peer with block-height load-balance policy: %s", err)
+		}
+		chosen[peer] = true
+	}
+	if len(chosen) != 2 || !chosen[p1] || !chosen[p2] {
+		t.Fatalf("expecting only peers within the allowed block lag to be chosen but got %v", chosen)
+	}
+
+	// No heights available - choose from all peers
+	peer, err = lbp.Choose([]fab.Peer{p4})
+	if err != nil {
+		t.Fatalf("error choosing peer with block-height load-balance policy: %s", err)
+	}
+	if peer != p4 {
+		t.Fatalf("expecting p4 to be chosen when no heights are available")
+	}
+}
+
+func TestBlockHeightShouldSwitch(t *testing.T) {
+	heights := newMockHeights(map[fab.Peer]uint64{p1: 100, p2: 98, p3: 90})
+	lbp := NewBlockHeight(heights.get, 5)
+
+	peers := []fab.Peer{p1, p2, p3, p4}
+	if lbp.ShouldSwitch(p2, peers) {
+		t.Fatalf("expecting not to switch from a peer within the allowed block lag")
+	}
+	if !lbp.ShouldSwitch(p3, peers) {
+		t.Fatalf("expecting to switch from a peer that is too far behind")
+	}
+	if !lbp.ShouldSwitch(p4, peers) {
+		t.Fatalf("expecting to switch from a peer whose height can't be determined")
+	}
+	if lbp.ShouldSwitch(p3, []fab.Peer{p3}) {
+		t.Fatalf("expecting not to switch when there are no other peers")
+	}
+	if lbp.ShouldSwitch(p3, []fab.Peer{p4}) {
+		t.Fatalf("expecting not to switch when no other peer's height can be determined")
+	}
+
+	heights.set(p3, 99)
+	if lbp.ShouldSwitch(p3, peers) {
+		t.Fatalf("expecting not to switch from a peer that has caught up")
+	}
+}
+
+type mockHeights struct {
+	mutex   sync.RWMutex
+	heights map[fab.Peer]uint64
+}
+
+func newMockHeights(heights map[fab.Peer]uint64) *mockHeights {
+	return &mockHeights{heights: heights}
+}
+
+func (m *mockHeights) get(peer fab.Peer) (uint64, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	h, ok := m.heights[peer]
+	if !ok {
+		return 0, errors.Errorf("peer %s is unavailable", peer.Name())
+	}
+	return h, nil
+}
+
+func (m *mockHeights) set(peer fab.Peer, height uint64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.heights[peer] = height
+}
+
 func findIndex(peers []fab.Peer, peer fab.Peer) int {
 	for i, p := range peers {
 		if peer == p {
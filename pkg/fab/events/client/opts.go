@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
 )
 
@@ -22,6 +23,7 @@ type params struct {
 	timeBetweenConnAttempts time.Duration
 	connEventCh             chan *fab.ConnectionEvent
 	respTimeout             time.Duration
+	loggerProvider          logApi.LoggerProvider
 }
 
 func defaultParams() *params {
@@ -143,6 +145,10 @@ func (p *params) SetResponseTimeout(value time.Duration) {
 	p.respTimeout = value
 }
 
+func (p *params) SetLoggerProvider(value logApi.LoggerProvider) {
+	p.loggerProvider = value
+}
+
 type reconnectSetter interface {
 	SetReconnect(value bool)
 }
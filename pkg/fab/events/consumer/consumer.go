@@ -32,7 +32,7 @@ import (
 	ehpb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/consumer")
 
 const defaultTimeout = time.Second * 3
 
@@ -119,6 +119,11 @@ func newEventsClientConnectionWithAddress(peerAddress string, cert *x509.Certifi
 	return conn, err
 }
 
+// logger returns the package logger bound to the logger provider of the client's context
+func (ec *eventsClient) logger() *logging.Logger {
+	return logger.ForProvider(ec.provider.LoggerProvider())
+}
+
 func (ec *eventsClient) send(emsg *ehpb.Event) error {
 	ec.Lock()
 	defer ec.Unlock()
@@ -164,7 +169,7 @@ func (ec *eventsClient) RegisterAsync(ies []*ehpb.Interest) error {
 		Timestamp:   ts,
 	}
 	if err = ec.send(emsg); err != nil {
-		logger.Errorf("error on Register send %s\n", err)
+		ec.logger().Errorf("error on Register send %s\n", err)
 	}
 	return err
 }
@@ -331,10 +336,10 @@ func (ec *eventsClient) establishConnectionAndRegister(secured bool) error {
 	serverClient := ehpb.NewEventsClient(conn)
 	ec.stream, err = serverClient.Chat(grpcContext.Background())
 	if err != nil {
-		logger.Error("events connection failed, cause: ", err)
+		ec.logger().Error("events connection failed, cause: ", err)
 		if secured && ec.allowInsecure {
 			//If secured mode failed and allow insecure is enabled then retry in insecure mode
-			logger.Debug("Secured establishConnectionAndRegister failed, attempting insecured")
+			ec.logger().Debug("Secured establishConnectionAndRegister failed, attempting insecured")
 			return ec.establishConnectionAndRegister(false)
 		}
 		return errors.Wrap(err, "events connection failed")
@@ -28,7 +28,7 @@ import (
 	"google.golang.org/grpc"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/deliverclient/connection")
 
 type deliverStream interface {
 	grpc.ClientStream
@@ -39,6 +39,7 @@ type deliverStream interface {
 // DeliverConnection manages the connection to the deliver server
 type DeliverConnection struct {
 	comm.GRPCConnection
+	logger *logging.Logger
 }
 
 // StreamProvider creates a deliver stream
@@ -75,6 +76,7 @@ func New(ctx fabcontext.Context, channelID string, streamProvider StreamProvider
 
 	return &DeliverConnection{
 		GRPCConnection: *connect,
+		logger:         logger.ForProvider(ctx.LoggerProvider()).With(logging.ChannelIDKey, channelID, logging.PeerKey, url),
 	}, nil
 }
 
@@ -95,7 +97,7 @@ func (c *DeliverConnection) Send(seekInfo *ab.SeekInfo) error {
 		return errors.New("connection is closed")
 	}
 
-	logger.Debugf("Sending %v\n", seekInfo)
+	c.logger.Debugf("Sending %v\n", seekInfo)
 
 	env, err := c.createSignedEnvelope(seekInfo)
 	if err != nil {
@@ -110,32 +112,32 @@ func (c *DeliverConnection) Receive(eventch chan<- interface{}) {
 	for {
 		stream := c.deliverStream()
 		if stream == nil {
-			logger.Warnf("The stream has closed. Terminating loop.\n")
+			c.logger.Warnf("The stream has closed. Terminating loop.\n")
 			break
 		}
 
 		in, err := stream.Recv()
 
 		if c.Closed() {
-			logger.Debugf("The connection has closed. Terminating loop.\n")
+			c.logger.Debugf("The connection has closed. Terminating loop.\n")
 			break
 		}
 
 		if err == io.EOF {
 			// This signifies that the stream has been terminated at the client-side. No need to send an event.
-			logger.Debugf("Received EOF from stream.\n")
+			c.logger.Debugf("Received EOF from stream.\n")
 			break
 		}
 
 		if err != nil {
-			logger.Errorf("Received error from stream: [%s]. Sending disconnected event.\n", err)
+			c.logger.Errorf("Received error from stream: [%s]. Sending disconnected event.\n", err)
 			eventch <- clientdisp.NewDisconnectedEvent(err)
 			break
 		}
 
 		eventch <- in
 	}
-	logger.Debugf("Exiting stream listener\n")
+	c.logger.Debugf("Exiting stream listener\n")
 }
 
 func (c *DeliverConnection) createSignedEnvelope(msg proto.Message) (*cb.Envelope, error) {
@@ -9,22 +9,27 @@ package deliverclient
 import (
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
 	fabcontext "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
+	clientdisp "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/dispatcher"
 	deliverconn "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/connection"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/dispatcher"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	esdispatcher "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/deliverclient")
 
 // deliverProvider is the connection provider used for connecting to the Deliver service
 var deliverProvider = func(channelID string, context fabcontext.Context, peer fab.Peer) (api.Connection, error) {
@@ -46,6 +51,8 @@ type Client struct {
 	stopped              int32
 	registerOnce         sync.Once
 	blockEventsPermitted bool
+	filteredOnly         int32
+	logger               *logging.Logger
 }
 
 // New returns a new deliver event client
@@ -54,35 +61,87 @@ func New(context fabcontext.Context, channelID string, discoveryService fab.Disc
 		return nil, errors.New("expecting channel ID")
 	}
 
+	// Log through the logger provider of the context unless overridden by an option
+	opts = append([]options.Opt{esdispatcher.WithLoggerProvider(context.LoggerProvider())}, opts...)
+
 	params := defaultParams()
 	options.Apply(params, opts)
 
-	client := &Client{
-		Client: *client.New(
-			params.permitBlockEvents,
-			dispatcher.New(context, channelID, params.connProvider, discoveryService, opts...),
-			opts...,
-		),
-		params: *params,
-	}
-	client.SetAfterConnectHandler(client.seek)
-	client.SetBeforeReconnectHandler(client.setSeekFromLastBlockReceived)
+	deliverClient := &Client{params: *params, logger: logger.ForProvider(context.LoggerProvider())}
+	deliverClient.Client = *client.New(
+		params.permitBlockEvents,
+		dispatcher.New(context, channelID, deliverClient.connectionProvider, discoveryService, opts...),
+		opts...,
+	)
+	deliverClient.SetAfterConnectHandler(deliverClient.seek)
+	deliverClient.SetBeforeReconnectHandler(deliverClient.setSeekFromLastBlockReceived)
 
-	if err := client.Start(); err != nil {
+	if err := deliverClient.Start(); err != nil {
 		return nil, err
 	}
 
-	return client, nil
+	return deliverClient, nil
+}
+
+// connectionProvider connects to the Deliver service, or to the DeliverFiltered
+// service if the client has fallen back to filtered block events
+func (c *Client) connectionProvider(channelID string, context fabcontext.Context, peer fab.Peer) (api.Connection, error) {
+	if atomic.LoadInt32(&c.filteredOnly) == 1 {
+		return deliverFilteredProvider(channelID, context, peer)
+	}
+	return c.connProvider(channelID, context, peer)
 }
 
 func (c *Client) seek() error {
-	logger.Debugf("sending seek request....\n")
+	c.logger.Debugf("sending seek request....\n")
 
 	seekInfo, err := c.seekInfo()
 	if err != nil {
 		return err
 	}
 
+	err = c.sendSeek(seekInfo)
+	if err == nil || !c.BlockEventsPermitted() || !isForbidden(err) {
+		return err
+	}
+
+	if !c.filteredBlockFallback {
+		return errors.WithMessage(err, "block events are not permitted for this identity (use filtered block events or the filtered block fallback option)")
+	}
+
+	c.logger.Warnf("block events are not permitted for this identity. Falling back to filtered block events.\n")
+	if err := c.fallBackToFilteredBlocks(); err != nil {
+		return err
+	}
+	return c.sendSeek(seekInfo)
+}
+
+// fallBackToFilteredBlocks revokes block events and replaces the connection to the
+// Deliver service with a connection to the DeliverFiltered service
+func (c *Client) fallBackToFilteredBlocks() error {
+	atomic.StoreInt32(&c.filteredOnly, 1)
+	c.SetBlockEventsPermitted(false)
+
+	errch := make(chan error)
+	c.Submit(clientdisp.NewDisconnectEvent(errch))
+	if err := <-errch; err != nil {
+		c.logger.Debugf("error closing connection to the deliver service: %s\n", err)
+	}
+
+	c.Submit(clientdisp.NewConnectEvent(errch))
+	if err := <-errch; err != nil {
+		return errors.WithMessage(err, "unable to connect to the filtered deliver service")
+	}
+	return nil
+}
+
+func isForbidden(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Group == status.DeliverServerStatus && s.Code == int32(cb.Status_FORBIDDEN)
+}
+
+func (c *Client) sendSeek(seekInfo *ab.SeekInfo) error {
+	var err error
 	errch := make(chan error)
 	c.Submit(dispatcher.NewSeekEvent(seekInfo, errch))
 
@@ -93,11 +152,11 @@ func (c *Client) seek() error {
 	}
 
 	if err != nil {
-		logger.Errorf("unable to send seek request: %s\n", err)
+		c.logger.Errorf("unable to send seek request: %s\n", err)
 		return err
 	}
 
-	logger.Debugf("successfully sent seek\n")
+	c.logger.Debugf("successfully sent seek\n")
 	return nil
 }
 
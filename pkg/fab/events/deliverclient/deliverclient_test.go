@@ -12,11 +12,13 @@ import (
 
 	fabcontext "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/dispatcher"
 	clientmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/mocks"
 	delivermocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/mocks"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	eventmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/mocks"
 	esdispatcher "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
 	fabclientmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
@@ -89,6 +91,83 @@ func TestClientConnect(t *testing.T) {
 
 // TestReconnect tests the ability of the Channel Event Client to retry multiple
 // times to connect, and reconnect after it has disconnected.
+func TestFilteredBlockFallback(t *testing.T) {
+	deliverPeer := eventmocks.NewMockDeliverPeer("mychannel")
+	deliverPeer.SetSeekAck(true)
+	if err := deliverPeer.Start("localhost:0"); err != nil {
+		t.Fatalf("error starting mock deliver peer: %s", err)
+	}
+	defer deliverPeer.Stop()
+
+	discoveryService := clientmocks.NewDiscoveryService(fabclientmocks.NewMockPeer("peer1", deliverPeer.URL()))
+
+	// Block events are permitted so no fallback is necessary
+	eventClient, err := New(newMockContext(), "mychannel", discoveryService, WithFilteredBlockFallback(), client.WithMaxConnectAttempts(1))
+	if err != nil {
+		t.Fatalf("error creating deliver client: %s", err)
+	}
+	if err := eventClient.Connect(); err != nil {
+		t.Fatalf("error connecting: %s", err)
+	}
+	if !eventClient.BlockEventsPermitted() {
+		t.Fatalf("expecting block events to be permitted")
+	}
+	eventClient.Close()
+
+	deliverPeer.SetSeekStatus(eventmocks.DeliverStream, cb.Status_FORBIDDEN)
+
+	// Without the fallback option the connection fails with a FORBIDDEN status
+	eventClient, err = New(newMockContext(), "mychannel", discoveryService, WithBlockEvents(), client.WithMaxConnectAttempts(1))
+	if err != nil {
+		t.Fatalf("error creating deliver client: %s", err)
+	}
+	err = eventClient.Connect()
+	s, ok := status.FromError(err)
+	if !ok || s.Group != status.DeliverServerStatus || s.Code != int32(cb.Status_FORBIDDEN) {
+		t.Fatalf("expecting FORBIDDEN deliver server status but got: %v", err)
+	}
+	eventClient.Close()
+
+	// With the fallback option the client connects to the filtered block stream
+	eventClient, err = New(newMockContext(), "mychannel", discoveryService, WithFilteredBlockFallback(), client.WithMaxConnectAttempts(1))
+	if err != nil {
+		t.Fatalf("error creating deliver client: %s", err)
+	}
+	defer eventClient.Close()
+
+	if err := eventClient.Connect(); err != nil {
+		t.Fatalf("error connecting: %s", err)
+	}
+	if eventClient.BlockEventsPermitted() {
+		t.Fatalf("expecting block events not to be permitted")
+	}
+
+	_, _, err = eventClient.RegisterBlockEvent()
+	s, ok = status.FromError(err)
+	if !ok || s.Code != status.BlockEventsNotPermitted.ToInt32() {
+		t.Fatalf("expecting BlockEventsNotPermitted status error but got: %v", err)
+	}
+
+	reg, eventch, err := eventClient.RegisterFilteredBlockEvent()
+	if err != nil {
+		t.Fatalf("error registering for filtered block events: %s", err)
+	}
+	defer eventClient.Unregister(reg)
+
+	deliverPeer.AddTransactions(servicemocks.NewTransaction("txid1", pb.TxValidationCode_VALID, cb.HeaderType_ENDORSER_TRANSACTION))
+	select {
+	case event, ok := <-eventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+		if event.FilteredBlock.Number != 0 {
+			t.Fatalf("expecting filtered block 0 but got %d", event.FilteredBlock.Number)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for filtered block event")
+	}
+}
+
 func TestReconnect(t *testing.T) {
 	// (1) Connect
 	//     -> should fail to connect on the first and second attempt but succeed on the third attempt
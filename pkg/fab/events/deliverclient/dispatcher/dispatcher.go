@@ -7,20 +7,25 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"time"
+
 	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
 	fabcontext "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/api"
 	clientdisp "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/dispatcher"
 	esdispatcher "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
 	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/deliverclient/dispatcher")
 
 type dsConnection interface {
 	api.Connection
@@ -34,12 +39,14 @@ type dsConnection interface {
 type Dispatcher struct {
 	clientdisp.Dispatcher
 	seekRequest *SeekEvent
+	logger      *logging.Logger
 }
 
 // New returns a new deliver dispatcher
 func New(context fabcontext.Context, channelID string, connectionProvider api.ConnectionProvider, discoveryService fab.DiscoveryService, opts ...options.Opt) *Dispatcher {
 	return &Dispatcher{
 		Dispatcher: *clientdisp.New(context, channelID, connectionProvider, discoveryService, opts...),
+		logger:     logger.ForProvider(context.LoggerProvider()),
 	}
 }
 
@@ -60,7 +67,7 @@ func (ed *Dispatcher) handleSeekEvent(e esdispatcher.Event) {
 	evt := e.(*SeekEvent)
 
 	if ed.Connection() == nil {
-		logger.Warnf("Unable to register channel since no connection was established.")
+		ed.logger.Warnf("Unable to register channel since no connection was established.")
 		return
 	}
 
@@ -72,6 +79,21 @@ func (ed *Dispatcher) handleSeekEvent(e esdispatcher.Event) {
 	}
 }
 
+// handleDeliverResponse handles a response received from the deliver connection
+// by dispatching its contents to the appropriate handler
+func (ed *Dispatcher) handleDeliverResponse(e esdispatcher.Event) {
+	switch evt := e.(*pb.DeliverResponse).Type.(type) {
+	case *pb.DeliverResponse_Status:
+		ed.handleDeliverResponseStatus(evt)
+	case *pb.DeliverResponse_Block:
+		ed.handleDeliverResponseBlock(evt)
+	case *pb.DeliverResponse_FilteredBlock:
+		ed.handleDeliverResponseFilteredBlock(evt)
+	default:
+		ed.logger.Warnf("Unsupported deliver response type: %T", evt)
+	}
+}
+
 func (ed *Dispatcher) handleDeliverResponseStatus(e esdispatcher.Event) {
 	evt := e.(*pb.DeliverResponse_Status)
 
@@ -81,7 +103,7 @@ func (ed *Dispatcher) handleDeliverResponseStatus(e esdispatcher.Event) {
 
 	if ed.seekRequest.ErrCh != nil {
 		if evt.Status != cb.Status_SUCCESS {
-			ed.seekRequest.ErrCh <- errors.Errorf("received error status from seek info request: %s", evt.Status)
+			ed.seekRequest.ErrCh <- status.New(status.DeliverServerStatus, int32(evt.Status), "received error status from seek info request", nil)
 		} else {
 			ed.seekRequest.ErrCh <- nil
 		}
@@ -91,7 +113,31 @@ func (ed *Dispatcher) handleDeliverResponseStatus(e esdispatcher.Event) {
 }
 
 func (ed *Dispatcher) handleDeliverResponseBlock(e esdispatcher.Event) {
-	ed.HandleBlock(e.(*pb.DeliverResponse_Block).Block)
+	block := e.(*pb.DeliverResponse_Block).Block
+	ed.observeLag(block)
+	ed.HandleBlock(block)
+}
+
+// observeLag records the delay between the creation of the block's first
+// transaction and the delivery of the block
+func (ed *Dispatcher) observeLag(block *cb.Block) {
+	if block.Data == nil || len(block.Data.Data) == 0 {
+		return
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return
+	}
+	payload, err := utils.ExtractPayload(env)
+	if err != nil || payload.Header == nil {
+		return
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil || chdr.Timestamp == nil {
+		return
+	}
+	created := time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos))
+	ed.Metrics().EventLag.With(metrics.ChannelLabel, ed.ChannelID()).Observe(time.Since(created).Seconds())
 }
 
 func (ed *Dispatcher) handleDeliverResponseFilteredBlock(e esdispatcher.Event) {
@@ -99,7 +145,7 @@ func (ed *Dispatcher) handleDeliverResponseFilteredBlock(e esdispatcher.Event) {
 }
 
 func (ed *Dispatcher) handleDisconnectedEvent(e esdispatcher.Event) {
-	logger.Debug("Handling disconnected event...")
+	ed.logger.Debug("Handling disconnected event...")
 
 	if ed.seekRequest != nil && ed.seekRequest.ErrCh != nil {
 		// We're in the middle of a seek request. Send an error response to the caller.
@@ -116,6 +162,7 @@ func (ed *Dispatcher) registerHandlers() {
 
 	// Register handlers
 	ed.RegisterHandler(&SeekEvent{}, ed.handleSeekEvent)
+	ed.RegisterHandler(&pb.DeliverResponse{}, ed.handleDeliverResponse)
 	ed.RegisterHandler(&pb.DeliverResponse_Status{}, ed.handleDeliverResponseStatus)
 	ed.RegisterHandler(&pb.DeliverResponse_Block{}, ed.handleDeliverResponseBlock)
 	ed.RegisterHandler(&pb.DeliverResponse_FilteredBlock{}, ed.handleDeliverResponseFilteredBlock)
@@ -20,6 +20,8 @@ import (
 	esdispatcher "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
 	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
 
@@ -320,6 +322,129 @@ func TestFilteredBlockEvents(t *testing.T) {
 	}
 }
 
+// TestDeliverResponses ensures that whole deliver responses, as submitted by
+// the deliver connection, are dispatched according to their type
+func TestDeliverResponses(t *testing.T) {
+	channelID := "testchannel"
+
+	dispatcher := New(
+		newMockContext(), channelID,
+		clientmocks.NewProviderFactory().Provider(
+			delivermocks.NewConnection(
+				clientmocks.WithResults(
+					clientmocks.NewResult(delivermocks.Seek, clientmocks.NoOpResult),
+				),
+				clientmocks.WithLedger(servicemocks.NewMockLedger(servicemocks.BlockEventFactory)),
+			),
+		),
+		clientmocks.NewDiscoveryService(peer1, peer2),
+	)
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	// Connect
+	errch := make(chan error)
+	dispatcherEventch <- clientdisp.NewConnectEvent(errch)
+	if err := <-errch; err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	// Seek - the connection doesn't respond so the status is submitted below
+	dispatcherEventch <- NewSeekEvent(seek.InfoNewest(), errch)
+	dispatcherEventch <- &pb.DeliverResponse{
+		Type: &pb.DeliverResponse_Status{Status: cb.Status_SUCCESS},
+	}
+
+	select {
+	case err := <-errch:
+		if err != nil {
+			t.Fatalf("error from seek request: %s", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timeout waiting for deliver status response")
+	}
+
+	// Register for block and filtered block events
+	beventch := make(chan *fab.BlockEvent, 10)
+	regch := make(chan fab.Registration)
+	dispatcherEventch <- esdispatcher.NewRegisterBlockEvent(blockfilter.AcceptAny, beventch, regch, errch)
+
+	var breg fab.Registration
+	select {
+	case breg = <-regch:
+	case err := <-errch:
+		t.Fatalf("Error registering for block events: %s", err)
+	}
+
+	fbeventch := make(chan *fab.FilteredBlockEvent, 10)
+	dispatcherEventch <- esdispatcher.NewRegisterFilteredBlockEvent(fbeventch, regch, errch)
+
+	var fbreg fab.Registration
+	select {
+	case fbreg = <-regch:
+	case err := <-errch:
+		t.Fatalf("Error registering for filtered block events: %s", err)
+	}
+
+	producer := servicemocks.NewBlockProducer()
+
+	dispatcherEventch <- &pb.DeliverResponse{
+		Type: &pb.DeliverResponse_Block{Block: producer.NewBlock(channelID)},
+	}
+
+	select {
+	case _, ok := <-beventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for block event")
+	}
+
+	// The filtered block registration is also notified of the block
+	select {
+	case <-fbeventch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for filtered block event")
+	}
+
+	dispatcherEventch <- &pb.DeliverResponse{
+		Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: producer.NewFilteredBlock(channelID)},
+	}
+
+	select {
+	case event, ok := <-fbeventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+		if event.FilteredBlock.ChannelId != channelID {
+			t.Fatalf("expecting channelID [%s] but got [%s]", channelID, event.FilteredBlock.ChannelId)
+		}
+		if event.FilteredBlock.Number != 1 {
+			t.Fatalf("expecting filtered block number 1 but got %d", event.FilteredBlock.Number)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for filtered block event")
+	}
+
+	// Unregister
+	dispatcherEventch <- esdispatcher.NewUnregisterEvent(breg)
+	dispatcherEventch <- esdispatcher.NewUnregisterEvent(fbreg)
+
+	// Stop
+	stopResp := make(chan error)
+	dispatcherEventch <- esdispatcher.NewStopEvent(stopResp)
+	if err := <-stopResp; err != nil {
+		t.Fatalf("Error stopping dispatcher: %s", err)
+	}
+}
+
 func newMockContext() fabcontext.Context {
 	return fabmocks.NewMockContext(fabmocks.NewMockUser("user1"))
 }
@@ -15,11 +15,12 @@ import (
 )
 
 type params struct {
-	connProvider      api.ConnectionProvider
-	permitBlockEvents bool
-	seekType          seek.Type
-	fromBlock         uint64
-	respTimeout       time.Duration
+	connProvider          api.ConnectionProvider
+	permitBlockEvents     bool
+	filteredBlockFallback bool
+	seekType              seek.Type
+	fromBlock             uint64
+	respTimeout           time.Duration
 }
 
 func defaultParams() *params {
@@ -40,6 +41,21 @@ func WithBlockEvents() options.Opt {
 	}
 }
 
+// WithFilteredBlockFallback indicates that block events are to be received if the caller has
+// sufficient privileges. If the peer rejects the request for block events as FORBIDDEN then the
+// client falls back to filtered block events and RegisterBlockEvent returns an error. Use
+// BlockEventsPermitted to determine which type of event is being received.
+func WithFilteredBlockFallback() options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(connectionProviderSetter); ok {
+			setter.SetConnectionProvider(deliverProvider, true)
+		}
+		if setter, ok := p.(filteredBlockFallbackSetter); ok {
+			setter.SetFilteredBlockFallback(true)
+		}
+	}
+}
+
 // WithSeekType specifies the point from which block events are to be received.
 func WithSeekType(value seek.Type) options.Opt {
 	return func(p options.Params) {
@@ -72,6 +88,10 @@ type connectionProviderSetter interface {
 	SetConnectionProvider(value api.ConnectionProvider, permitBlockEvents bool)
 }
 
+type filteredBlockFallbackSetter interface {
+	SetFilteredBlockFallback(value bool)
+}
+
 type seekTypeSetter interface {
 	SetSeekType(value seek.Type)
 }
@@ -86,6 +106,11 @@ func (p *params) SetConnectionProvider(connProvider api.ConnectionProvider, perm
 	p.permitBlockEvents = permitBlockEvents
 }
 
+func (p *params) SetFilteredBlockFallback(value bool) {
+	logger.Debugf("FilteredBlockFallback: %t", value)
+	p.filteredBlockFallback = value
+}
+
 func (p *params) SetFromBlock(value uint64) {
 	logger.Debugf("FromBlock: %d", value)
 	p.fromBlock = value
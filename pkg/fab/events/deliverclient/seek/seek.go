@@ -48,6 +48,17 @@ func InfoFrom(fromBlock uint64) *ab.SeekInfo {
 	return newSeekInfo(seekFromPos(fromBlock), maxPos)
 }
 
+// InfoRange returns a SeekInfo struct that indicates to the deliver server that we want
+// the blocks from fromBlock to toBlock (inclusive). The deliver server responds with
+// NOT_FOUND rather than waiting if a block in the range hasn't been committed.
+func InfoRange(fromBlock, toBlock uint64) *ab.SeekInfo {
+	return &ab.SeekInfo{
+		Start:    seekFromPos(fromBlock),
+		Stop:     seekFromPos(toBlock),
+		Behavior: ab.SeekInfo_FAIL_IF_NOT_READY,
+	}
+}
+
 func seekFromPos(fromBlock uint64) *ab.SeekPosition {
 	return &ab.SeekPosition{
 		Type: &ab.SeekPosition_Specified{
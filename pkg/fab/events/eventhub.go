@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/urlutil"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/blockdecoder"
 	consumer "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/consumer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
@@ -34,7 +35,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events")
 
 // EventHub allows a client to listen to event at a peer.
 type EventHub struct {
@@ -290,6 +291,11 @@ func (eventHub *EventHub) SetPeerAddr(peerURL string, peerTLSCertificate *x509.C
 	eventHub.allowInsecure = allowInsecure && !urlutil.HasProtocol(peerURL)
 }
 
+// logger returns the package logger bound to the logger provider of the event hub's context
+func (eventHub *EventHub) logger() *logging.Logger {
+	return logger.ForProvider(eventHub.provider.LoggerProvider())
+}
+
 // IsConnected gets connected state of eventhub
 // Returns true if connected to event source, false otherwise
 func (eventHub *EventHub) IsConnected() bool {
@@ -303,7 +309,7 @@ func (eventHub *EventHub) Connect() error {
 	defer eventHub.mtx.Unlock()
 
 	if eventHub.connected {
-		logger.Debugf("Nothing to do - EventHub already connected")
+		eventHub.logger().Debugf("Nothing to do - EventHub already connected")
 		return nil
 	}
 
@@ -343,26 +349,28 @@ func (eventHub *EventHub) Recv(msg *pb.Event) (bool, error) {
 		switch msg.Event.(type) {
 		case *pb.Event_Block:
 			blockEvent := msg.Event.(*pb.Event_Block)
-			logger.Debugf("Recv blockEvent for block number [%d]", blockEvent.Block.Header.Number)
+			eventHub.logger().Debugf("Recv blockEvent for block number [%d]", blockEvent.Block.Header.Number)
 			for _, v := range eventHub.getBlockRegistrants() {
 				v(blockEvent.Block)
 			}
 			txFilter := util.TxValidationFlags(blockEvent.Block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 			for i, tdata := range blockEvent.Block.Data.Data {
 				if txFilter.IsValid(i) {
-					if ccEvent, channelID, err := getChainCodeEvent(tdata); err != nil {
-						logger.Warnf("getChainCodeEvent return error: %v\n", err)
-					} else if ccEvent != nil {
-						eventHub.notifyChaincodeRegistrants(channelID, ccEvent, true)
+					if ccEvents, channelID, err := getChainCodeEvents(tdata); err != nil {
+						eventHub.logger().Warnf("getChainCodeEvents return error: %v\n", err)
+					} else {
+						for _, ccEvent := range ccEvents {
+							eventHub.notifyChaincodeRegistrants(channelID, ccEvent, true)
+						}
 					}
 				} else {
-					logger.Debugf("received invalid transaction")
+					eventHub.logger().Debugf("received invalid transaction")
 				}
 			}
 			return
 		case *pb.Event_ChaincodeEvent:
 			ccEvent := msg.Event.(*pb.Event_ChaincodeEvent)
-			logger.Debugf("Recv ccEvent for txID [%s]", ccEvent.ChaincodeEvent.TxId)
+			eventHub.logger().Debugf("Recv ccEvent for txID [%s]", ccEvent.ChaincodeEvent.TxId)
 			if ccEvent != nil {
 				eventHub.notifyChaincodeRegistrants("", ccEvent.ChaincodeEvent, false)
 			}
@@ -378,7 +386,7 @@ func (eventHub *EventHub) Recv(msg *pb.Event) (bool, error) {
 // Disconnected implements consumer.EventAdapter interface for receiving events
 func (eventHub *EventHub) Disconnected(err error) {
 	if err != nil {
-		logger.Warnf("EventHub was disconnected unexpectedly: %s", err)
+		eventHub.logger().Warnf("EventHub was disconnected unexpectedly: %s", err)
 	}
 }
 
@@ -422,7 +430,7 @@ func (eventHub *EventHub) UnregisterChaincodeEvent(cbe *fab.ChainCodeCBE) {
 	if ok {
 		cbeArray := ccRegistrantArray.([]*fab.ChainCodeCBE)
 		if len(cbeArray) <= 0 {
-			logger.Debugf("No event registration for ccid %s \n", cbe.CCID)
+			eventHub.logger().Debugf("No event registration for ccid %s \n", cbe.CCID)
 			return
 		}
 
@@ -445,14 +453,14 @@ func (eventHub *EventHub) UnregisterChaincodeEvent(cbe *fab.ChainCodeCBE) {
 // callback: Function that takes a single parameter which
 // is a json object representation of type "message Transaction"
 func (eventHub *EventHub) RegisterTxEvent(txnID fab.TransactionID, callback func(fab.TransactionID, pb.TxValidationCode, error)) {
-	logger.Debugf("reg txid %s\n", txnID)
+	eventHub.logger().Debugf("reg txid %s\n", txnID)
 	eventHub.txRegistrants.Store(txnID, callback)
 }
 
 // UnregisterTxEvent unregister transactional event registration.
 // txid: transaction id
 func (eventHub *EventHub) UnregisterTxEvent(txnID fab.TransactionID) {
-	logger.Debugf("un-reg txid %s\n", txnID)
+	eventHub.logger().Debugf("un-reg txid %s\n", txnID)
 	eventHub.txRegistrants.Delete(txnID)
 }
 
@@ -466,13 +474,13 @@ func (eventHub *EventHub) txCallback(block *common.Block) {
 	for i, v := range block.Data.Data {
 
 		if env, err := utils.GetEnvelopeFromBlock(v); err != nil {
-			logger.Debugf("error extracting Envelope from block: %v\n", err)
+			eventHub.logger().Debugf("error extracting Envelope from block: %v\n", err)
 			return
 		} else if env != nil {
 			// get the payload from the envelope
 			payload, err := utils.GetPayload(env)
 			if err != nil {
-				logger.Debugf("error extracting Payload from envelope: %v\n", err)
+				eventHub.logger().Debugf("error extracting Payload from envelope: %v\n", err)
 				return
 			}
 
@@ -480,7 +488,7 @@ func (eventHub *EventHub) txCallback(block *common.Block) {
 			channelHeader := &common.ChannelHeader{}
 			err = proto.Unmarshal(channelHeaderBytes, channelHeader)
 			if err != nil {
-				logger.Debugf("error extracting ChannelHeader from payload: %v\n", err)
+				eventHub.logger().Debugf("error extracting ChannelHeader from payload: %v\n", err)
 				return
 			}
 
@@ -494,7 +502,7 @@ func (eventHub *EventHub) txCallback(block *common.Block) {
 					callback(fab.TransactionID(txnID), txFilter.Flag(i), nil)
 				}
 			} else {
-				logger.Debugf("No callback registered for TxID: %s\n", txnID)
+				eventHub.logger().Debugf("No callback registered for TxID: %s\n", txnID)
 			}
 		}
 	}
@@ -533,63 +541,31 @@ func (eventHub *EventHub) getTXRegistrant(txID fab.TransactionID) func(fab.Trans
 }
 
 // getChainCodeEvents parses block events for chaincode events associated with individual transactions
-func getChainCodeEvent(tdata []byte) (event *pb.ChaincodeEvent, channelID string, err error) {
+func getChainCodeEvents(tdata []byte) (events []*pb.ChaincodeEvent, channelID string, err error) {
 
 	if tdata == nil {
 		return nil, "", errors.New("Cannot extract payload from nil transaction")
 	}
 
-	if env, err := utils.GetEnvelopeFromBlock(tdata); err != nil {
-		return nil, "", errors.Wrap(err, "tx from block failed")
-	} else if env != nil {
-		// get the payload from the envelope
-		payload, err := utils.GetPayload(env)
-		if err != nil {
-			return nil, "", errors.Wrap(err, "extract payload from envelope failed")
-		}
-
-		channelHeaderBytes := payload.Header.ChannelHeader
-		channelHeader := &common.ChannelHeader{}
-		err = proto.Unmarshal(channelHeaderBytes, channelHeader)
-		if err != nil {
-			return nil, "", errors.Wrap(err, "unmarshal channel header failed")
-		}
-
-		channelID := channelHeader.ChannelId
-
-		// Chaincode events apply to endorser transaction only
-		if common.HeaderType(channelHeader.Type) == common.HeaderType_ENDORSER_TRANSACTION {
-			tx, err := utils.GetTransaction(payload.Data)
-			if err != nil {
-				return nil, "", errors.Wrap(err, "unmarshal transaction payload")
-			}
-			chaincodeActionPayload, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
-			if err != nil {
-				return nil, "", errors.Wrap(err, "chaincode action payload retrieval failed")
-			}
-			propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
-			if err != nil {
-				return nil, "", errors.Wrap(err, "proposal response payload retrieval failed")
-			}
-			caPayload, err := utils.GetChaincodeAction(propRespPayload.Extension)
-			if err != nil {
-				return nil, "", errors.Wrap(err, "chaincode action retrieval failed")
-			}
-			ccEvent, err := utils.GetChaincodeEvents(caPayload.Events)
+	tx, err := blockdecoder.DecodeTransactionEvents(tdata)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "decode transaction failed")
+	}
 
-			if ccEvent != nil {
-				return ccEvent, channelID, nil
-			}
+	// Chaincode events apply to endorser transaction only, which are the only ones with actions
+	for _, action := range tx.Actions {
+		if action.Event != nil {
+			events = append(events, action.Event)
 		}
 	}
-	return nil, "", nil
+	return events, tx.ChannelID, nil
 }
 
 // Utility function to fire callbacks for chaincode registrants
 func (eventHub *EventHub) notifyChaincodeRegistrants(channelID string, ccEvent *pb.ChaincodeEvent, patternMatch bool) {
 	cbeArray := eventHub.getChaincodeRegistrants(ccEvent.ChaincodeId)
 	if len(cbeArray) <= 0 {
-		logger.Debugf("No event registration for ccid %s \n", ccEvent.ChaincodeId)
+		eventHub.logger().Debugf("No event registration for ccid %s \n", ccEvent.ChaincodeId)
 	}
 	for _, v := range cbeArray {
 		match := v.EventNameFilter == ccEvent.EventName
@@ -26,12 +26,13 @@ import (
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/eventhubclient/connection")
 
 // EventHubConnection manages the connection and client stream
 // to the event hub server
 type EventHubConnection struct {
 	comm.GRPCConnection
+	logger *logging.Logger
 }
 
 // New returns a new Connection to the event hub.
@@ -53,6 +54,7 @@ func New(ctx fabcontext.Context, channelID string, url string, opts ...options.O
 
 	return &EventHubConnection{
 		GRPCConnection: *connect,
+		logger:         logger.ForProvider(ctx.LoggerProvider()).With(logging.ChannelIDKey, channelID, logging.PeerKey, url),
 	}, nil
 }
 
@@ -104,33 +106,33 @@ func (c *EventHubConnection) Send(emsg *pb.Event) error {
 // Receive receives events from the event hub server
 func (c *EventHubConnection) Receive(eventch chan<- interface{}) {
 	for {
-		logger.Debugf("Listening for events...")
+		c.logger.Debugf("Listening for events...")
 		if c.EventHubStream() == nil {
-			logger.Warnf("The stream has closed. Terminating loop.")
+			c.logger.Warnf("The stream has closed. Terminating loop.")
 			break
 		}
 
 		in, err := c.EventHubStream().Recv()
 
 		if c.Closed() {
-			logger.Debugf("The connection has closed. Terminating loop.")
+			c.logger.Debugf("The connection has closed. Terminating loop.")
 			break
 		}
 
 		if err == io.EOF {
 			// This signifies that the stream has been terminated at the client-side. No need to send an event.
-			logger.Debugf("Received EOF from stream.")
+			c.logger.Debugf("Received EOF from stream.")
 			break
 		}
 
 		if err != nil {
-			logger.Errorf("Received error from stream: [%s]. Sending disconnected event.", err)
+			c.logger.Errorf("Received error from stream: [%s]. Sending disconnected event.", err)
 			eventch <- clientdisp.NewDisconnectedEvent(err)
 			break
 		}
 
-		logger.Debugf("Got event %#v", in)
+		c.logger.Debugf("Got event %#v", in)
 		eventch <- in
 	}
-	logger.Debugf("Exiting stream listener")
+	c.logger.Debugf("Exiting stream listener")
 }
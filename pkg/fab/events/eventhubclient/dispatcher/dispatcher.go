@@ -18,7 +18,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/eventhubclient/dispatcher")
 
 type ehConnection interface {
 	api.Connection
@@ -32,12 +32,14 @@ type Dispatcher struct {
 	clientdisp.Dispatcher
 	regInterestsRequest   *RegisterInterestsEvent
 	unregInterestsRequest *UnregisterInterestsEvent
+	logger                *logging.Logger
 }
 
 // New creates a new event hub dispatcher
 func New(context context.Context, channelID string, connectionProvider api.ConnectionProvider, discoveryService fab.DiscoveryService, opts ...options.Opt) *Dispatcher {
 	return &Dispatcher{
 		Dispatcher: *clientdisp.New(context, channelID, connectionProvider, discoveryService, opts...),
+		logger:     logger.ForProvider(context.LoggerProvider()),
 	}
 }
 
@@ -58,7 +60,7 @@ func (ed *Dispatcher) handleRegInterestsEvent(e esdispatcher.Event) {
 	evt := e.(*RegisterInterestsEvent)
 
 	if ed.Connection() == nil {
-		logger.Warnf("Unable to register interests since no connection was established.")
+		ed.logger.Warnf("Unable to register interests since no connection was established.")
 		return
 	}
 
@@ -82,7 +84,7 @@ func (ed *Dispatcher) handleUnregInterestsEvent(e esdispatcher.Event) {
 	evt := e.(*UnregisterInterestsEvent)
 
 	if ed.Connection() == nil {
-		logger.Warnf("Unable to unregister interests since no connection was established.")
+		ed.logger.Warnf("Unable to unregister interests since no connection was established.")
 		return
 	}
 
@@ -108,7 +110,7 @@ func (ed *Dispatcher) handleRegInterestsResponse(e *pb.Event_Register) {
 	}
 
 	if err := validateInterests(e.Register.Events, ed.regInterestsRequest.Interests); err != nil {
-		logger.Warnf("Error registering interests: %s", err)
+		ed.logger.Warnf("Error registering interests: %s", err)
 		if ed.regInterestsRequest.ErrCh != nil {
 			ed.regInterestsRequest.ErrCh <- errors.Wrap(err, "error registering interests")
 		}
@@ -125,7 +127,7 @@ func (ed *Dispatcher) handleUnregInterestsResponse(e *pb.Event_Unregister) {
 	}
 
 	if err := validateInterests(e.Unregister.Events, ed.unregInterestsRequest.Interests); err != nil {
-		logger.Warnf("Error unregistering interests: %s", err)
+		ed.logger.Warnf("Error unregistering interests: %s", err)
 		if ed.unregInterestsRequest.ErrCh != nil {
 			ed.unregInterestsRequest.ErrCh <- errors.Wrap(err, "error unregistering interests")
 		}
@@ -159,7 +161,7 @@ func validateInterests(have []*pb.Interest, want []*pb.Interest) error {
 func (ed *Dispatcher) handleEvent(e esdispatcher.Event) {
 	event := e.(*pb.Event)
 
-	logger.Debugf("Handling event: %#v", event)
+	ed.logger.Debugf("Handling event: %#v", event)
 
 	switch evt := event.Event.(type) {
 	case *pb.Event_Block:
@@ -171,7 +173,7 @@ func (ed *Dispatcher) handleEvent(e esdispatcher.Event) {
 	case *pb.Event_Unregister:
 		ed.handleUnregInterestsResponse(evt)
 	default:
-		logger.Warnf("Unsupported event type: %T", event.Event)
+		ed.logger.Warnf("Unsupported event type: %T", event.Event)
 	}
 }
 
@@ -17,12 +17,13 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/eventhubclient/connection"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/eventhubclient/dispatcher"
+	esdispatcher "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/eventhubclient")
 
 var ehConnProvider = func(channelID string, context context.Context, peer fab.Peer) (api.Connection, error) {
 	eventEndpoint, ok := peer.(api.EventEndpoint)
@@ -39,6 +40,7 @@ var ehConnProvider = func(channelID string, context context.Context, peer fab.Pe
 type Client struct {
 	client.Client
 	params
+	logger *logging.Logger
 }
 
 // New returns a new event hub client
@@ -47,6 +49,9 @@ func New(context context.Context, channelID string, discoveryService fab.Discove
 		return nil, errors.New("expecting channel ID")
 	}
 
+	// Log through the logger provider of the context unless overridden by an option
+	opts = append([]options.Opt{esdispatcher.WithLoggerProvider(context.LoggerProvider())}, opts...)
+
 	params := defaultParams()
 	options.Apply(params, opts)
 
@@ -57,6 +62,7 @@ func New(context context.Context, channelID string, discoveryService fab.Discove
 			opts...,
 		),
 		params: *params,
+		logger: logger.ForProvider(context.LoggerProvider()),
 	}
 	client.SetAfterConnectHandler(client.registerInterests)
 
@@ -68,7 +74,7 @@ func New(context context.Context, channelID string, discoveryService fab.Discove
 }
 
 func (c *Client) registerInterests() error {
-	logger.Debugf("sending register interests request....\n")
+	c.logger.Debugf("sending register interests request....\n")
 
 	errch := make(chan error)
 	c.Submit(dispatcher.NewRegisterInterestsEvent(c.interests, errch))
@@ -81,10 +87,10 @@ func (c *Client) registerInterests() error {
 	}
 
 	if err != nil {
-		logger.Errorf("unable to send register interests request: %s\n", err)
+		c.logger.Errorf("unable to send register interests request: %s\n", err)
 		return err
 	}
 
-	logger.Debugf("successfully sent register interests\n")
+	c.logger.Debugf("successfully sent register interests\n")
 	return nil
 }
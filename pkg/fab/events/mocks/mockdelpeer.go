@@ -0,0 +1,488 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mocks
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/blockdecoder"
+	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+)
+
+// StreamType is the type of deliver stream
+type StreamType string
+
+const (
+	// DeliverStream is the stream of full blocks
+	DeliverStream StreamType = "deliver"
+	// DeliverFilteredStream is the stream of filtered blocks
+	DeliverFilteredStream StreamType = "deliverfiltered"
+)
+
+// MockDeliverPeer is a scriptable fake peer that serves the Deliver and DeliverFiltered services
+// for a channel. Blocks added to the peer's ledger are delivered to clients according to their
+// seek requests. Faults may be simulated with Disconnect, SetDelay and SetSeekStatus.
+//
+// As done by Fabric peers, a SUCCESS status is sent once the seek's stop block has been delivered
+// and an error status is sent if the seek request is rejected. See SetSeekAck for use with the
+// SDK's deliver client.
+type MockDeliverPeer struct {
+	mutex      sync.RWMutex
+	channelID  string
+	blocks     []*ledgerEntry
+	added      chan struct{}
+	streams    map[*deliverStream]bool
+	delay      time.Duration
+	status     map[StreamType]cb.Status
+	seekAck    bool
+	seeks      []*ab.SeekInfo
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+type ledgerEntry struct {
+	block    *cb.Block
+	filtered *pb.FilteredBlock
+}
+
+type deliverStream struct {
+	streamType StreamType
+	disconnect chan error
+}
+
+// deliverServer is implemented by both Deliver_DeliverServer and Deliver_DeliverFilteredServer
+type deliverServer interface {
+	Send(*pb.DeliverResponse) error
+	Recv() (*cb.Envelope, error)
+	Context() context.Context
+}
+
+// NewMockDeliverPeer returns a new fake peer for the given channel with an empty ledger
+func NewMockDeliverPeer(channelID string) *MockDeliverPeer {
+	return &MockDeliverPeer{
+		channelID: channelID,
+		added:     make(chan struct{}),
+		streams:   make(map[*deliverStream]bool),
+		status:    make(map[StreamType]cb.Status),
+	}
+}
+
+// Start starts serving on the given address (e.g. "localhost:0" to pick a free port)
+func (p *MockDeliverPeer) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %s", address)
+	}
+
+	p.mutex.Lock()
+	p.listener = listener
+	p.grpcServer = grpc.NewServer()
+	pb.RegisterDeliverServer(p.grpcServer, p)
+	grpcServer := p.grpcServer
+	p.mutex.Unlock()
+
+	go grpcServer.Serve(listener)
+	return nil
+}
+
+// Stop stops the server and closes all streams
+func (p *MockDeliverPeer) Stop() {
+	p.mutex.RLock()
+	grpcServer := p.grpcServer
+	p.mutex.RUnlock()
+
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+}
+
+// Address returns the address that the peer is listening on
+func (p *MockDeliverPeer) Address() string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if p.listener == nil {
+		return ""
+	}
+	return p.listener.Addr().String()
+}
+
+// URL returns the URL of the peer, for use by a (non-TLS) client
+func (p *MockDeliverPeer) URL() string {
+	return "grpc://" + p.Address()
+}
+
+// Height returns the number of blocks in the ledger
+func (p *MockDeliverPeer) Height() uint64 {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return uint64(len(p.blocks))
+}
+
+// AddBlock appends the block to the ledger, setting its number to the current height, and
+// delivers it to waiting clients. Filtered block streams receive the block in filtered form.
+// Blocks may be built with the service mocks (e.g. servicemocks.NewBlock) and config blocks
+// with fab/mocks.MockConfigBlockBuilder.
+func (p *MockDeliverPeer) AddBlock(block *cb.Block) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if block.Header == nil {
+		block.Header = &cb.BlockHeader{}
+	}
+	block.Header.Number = uint64(len(p.blocks))
+	p.append(&ledgerEntry{block: block, filtered: p.toFilteredBlock(block)})
+}
+
+// AddFilteredBlock appends the filtered block to the ledger, setting its number to the current
+// height. Full block streams receive an empty block with the same number.
+func (p *MockDeliverPeer) AddFilteredBlock(fblock *pb.FilteredBlock) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	number := uint64(len(p.blocks))
+	fblock.Number = number
+	if fblock.ChannelId == "" {
+		fblock.ChannelId = p.channelID
+	}
+	block := &cb.Block{
+		Header: &cb.BlockHeader{Number: number},
+		Data:   &cb.BlockData{},
+	}
+	p.append(&ledgerEntry{block: block, filtered: fblock})
+}
+
+// AddTransactions appends a block that contains the given transactions
+func (p *MockDeliverPeer) AddTransactions(txs ...*servicemocks.TxInfo) {
+	p.AddBlock(servicemocks.NewBlock(p.channelID, txs...))
+}
+
+// AddChaincodeEvent appends a block that contains a valid transaction with the given chaincode event
+func (p *MockDeliverPeer) AddChaincodeEvent(txID, ccID, eventName string, payload []byte) {
+	p.AddTransactions(&servicemocks.TxInfo{
+		TxID:             txID,
+		TxValidationCode: pb.TxValidationCode_VALID,
+		HeaderType:       cb.HeaderType_ENDORSER_TRANSACTION,
+		ChaincodeID:      ccID,
+		EventName:        eventName,
+		Payload:          payload,
+	})
+}
+
+// SetDelay sets the delay before each block is sent
+func (p *MockDeliverPeer) SetDelay(delay time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.delay = delay
+}
+
+// SetSeekStatus causes seek requests on the given stream type to be rejected with the given status,
+// e.g. cb.Status_FORBIDDEN to simulate a client that is not permitted to receive full blocks.
+// Setting cb.Status_SUCCESS restores normal behaviour.
+func (p *MockDeliverPeer) SetSeekStatus(streamType StreamType, status cb.Status) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.status[streamType] = status
+}
+
+// SetSeekAck causes a SUCCESS status to be sent as soon as a seek request is accepted, before any
+// block is delivered. Fabric peers don't send this status; it is provided for compatibility with
+// the SDK's deliver client, which waits for a status in response to its seek request (see
+// client.WithResponseTimeout) and fails to connect without one.
+func (p *MockDeliverPeer) SetSeekAck(enabled bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.seekAck = enabled
+}
+
+// Disconnect terminates all open streams, returning the given error to the clients
+func (p *MockDeliverPeer) Disconnect(err error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	for stream := range p.streams {
+		select {
+		case stream.disconnect <- err:
+		default:
+		}
+	}
+}
+
+// SeekRequests returns the seek requests received so far
+func (p *MockDeliverPeer) SeekRequests() []*ab.SeekInfo {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return append([]*ab.SeekInfo(nil), p.seeks...)
+}
+
+// Deliver serves a stream of blocks
+func (p *MockDeliverPeer) Deliver(srv pb.Deliver_DeliverServer) error {
+	return p.deliver(srv, DeliverStream)
+}
+
+// DeliverFiltered serves a stream of filtered blocks
+func (p *MockDeliverPeer) DeliverFiltered(srv pb.Deliver_DeliverFilteredServer) error {
+	return p.deliver(srv, DeliverFilteredStream)
+}
+
+func (p *MockDeliverPeer) deliver(srv deliverServer, streamType StreamType) error {
+	stream := &deliverStream{streamType: streamType, disconnect: make(chan error, 1)}
+	p.mutex.Lock()
+	p.streams[stream] = true
+	p.mutex.Unlock()
+
+	defer func() {
+		p.mutex.Lock()
+		delete(p.streams, stream)
+		p.mutex.Unlock()
+	}()
+
+	envch := make(chan *cb.Envelope)
+	go func() {
+		defer close(envch)
+		for {
+			envelope, err := srv.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case envch <- envelope:
+			case <-srv.Context().Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case err := <-stream.disconnect:
+			return err
+		case envelope, ok := <-envch:
+			if !ok {
+				return nil
+			}
+			if err := p.handleSeek(srv, stream, envelope); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleSeek delivers the blocks requested by the seek envelope. An error is returned if the stream is to be terminated.
+func (p *MockDeliverPeer) handleSeek(srv deliverServer, stream *deliverStream, envelope *cb.Envelope) error {
+	seekInfo, channelID, err := unmarshalSeekEnvelope(envelope)
+	if err != nil {
+		return sendStatus(srv, cb.Status_BAD_REQUEST)
+	}
+
+	p.mutex.Lock()
+	p.seeks = append(p.seeks, seekInfo)
+	status, ok := p.status[stream.streamType]
+	seekAck := p.seekAck
+	height := uint64(len(p.blocks))
+	p.mutex.Unlock()
+
+	if ok && status != cb.Status_SUCCESS {
+		return sendStatus(srv, status)
+	}
+	if channelID != p.channelID {
+		return sendStatus(srv, cb.Status_NOT_FOUND)
+	}
+
+	start, stop, ok := seekRange(seekInfo, height)
+	if !ok {
+		return sendStatus(srv, cb.Status_BAD_REQUEST)
+	}
+	if seekInfo.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY && start >= height {
+		return sendStatus(srv, cb.Status_NOT_FOUND)
+	}
+
+	if seekAck {
+		if err := sendStatus(srv, cb.Status_SUCCESS); err != nil {
+			return err
+		}
+	}
+
+	for number := start; number <= stop; number++ {
+		entry, err := p.waitForBlock(srv, stream, number, seekInfo.Behavior)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return sendStatus(srv, cb.Status_NOT_FOUND)
+		}
+
+		p.mutex.RLock()
+		delay := p.delay
+		p.mutex.RUnlock()
+		if delay > 0 {
+			select {
+			case err := <-stream.disconnect:
+				return err
+			case <-time.After(delay):
+			}
+		}
+
+		if err := srv.Send(entry.response(stream.streamType)); err != nil {
+			return err
+		}
+		if number == stop {
+			break
+		}
+	}
+
+	return sendStatus(srv, cb.Status_SUCCESS)
+}
+
+// waitForBlock returns the block with the given number, waiting for it to be added if the behavior is
+// BLOCK_UNTIL_READY. Nil is returned if the block doesn't exist and the behavior is FAIL_IF_NOT_READY.
+func (p *MockDeliverPeer) waitForBlock(srv deliverServer, stream *deliverStream, number uint64, behavior ab.SeekInfo_SeekBehavior) (*ledgerEntry, error) {
+	for {
+		p.mutex.RLock()
+		if number < uint64(len(p.blocks)) {
+			entry := p.blocks[number]
+			p.mutex.RUnlock()
+			return entry, nil
+		}
+		added := p.added
+		p.mutex.RUnlock()
+
+		if behavior == ab.SeekInfo_FAIL_IF_NOT_READY {
+			return nil, nil
+		}
+
+		select {
+		case <-added:
+		case err := <-stream.disconnect:
+			return nil, err
+		case <-srv.Context().Done():
+			return nil, srv.Context().Err()
+		}
+	}
+}
+
+// append adds the entry and notifies the streams that are waiting. The lock must be held.
+func (p *MockDeliverPeer) append(entry *ledgerEntry) {
+	p.blocks = append(p.blocks, entry)
+	close(p.added)
+	p.added = make(chan struct{})
+}
+
+// toFilteredBlock converts the block to a filtered block, as done by the peer
+func (p *MockDeliverPeer) toFilteredBlock(block *cb.Block) *pb.FilteredBlock {
+	fblock := &pb.FilteredBlock{
+		ChannelId: p.channelID,
+		Number:    block.Header.Number,
+	}
+
+	decoded, err := blockdecoder.DecodeBlock(block)
+	if err != nil {
+		return fblock
+	}
+
+	for _, tx := range decoded.Transactions {
+		ftx := &pb.FilteredTransaction{
+			Txid:             tx.TxID,
+			Type:             tx.Type,
+			TxValidationCode: tx.ValidationCode,
+		}
+
+		var actions []*pb.FilteredChaincodeAction
+		for _, action := range tx.Actions {
+			if action.Event == nil {
+				continue
+			}
+			// Filtered blocks don't include chaincode event payloads
+			actions = append(actions, &pb.FilteredChaincodeAction{
+				CcEvent: &pb.ChaincodeEvent{
+					ChaincodeId: action.Event.ChaincodeId,
+					TxId:        action.Event.TxId,
+					EventName:   action.Event.EventName,
+				},
+			})
+		}
+		if len(actions) > 0 {
+			ftx.Data = &pb.FilteredTransaction_TransactionActions{
+				TransactionActions: &pb.FilteredTransactionActions{ChaincodeActions: actions},
+			}
+		}
+
+		fblock.FilteredTx = append(fblock.FilteredTx, ftx)
+	}
+
+	return fblock
+}
+
+func (e *ledgerEntry) response(streamType StreamType) *pb.DeliverResponse {
+	if streamType == DeliverFilteredStream {
+		return &pb.DeliverResponse{Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: e.filtered}}
+	}
+	return &pb.DeliverResponse{Type: &pb.DeliverResponse_Block{Block: e.block}}
+}
+
+// seekRange returns the numbers of the first and last blocks of the seek request
+func seekRange(seekInfo *ab.SeekInfo, height uint64) (uint64, uint64, bool) {
+	start, ok := seekPosition(seekInfo.Start, height)
+	if !ok {
+		return 0, 0, false
+	}
+	stop, ok := seekPosition(seekInfo.Stop, height)
+	if !ok || stop < start {
+		return 0, 0, false
+	}
+	return start, stop, true
+}
+
+func seekPosition(pos *ab.SeekPosition, height uint64) (uint64, bool) {
+	switch t := pos.GetType().(type) {
+	case *ab.SeekPosition_Oldest:
+		return 0, true
+	case *ab.SeekPosition_Newest:
+		if height == 0 {
+			return 0, true
+		}
+		return height - 1, true
+	case *ab.SeekPosition_Specified:
+		return t.Specified.Number, true
+	default:
+		return 0, false
+	}
+}
+
+func unmarshalSeekEnvelope(envelope *cb.Envelope) (*ab.SeekInfo, string, error) {
+	payload, err := utils.ExtractPayload(envelope)
+	if err != nil {
+		return nil, "", err
+	}
+	if payload.Header == nil {
+		return nil, "", errors.New("payload header is missing")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, "", err
+	}
+	seekInfo := &ab.SeekInfo{}
+	if err := proto.Unmarshal(payload.Data, seekInfo); err != nil {
+		return nil, "", errors.Wrap(err, "failed to unmarshal seek info")
+	}
+	return seekInfo, chdr.ChannelId, nil
+}
+
+func sendStatus(srv deliverServer, status cb.Status) error {
+	return srv.Send(&pb.DeliverResponse{Type: &pb.DeliverResponse_Status{Status: status}})
+}
@@ -0,0 +1,316 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
+	clientmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
+	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/options"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+const (
+	testChannelID = "mychannel"
+	ccID          = "examplecc"
+	eventName     = "event1"
+)
+
+func TestMockDeliverPeerFilteredBlocks(t *testing.T) {
+	deliverPeer := startMockDeliverPeer(t)
+	defer deliverPeer.Stop()
+
+	eventClient := connectDeliverClient(t, deliverPeer, seek.FromBlock, 0)
+	defer eventClient.Close()
+
+	reg, eventch, err := eventClient.RegisterChaincodeEvent(ccID, eventName)
+	if err != nil {
+		t.Fatalf("error registering for chaincode events: %s", err)
+	}
+	defer eventClient.Unregister(reg)
+
+	deliverPeer.AddChaincodeEvent("txid1", ccID, eventName, []byte("payload1"))
+	event := receiveCCEvent(t, eventch)
+	if event.TxID != "txid1" {
+		t.Fatalf("expecting TxID [txid1] but got [%s]", event.TxID)
+	}
+	if len(event.Payload) != 0 {
+		t.Fatalf("expecting no payload in filtered block event but got [%s]", event.Payload)
+	}
+
+	deliverPeer.AddChaincodeEvent("txid2", ccID, eventName, []byte("payload2"))
+	event = receiveCCEvent(t, eventch)
+	if event.TxID != "txid2" || event.BlockNumber != 1 {
+		t.Fatalf("expecting TxID [txid2] in block 1 but got [%s] in block %d", event.TxID, event.BlockNumber)
+	}
+}
+
+func TestMockDeliverPeerBlocks(t *testing.T) {
+	deliverPeer := startMockDeliverPeer(t)
+	defer deliverPeer.Stop()
+
+	eventClient := connectDeliverClient(t, deliverPeer, seek.Oldest, 0, deliverclient.WithBlockEvents())
+	defer eventClient.Close()
+
+	reg, eventch, err := eventClient.RegisterChaincodeEvent(ccID, eventName)
+	if err != nil {
+		t.Fatalf("error registering for chaincode events: %s", err)
+	}
+	defer eventClient.Unregister(reg)
+
+	deliverPeer.AddChaincodeEvent("txid1", ccID, eventName, []byte("payload1"))
+	event := receiveCCEvent(t, eventch)
+	if string(event.Payload) != "payload1" {
+		t.Fatalf("expecting payload [payload1] but got [%s]", event.Payload)
+	}
+
+	seeks := deliverPeer.SeekRequests()
+	if len(seeks) != 1 || seeks[0].Start.GetOldest() == nil {
+		t.Fatalf("expecting one seek request from the oldest block but got %v", seeks)
+	}
+}
+
+func TestMockDeliverPeerSeekStatus(t *testing.T) {
+	deliverPeer := startMockDeliverPeer(t)
+	defer deliverPeer.Stop()
+
+	deliverPeer.SetSeekStatus(DeliverStream, cb.Status_FORBIDDEN)
+
+	eventClient, err := deliverclient.New(
+		fabmocks.NewMockContext(fabmocks.NewMockUser("user1")), testChannelID,
+		clientmocks.NewDiscoveryService(fabmocks.NewMockPeer("peer1", deliverPeer.URL())),
+		deliverclient.WithBlockEvents(),
+		client.WithMaxConnectAttempts(1),
+		client.WithResponseTimeout(3*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("error creating deliver client: %s", err)
+	}
+	defer eventClient.Close()
+
+	if err := eventClient.Connect(); err == nil {
+		t.Fatalf("expecting error connecting with a forbidden seek but got none")
+	}
+
+	// Filtered blocks are still permitted
+	connectDeliverClient(t, deliverPeer, seek.Newest, 0).Close()
+}
+
+func TestMockDeliverPeerDisconnect(t *testing.T) {
+	deliverPeer := startMockDeliverPeer(t)
+	defer deliverPeer.Stop()
+
+	connectch := make(chan *fab.ConnectionEvent, 10)
+	eventClient := connectDeliverClient(t, deliverPeer, seek.Oldest, 0,
+		client.WithConnectionEvent(connectch),
+		client.WithReconnect(true),
+		client.WithReconnectInitialDelay(0),
+	)
+	defer eventClient.Close()
+	waitForConnectionEvent(t, connectch, true)
+
+	reg, eventch, err := eventClient.RegisterFilteredBlockEvent()
+	if err != nil {
+		t.Fatalf("error registering for filtered block events: %s", err)
+	}
+	defer eventClient.Unregister(reg)
+
+	deliverPeer.AddTransactions(servicemocks.NewTransaction("txid1", pb.TxValidationCode_VALID, cb.HeaderType_ENDORSER_TRANSACTION))
+	receiveFilteredBlock(t, eventch, 0)
+
+	deliverPeer.Disconnect(errors.New("simulated disconnect"))
+	waitForConnectionEvent(t, connectch, false)
+	waitForConnectionEvent(t, connectch, true)
+
+	// The client resumes from the block after the last one received
+	seeks := deliverPeer.SeekRequests()
+	if len(seeks) != 2 || seeks[1].Start.GetSpecified().GetNumber() != 1 {
+		t.Fatalf("expecting reconnect to seek from block 1 but got %v", seeks)
+	}
+
+	deliverPeer.AddTransactions(servicemocks.NewTransaction("txid2", pb.TxValidationCode_VALID, cb.HeaderType_ENDORSER_TRANSACTION))
+	receiveFilteredBlock(t, eventch, 1)
+}
+
+func TestMockDeliverPeerFailIfNotReady(t *testing.T) {
+	deliverPeer := NewMockDeliverPeer(testChannelID)
+	deliverPeer.AddTransactions()
+
+	seekInfo := &ab.SeekInfo{
+		Start:    &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}},
+		Stop:     &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}},
+		Behavior: ab.SeekInfo_FAIL_IF_NOT_READY,
+	}
+	start, stop, ok := seekRange(seekInfo, deliverPeer.Height())
+	if !ok || start != 1 || stop != 1 {
+		t.Fatalf("unexpected seek range [%d, %d]", start, stop)
+	}
+
+	entry, err := deliverPeer.waitForBlock(nil, nil, 1, seekInfo.Behavior)
+	if err != nil || entry != nil {
+		t.Fatalf("expecting no block and no error but got %v, %v", entry, err)
+	}
+
+	seekInfo.Stop = &ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}}
+	if _, _, ok := seekRange(seekInfo, deliverPeer.Height()); ok {
+		t.Fatalf("expecting invalid seek range when stop is before start")
+	}
+}
+
+func TestMockDeliverPeerSeekAck(t *testing.T) {
+	deliverPeer := NewMockDeliverPeer(testChannelID)
+	deliverPeer.AddTransactions()
+	if err := deliverPeer.Start("localhost:0"); err != nil {
+		t.Fatalf("error starting mock deliver peer: %s", err)
+	}
+	defer deliverPeer.Stop()
+
+	// As done by Fabric peers, the only status is sent after the stop block
+	checkDeliverResponses(t, deliverPeer, "block", "SUCCESS")
+
+	deliverPeer.SetSeekAck(true)
+	checkDeliverResponses(t, deliverPeer, "SUCCESS", "block", "SUCCESS")
+}
+
+// checkDeliverResponses seeks block 0 on the filtered block stream and checks the
+// types of the responses ("block" or the name of the status)
+func checkDeliverResponses(t *testing.T, deliverPeer *MockDeliverPeer, expected ...string) {
+	conn, err := grpc.Dial(deliverPeer.Address(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("error dialing mock deliver peer: %s", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := pb.NewDeliverClient(conn).DeliverFiltered(ctx)
+	if err != nil {
+		t.Fatalf("error opening deliver stream: %s", err)
+	}
+
+	seekInfo := &ab.SeekInfo{
+		Start:    &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 0}}},
+		Stop:     &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 0}}},
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}
+	if err := stream.Send(newSeekEnvelope(t, seekInfo)); err != nil {
+		t.Fatalf("error sending seek request: %s", err)
+	}
+
+	for _, exp := range expected {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("error receiving deliver response: %s", err)
+		}
+		actual := "block"
+		if resp.GetFilteredBlock() == nil {
+			actual = resp.GetStatus().String()
+		}
+		if actual != exp {
+			t.Fatalf("expecting responses %v but got %s instead of %s", expected, actual, exp)
+		}
+	}
+}
+
+func newSeekEnvelope(t *testing.T, seekInfo *ab.SeekInfo) *cb.Envelope {
+	chdr, err := proto.Marshal(&cb.ChannelHeader{ChannelId: testChannelID, Type: int32(cb.HeaderType_DELIVER_SEEK_INFO)})
+	if err != nil {
+		t.Fatalf("error marshalling channel header: %s", err)
+	}
+	data, err := proto.Marshal(seekInfo)
+	if err != nil {
+		t.Fatalf("error marshalling seek info: %s", err)
+	}
+	payload, err := proto.Marshal(&cb.Payload{Header: &cb.Header{ChannelHeader: chdr}, Data: data})
+	if err != nil {
+		t.Fatalf("error marshalling payload: %s", err)
+	}
+	return &cb.Envelope{Payload: payload}
+}
+
+// startMockDeliverPeer starts a peer that acknowledges seek requests, as required by the SDK's deliver client
+func startMockDeliverPeer(t *testing.T) *MockDeliverPeer {
+	deliverPeer := NewMockDeliverPeer(testChannelID)
+	deliverPeer.SetSeekAck(true)
+	if err := deliverPeer.Start("localhost:0"); err != nil {
+		t.Fatalf("error starting mock deliver peer: %s", err)
+	}
+	return deliverPeer
+}
+
+func connectDeliverClient(t *testing.T, deliverPeer *MockDeliverPeer, seekType seek.Type, fromBlock uint64, opts ...options.Opt) *deliverclient.Client {
+	opts = append([]options.Opt{
+		deliverclient.WithSeekType(seekType),
+		deliverclient.WithBlockNum(fromBlock),
+		client.WithResponseTimeout(3 * time.Second),
+	}, opts...)
+
+	eventClient, err := deliverclient.New(
+		fabmocks.NewMockContext(fabmocks.NewMockUser("user1")), testChannelID,
+		clientmocks.NewDiscoveryService(fabmocks.NewMockPeer("peer1", deliverPeer.URL())),
+		opts...,
+	)
+	if err != nil {
+		t.Fatalf("error creating deliver client: %s", err)
+	}
+	if err := eventClient.Connect(); err != nil {
+		t.Fatalf("error connecting to mock deliver peer: %s", err)
+	}
+	return eventClient
+}
+
+func receiveCCEvent(t *testing.T, eventch <-chan *fab.CCEvent) *fab.CCEvent {
+	select {
+	case event, ok := <-eventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for chaincode event")
+	}
+	return nil
+}
+
+func receiveFilteredBlock(t *testing.T, eventch <-chan *fab.FilteredBlockEvent, expectedNumber uint64) {
+	select {
+	case event, ok := <-eventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+		if event.FilteredBlock.Number != expectedNumber {
+			t.Fatalf("expecting block %d but got %d", expectedNumber, event.FilteredBlock.Number)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for filtered block %d", expectedNumber)
+	}
+}
+
+func waitForConnectionEvent(t *testing.T, connectch <-chan *fab.ConnectionEvent, connected bool) {
+	select {
+	case event := <-connectch:
+		if event.Connected != connected {
+			t.Fatalf("expecting connected=%t but got connected=%t", connected, event.Connected)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for connection event connected=%t", connected)
+	}
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
 )
 
-var logger = logging.NewLogger("eventservice/blockfilter")
+var logger = logging.NewLogger("fabric_sdk_go/events/blockfilter")
 
 // New returns a block filter that filters out blocks that
 // don't contain envelopes of the given type(s)
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy determines what is done with an event when a consumer's event channel is full
+type OverflowPolicy int
+
+const (
+	// OverflowTimeout waits for the dispatcher's event consumer timeout (see WithEventConsumerTimeout)
+	// and drops the event if the channel is still full. This is the default.
+	OverflowTimeout OverflowPolicy = iota
+	// OverflowBlock waits until the consumer has room for the event. Note that this
+	// holds up delivery to all other consumers.
+	OverflowBlock
+	// OverflowDropOldest discards the oldest event in the channel to make room for the new one
+	// (see Consumer.DiscardFrom)
+	OverflowDropOldest
+	// OverflowDropNewest drops the new event
+	OverflowDropNewest
+	// OverflowDisconnect unregisters the consumer and closes its event channel
+	OverflowDisconnect
+)
+
+// ConsumerPolicy determines how events are buffered for a registration
+type ConsumerPolicy struct {
+	// BufferSize is the size of the registration's event channel. If 0, the
+	// event service's buffer size is used.
+	BufferSize uint
+	// Overflow is the action taken when the event channel is full
+	Overflow OverflowPolicy
+	// NotifySkipped, if true, sets the Skipped field of the first event delivered
+	// after events were dropped to the number of events that were dropped, so that
+	// the consumer knows to resync.
+	NotifySkipped bool
+}
+
+// Consumer holds the policy and delivery statistics of a registration
+type Consumer struct {
+	Policy ConsumerPolicy
+
+	dropped uint64
+	skipped uint64
+	discard func() bool
+}
+
+// DroppedEvents returns the number of events that were not delivered to the consumer
+// because its event channel was full
+func (c *Consumer) DroppedEvents() uint64 {
+	return atomic.LoadUint64(&c.dropped)
+}
+
+// DiscardFrom sets the channel from which the oldest event is discarded when the
+// overflow policy is OverflowDropOldest. eventch must be the registration's event
+// channel and must allow receiving. If it isn't set then the new event is dropped instead.
+func (c *Consumer) DiscardFrom(eventch interface{}) {
+	ch := reflect.ValueOf(eventch)
+	c.discard = func() bool {
+		_, ok := ch.TryRecv()
+		return ok
+	}
+}
+
+func (c *Consumer) drop() {
+	atomic.AddUint64(&c.dropped, 1)
+	c.skipped++
+}
+
+func (c *Consumer) skippedCount() uint64 {
+	if !c.Policy.NotifySkipped {
+		return 0
+	}
+	return c.skipped
+}
+
+// sendFunc sends an event with the given skipped count. If timeout < 0 then it
+// returns immediately if the channel is full; if 0 then it blocks until the event is sent;
+// otherwise it waits up to the given timeout. False is returned if the event wasn't sent.
+type sendFunc func(skipped uint64, timeout time.Duration) bool
+
+// deliver sends an event to the consumer according to its overflow policy.
+// False is returned if the consumer is to be disconnected.
+func (ed *Dispatcher) deliver(c *Consumer, send sendFunc) bool {
+	var sent bool
+	switch c.Policy.Overflow {
+	case OverflowBlock:
+		sent = send(c.skippedCount(), 0)
+	case OverflowDropNewest, OverflowDisconnect:
+		sent = send(c.skippedCount(), -1)
+	case OverflowDropOldest:
+		sent = send(c.skippedCount(), -1)
+		if !sent && c.discard != nil && c.discard() {
+			c.drop()
+			sent = send(c.skippedCount(), -1)
+		}
+	default:
+		sent = send(c.skippedCount(), ed.eventConsumerTimeout)
+	}
+
+	if sent {
+		c.skipped = 0
+		return true
+	}
+
+	c.drop()
+	ed.logger.Warnf("Event channel is full. Dropped event - total dropped: %d", c.DroppedEvents())
+	return c.Policy.Overflow != OverflowDisconnect
+}
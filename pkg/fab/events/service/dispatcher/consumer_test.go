@@ -0,0 +1,178 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/blockfilter"
+	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
+)
+
+const policyChannelID = "testchannel"
+
+func TestConsumerPolicyDropNewest(t *testing.T) {
+	dispatcherEventch, producer, reg, eventch := registerWithPolicy(t, ConsumerPolicy{Overflow: OverflowDropNewest, NotifySkipped: true})
+
+	for i := 0; i < 4; i++ {
+		dispatcherEventch <- producer.NewBlock(policyChannelID)
+	}
+	flush(t, dispatcherEventch)
+
+	checkBlockEvent(t, eventch, 0, 0)
+	checkBlockEvent(t, eventch, 1, 0)
+	if reg.DroppedEvents() != 2 {
+		t.Fatalf("Expected 2 dropped events, got %d", reg.DroppedEvents())
+	}
+
+	dispatcherEventch <- producer.NewBlock(policyChannelID)
+	checkBlockEvent(t, eventch, 4, 2)
+}
+
+func TestConsumerPolicyDropOldest(t *testing.T) {
+	dispatcherEventch, producer, reg, eventch := registerWithPolicy(t, ConsumerPolicy{Overflow: OverflowDropOldest, NotifySkipped: true})
+
+	for i := 0; i < 4; i++ {
+		dispatcherEventch <- producer.NewBlock(policyChannelID)
+	}
+	flush(t, dispatcherEventch)
+
+	checkBlockEvent(t, eventch, 2, 1)
+	checkBlockEvent(t, eventch, 3, 1)
+	if reg.DroppedEvents() != 2 {
+		t.Fatalf("Expected 2 dropped events, got %d", reg.DroppedEvents())
+	}
+
+	dispatcherEventch <- producer.NewBlock(policyChannelID)
+	checkBlockEvent(t, eventch, 4, 0)
+}
+
+func TestConsumerPolicyDisconnect(t *testing.T) {
+	dispatcherEventch, producer, reg, eventch := registerWithPolicy(t, ConsumerPolicy{Overflow: OverflowDisconnect})
+
+	for i := 0; i < 3; i++ {
+		dispatcherEventch <- producer.NewBlock(policyChannelID)
+	}
+	flush(t, dispatcherEventch)
+
+	checkBlockEvent(t, eventch, 0, 0)
+	checkBlockEvent(t, eventch, 1, 0)
+	select {
+	case _, ok := <-eventch:
+		if ok {
+			t.Fatal("Expected event channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for event channel to be closed")
+	}
+	if reg.DroppedEvents() != 1 {
+		t.Fatalf("Expected 1 dropped event, got %d", reg.DroppedEvents())
+	}
+}
+
+func TestConsumerPolicyDefault(t *testing.T) {
+	dispatcherEventch, producer, reg, eventch := registerWithPolicy(t, ConsumerPolicy{})
+
+	for i := 0; i < 3; i++ {
+		dispatcherEventch <- producer.NewBlock(policyChannelID)
+	}
+	flush(t, dispatcherEventch)
+
+	checkBlockEvent(t, eventch, 0, 0)
+	checkBlockEvent(t, eventch, 1, 0)
+	if reg.DroppedEvents() != 1 {
+		t.Fatalf("Expected 1 dropped event, got %d", reg.DroppedEvents())
+	}
+
+	// Skipped is only reported if requested
+	dispatcherEventch <- producer.NewBlock(policyChannelID)
+	checkBlockEvent(t, eventch, 3, 0)
+}
+
+// registerWithPolicy registers for block events with an event channel of size 2
+func registerWithPolicy(t *testing.T, policy ConsumerPolicy) (chan<- interface{}, *servicemocks.BlockProducer, *BlockReg, chan *fab.BlockEvent) {
+	dispatcher := New(WithEventConsumerTimeout(-1))
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	eventch := make(chan *fab.BlockEvent, 2)
+	regch := make(chan fab.Registration)
+	errch := make(chan error)
+
+	event := NewRegisterBlockEvent(blockfilter.AcceptAny, eventch, regch, errch)
+	event.Reg.Policy = policy
+	event.Reg.DiscardFrom(eventch)
+	dispatcherEventch <- event
+
+	select {
+	case <-regch:
+	case err := <-errch:
+		t.Fatalf("Error registering for block events: %s", err)
+	}
+
+	return dispatcherEventch, servicemocks.NewBlockProducer(), event.Reg, eventch
+}
+
+func TestSend(t *testing.T) {
+	eventch := make(chan *fab.TxStatusEvent, 1)
+	var sendch chan<- *fab.TxStatusEvent = eventch
+
+	if !send(sendch, &fab.TxStatusEvent{TxID: "txid1"}, -1) {
+		t.Fatal("expected event to be sent to channel with room")
+	}
+	if send(sendch, &fab.TxStatusEvent{TxID: "txid2"}, -1) {
+		t.Fatal("expected event not to be sent to full channel without waiting")
+	}
+	if send(sendch, &fab.TxStatusEvent{TxID: "txid2"}, 10*time.Millisecond) {
+		t.Fatal("expected event not to be sent to full channel after timeout")
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		<-eventch
+	}()
+	if !send(sendch, &fab.TxStatusEvent{TxID: "txid2"}, 0) {
+		t.Fatal("expected blocking send to succeed once the channel has room")
+	}
+	if event := <-eventch; event.TxID != "txid2" {
+		t.Fatalf("expected event for txid2 but got %s", event.TxID)
+	}
+}
+
+// flush waits for the dispatcher to process all previously submitted events
+func flush(t *testing.T, dispatcherEventch chan<- interface{}) {
+	regch := make(chan fab.Registration)
+	errch := make(chan error)
+	dispatcherEventch <- NewRegisterFilteredBlockEvent(make(chan *fab.FilteredBlockEvent, 10), regch, errch)
+	select {
+	case <-regch:
+	case err := <-errch:
+		t.Fatalf("Error registering for filtered block events: %s", err)
+	}
+}
+
+func checkBlockEvent(t *testing.T, eventch <-chan *fab.BlockEvent, expectedBlockNum, expectedSkipped uint64) {
+	select {
+	case event, ok := <-eventch:
+		if !ok {
+			t.Fatal("unexpected closed channel")
+		}
+		if event.Block.Header.Number != expectedBlockNum || event.Skipped != expectedSkipped {
+			t.Fatalf("Expected block #%d with %d skipped but got block #%d with %d skipped", expectedBlockNum, expectedSkipped, event.Block.Header.Number, event.Skipped)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for block #%d", expectedBlockNum)
+	}
+}
@@ -7,24 +7,24 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"fmt"
 	"math"
 	"reflect"
 	"regexp"
 	"sync/atomic"
 	"time"
 
-	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/blockdecoder"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
 	ledgerutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
 	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
-	utils "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/service/dispatcher")
 
 const (
 	dispatcherStateInitial = iota
@@ -50,18 +50,18 @@ type Dispatcher struct {
 	filteredBlockRegistrations []*FilteredBlockReg
 	txRegistrations            map[string]*TxStatusReg
 	ccRegistrations            map[string]*ChaincodeReg
+	ccRegistrationSeq          uint64
 	state                      int32
 	lastBlockNum               uint64
+	logger                     *logging.Logger
 }
 
 // New creates a new Dispatcher.
 func New(opts ...options.Opt) *Dispatcher {
-	logger.Debugf("Creating new dispatcher.")
-
 	params := defaultParams()
 	options.Apply(params, opts)
 
-	return &Dispatcher{
+	ed := &Dispatcher{
 		params:          *params,
 		handlers:        make(map[reflect.Type]Handler),
 		eventch:         make(chan interface{}, params.eventConsumerBufferSize),
@@ -69,7 +69,11 @@ func New(opts ...options.Opt) *Dispatcher {
 		ccRegistrations: make(map[string]*ChaincodeReg),
 		state:           dispatcherStateInitial,
 		lastBlockNum:    math.MaxUint64,
+		logger:          logger.ForProvider(params.loggerProvider),
 	}
+	ed.logger.Debugf("Creating new dispatcher.")
+
+	return ed
 }
 
 // RegisterHandlers registers all of the handlers by event type
@@ -104,26 +108,32 @@ func (ed *Dispatcher) Start() error {
 
 	go func() {
 		for {
-			logger.Debug("Listening for events...")
+			ed.logger.Debug("Listening for events...")
 			e, ok := <-ed.eventch
 			if !ok {
 				break
 			}
 
-			logger.Debugf("Received event: %v", reflect.TypeOf(e))
+			ed.logger.Debugf("Received event: %v", reflect.TypeOf(e))
 
-			if handler, ok := ed.handlers[reflect.TypeOf(e)]; ok {
-				logger.Debugf("Dispatching event: %v", reflect.TypeOf(e))
-				handler(e)
-			} else {
-				logger.Errorf("Handler not found for: %s", reflect.TypeOf(e))
-			}
+			ed.HandleEvent(e)
 		}
-		logger.Debug("Exiting event dispatcher")
+		ed.logger.Debug("Exiting event dispatcher")
 	}()
 	return nil
 }
 
+// HandleEvent invokes the handler registered for the type of the given event.
+// It must only be called from within the dispatcher's Go routine, i.e. from another handler.
+func (ed *Dispatcher) HandleEvent(e Event) {
+	if handler, ok := ed.handlers[reflect.TypeOf(e)]; ok {
+		ed.logger.Debugf("Dispatching event: %v", reflect.TypeOf(e))
+		handler(e)
+	} else {
+		ed.logger.Errorf("Handler not found for: %s", reflect.TypeOf(e))
+	}
+}
+
 // LastBlockNum returns the block number of the last block for which an event was received.
 func (ed *Dispatcher) LastBlockNum() uint64 {
 	return atomic.LoadUint64(&ed.lastBlockNum)
@@ -164,7 +174,7 @@ func (ed *Dispatcher) clearFilteredBlockRegistrations() {
 // The listener will receive a 'closed' event to indicate that the channel has been closed.
 func (ed *Dispatcher) clearTxRegistrations() {
 	for _, reg := range ed.txRegistrations {
-		logger.Debugf("Closing TX registration event channel for TxID [%s].", reg.TxID)
+		ed.logger.Debugf("Closing TX registration event channel for TxID [%s].", reg.TxID)
 		close(reg.Eventch)
 	}
 	ed.txRegistrations = make(map[string]*TxStatusReg)
@@ -174,7 +184,7 @@ func (ed *Dispatcher) clearTxRegistrations() {
 // The listener will receive a 'closed' event to indicate that the channel has been closed.
 func (ed *Dispatcher) clearChaincodeRegistrations() {
 	for _, reg := range ed.ccRegistrations {
-		logger.Debugf("Closing chaincode registration event channel for CC ID [%s] and event filter [%s].", reg.ChaincodeID, reg.EventFilter)
+		ed.logger.Debugf("Closing chaincode registration event channel for CC ID [%s] and event filter [%s].", reg.ChaincodeID, reg.EventFilter)
 		close(reg.Eventch)
 	}
 	ed.ccRegistrations = make(map[string]*ChaincodeReg)
@@ -185,9 +195,9 @@ func (ed *Dispatcher) clearChaincodeRegistrations() {
 func (ed *Dispatcher) HandleStopEvent(e Event) {
 	event := e.(*StopEvent)
 
-	logger.Debugf("Stopping dispatcher...")
+	ed.logger.Debugf("Stopping dispatcher...")
 	if !atomic.CompareAndSwapInt32(&ed.state, dispatcherStateStarted, dispatcherStateStopped) {
-		logger.Warn("Cannot stop event dispatcher since it's already stopped.")
+		ed.logger.Warn("Cannot stop event dispatcher since it's already stopped.")
 		return
 	}
 
@@ -198,7 +208,7 @@ func (ed *Dispatcher) HandleStopEvent(e Event) {
 	ed.clearTxRegistrations()
 	ed.clearChaincodeRegistrations()
 
-	logger.Debugf("Closing dispatcher event channel.")
+	ed.logger.Debugf("Closing dispatcher event channel.")
 	close(ed.eventch)
 
 	event.RegCh <- nil
@@ -220,7 +230,18 @@ func (ed *Dispatcher) handleRegisterFilteredBlockEvent(e Event) {
 func (ed *Dispatcher) handleRegisterCCEvent(e Event) {
 	event := e.(*RegisterChaincodeEvent)
 
-	key := getCCKey(event.Reg.ChaincodeID, event.Reg.EventFilter)
+	if len(event.Reg.Filter.ChaincodeIDs) == 0 {
+		event.ErrCh <- errors.New("at least one chaincode ID is required")
+		return
+	}
+
+	// Registrations with a payload filter can't be compared so they are never considered duplicates
+	key := getCCKey(event.Reg.Filter)
+	if event.Reg.Filter.PayloadFilter != nil {
+		ed.ccRegistrationSeq++
+		key = fmt.Sprintf("%s#%d", key, ed.ccRegistrationSeq)
+	}
+
 	if _, exists := ed.ccRegistrations[key]; exists {
 		event.ErrCh <- errors.Errorf("registration already exists for chaincode [%s] and event [%s]", event.Reg.ChaincodeID, event.Reg.EventFilter)
 	} else {
@@ -229,6 +250,7 @@ func (ed *Dispatcher) handleRegisterCCEvent(e Event) {
 			event.ErrCh <- errors.Wrapf(err, "error compiling regular expression for event filter [%s]", event.Reg.EventFilter)
 		} else {
 			event.Reg.EventRegExp = regExp
+			event.Reg.key = key
 			ed.ccRegistrations[key] = event.Reg
 			event.RegCh <- event.Reg
 		}
@@ -263,7 +285,7 @@ func (ed *Dispatcher) handleUnregisterEvent(e Event) {
 		err = errors.Errorf("Unsupported registration type: %v", reflect.TypeOf(registration))
 	}
 	if err != nil {
-		logger.Warnf("Error in unregister: %s", err)
+		ed.logger.Warnf("Error in unregister: %s", err)
 	}
 }
 
@@ -277,27 +299,27 @@ func (ed *Dispatcher) handleFilteredBlockEvent(e Event) {
 
 // HandleBlock handles a block event
 func (ed *Dispatcher) HandleBlock(block *cb.Block) {
-	logger.Debugf("Handling block event - Block #%d", block.Header.Number)
+	ed.logger.Debugf("Handling block event - Block #%d", block.Header.Number)
 
 	if err := ed.updateLastBlockNum(block.Header.Number); err != nil {
-		logger.Error(err.Error())
+		ed.logger.Error(err.Error())
 		return
 	}
 
 	ed.publishBlockEvents(block)
-	ed.publishFilteredBlockEvents(toFilteredBlock(block))
+	ed.publishFilteredBlockEvents(ed.toFilteredBlock(block))
 }
 
 // HandleFilteredBlock handles a filtered block event
 func (ed *Dispatcher) HandleFilteredBlock(fblock *pb.FilteredBlock) {
-	logger.Debugf("Handling filtered block event - Block #%d", fblock.Number)
+	ed.logger.Debugf("Handling filtered block event - Block #%d", fblock.Number)
 
 	if err := ed.updateLastBlockNum(fblock.Number); err != nil {
-		logger.Error(err.Error())
+		ed.logger.Error(err.Error())
 		return
 	}
 
-	logger.Debugf("Publishing filtered block event...")
+	ed.logger.Debugf("Publishing filtered block event...")
 	ed.publishFilteredBlockEvents(fblock)
 }
 
@@ -328,13 +350,13 @@ func (ed *Dispatcher) unregisterFilteredBlockEvents(registration *FilteredBlockR
 }
 
 func (ed *Dispatcher) unregisterCCEvents(registration *ChaincodeReg) error {
-	key := getCCKey(registration.ChaincodeID, registration.EventFilter)
+	key := registration.key
 	reg, ok := ed.ccRegistrations[key]
-	if !ok {
+	if !ok || reg != registration {
 		return errors.New("the provided registration is invalid")
 	}
 
-	logger.Debugf("Unregistering CC event for CC ID [%s] and event filter [%s]...", registration.ChaincodeID, registration.EventFilter)
+	ed.logger.Debugf("Unregistering CC event for CC ID [%s] and event filter [%s]...", registration.ChaincodeID, registration.EventFilter)
 	close(reg.Eventch)
 	delete(ed.ccRegistrations, key)
 	return nil
@@ -346,157 +368,159 @@ func (ed *Dispatcher) unregisterTXEvents(registration *TxStatusReg) error {
 		return errors.New("the provided registration is invalid")
 	}
 
-	logger.Debugf("Unregistering Tx Status event for TxID [%s]...", registration.TxID)
+	ed.logger.Debugf("Unregistering Tx Status event for TxID [%s]...", registration.TxID)
 	close(reg.Eventch)
 	delete(ed.txRegistrations, registration.TxID)
 	return nil
 }
 
 func (ed *Dispatcher) publishBlockEvents(block *cb.Block) {
+	var disconnected []*BlockReg
 	for _, reg := range ed.blockRegistrations {
 		if !reg.Filter(block) {
-			logger.Debugf("Not sending block event for block #%d since it was filtered out.", block.Header.Number)
+			ed.logger.Debugf("Not sending block event for block #%d since it was filtered out.", block.Header.Number)
 			continue
 		}
 
-		if ed.eventConsumerTimeout < 0 {
-			select {
-			case reg.Eventch <- &fab.BlockEvent{Block: block}:
-			default:
-				logger.Warnf("Unable to send to block event channel.")
-			}
-		} else if ed.eventConsumerTimeout == 0 {
-			reg.Eventch <- &fab.BlockEvent{Block: block}
-		} else {
-			select {
-			case reg.Eventch <- &fab.BlockEvent{Block: block}:
-			case <-time.After(ed.eventConsumerTimeout):
-				logger.Warnf("Timed out sending block event.")
-			}
+		if !ed.deliver(&reg.Consumer, func(skipped uint64, timeout time.Duration) bool {
+			event := &fab.BlockEvent{Block: block}
+			event.Skipped = skipped
+			return send(reg.Eventch, event, timeout)
+		}) {
+			disconnected = append(disconnected, reg)
+		}
+	}
+
+	for _, reg := range disconnected {
+		ed.logger.Warnf("Disconnecting block event consumer that is not keeping up.")
+		if err := ed.unregisterBlockEvents(reg); err != nil {
+			ed.logger.Warnf("Error disconnecting block event consumer: %s", err)
 		}
 	}
 }
 
 func (ed *Dispatcher) publishFilteredBlockEvents(fblock *pb.FilteredBlock) {
 	if fblock == nil {
-		logger.Warnf("Filtered block is nil. Event will not be published")
+		ed.logger.Warnf("Filtered block is nil. Event will not be published")
 		return
 	}
 
-	logger.Debugf("Publishing filtered block event: %#v", fblock)
+	ed.logger.Debugf("Publishing filtered block event: %#v", fblock)
 
+	var disconnected []*FilteredBlockReg
 	for _, reg := range ed.filteredBlockRegistrations {
-		if ed.eventConsumerTimeout < 0 {
-			select {
-			case reg.Eventch <- &fab.FilteredBlockEvent{FilteredBlock: fblock}:
-			default:
-				logger.Warnf("Unable to send to filtered block event channel.")
-			}
-		} else if ed.eventConsumerTimeout == 0 {
-			reg.Eventch <- &fab.FilteredBlockEvent{FilteredBlock: fblock}
-		} else {
-			select {
-			case reg.Eventch <- &fab.FilteredBlockEvent{FilteredBlock: fblock}:
-			case <-time.After(ed.eventConsumerTimeout):
-				logger.Warnf("Timed out sending filtered block event.")
-			}
+		if !ed.deliver(&reg.Consumer, func(skipped uint64, timeout time.Duration) bool {
+			event := &fab.FilteredBlockEvent{FilteredBlock: fblock}
+			event.Skipped = skipped
+			return send(reg.Eventch, event, timeout)
+		}) {
+			disconnected = append(disconnected, reg)
+		}
+	}
+
+	for _, reg := range disconnected {
+		ed.logger.Warnf("Disconnecting filtered block event consumer that is not keeping up.")
+		if err := ed.unregisterFilteredBlockEvents(reg); err != nil {
+			ed.logger.Warnf("Error disconnecting filtered block event consumer: %s", err)
 		}
 	}
 
 	for _, tx := range fblock.FilteredTx {
 		ed.publishTxStatusEvents(tx)
 
-		// Only send a chaincode event if the transaction has committed
-		if tx.TxValidationCode == pb.TxValidationCode_VALID {
-			txActions := tx.GetTransactionActions()
-			if txActions == nil {
-				continue
-			}
-			for _, action := range txActions.ChaincodeActions {
-				if action.CcEvent != nil {
-					ed.publishCCEvents(action.CcEvent)
-				}
+		// Registrations decide whether they want the chaincode events of invalid transactions
+		txActions := tx.GetTransactionActions()
+		if txActions == nil {
+			continue
+		}
+		for _, action := range txActions.ChaincodeActions {
+			if action.CcEvent != nil {
+				ed.publishCCEvents(action.CcEvent, tx.TxValidationCode, fblock.Number)
 			}
 		}
 	}
 }
 
 func (ed *Dispatcher) publishTxStatusEvents(tx *pb.FilteredTransaction) {
-	logger.Debugf("Publishing Tx Status event for TxID [%s]...", tx.Txid)
+	ed.logger.Debugf("Publishing Tx Status event for TxID [%s]...", tx.Txid)
 	if reg, ok := ed.txRegistrations[tx.Txid]; ok {
-		logger.Debugf("Sending Tx Status event for TxID [%s] to registrant...", tx.Txid)
-
-		if ed.eventConsumerTimeout < 0 {
-			select {
-			case reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode):
-			default:
-				logger.Warnf("Unable to send to Tx Status event channel.")
-			}
-		} else if ed.eventConsumerTimeout == 0 {
-			reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode)
-		} else {
-			select {
-			case reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode):
-			case <-time.After(ed.eventConsumerTimeout):
-				logger.Warnf("Timed out sending Tx Status event.")
+		ed.logger.Debugf("Sending Tx Status event for TxID [%s] to registrant...", tx.Txid)
+
+		if !ed.deliver(&reg.Consumer, func(skipped uint64, timeout time.Duration) bool {
+			event := NewTxStatusEvent(tx.Txid, tx.TxValidationCode)
+			event.Skipped = skipped
+			return send(reg.Eventch, event, timeout)
+		}) {
+			ed.logger.Warnf("Disconnecting Tx Status event consumer for TxID [%s] that is not keeping up.", tx.Txid)
+			if err := ed.unregisterTXEvents(reg); err != nil {
+				ed.logger.Warnf("Error disconnecting Tx Status event consumer: %s", err)
 			}
 		}
 	}
 }
 
-func (ed *Dispatcher) publishCCEvents(ccEvent *pb.ChaincodeEvent) {
+func (ed *Dispatcher) publishCCEvents(ccEvent *pb.ChaincodeEvent, txValidationCode pb.TxValidationCode, blockNum uint64) {
+	var disconnected []*ChaincodeReg
 	for _, reg := range ed.ccRegistrations {
-		logger.Debugf("Matching CCEvent[%s,%s] against Reg[%s,%s] ...", ccEvent.ChaincodeId, ccEvent.EventName, reg.ChaincodeID, reg.EventFilter)
-		if reg.ChaincodeID == ccEvent.ChaincodeId && reg.EventRegExp.MatchString(ccEvent.EventName) {
-			logger.Debugf("... matched CCEvent[%s,%s] against Reg[%s,%s]", ccEvent.ChaincodeId, ccEvent.EventName, reg.ChaincodeID, reg.EventFilter)
-
-			if ed.eventConsumerTimeout < 0 {
-				select {
-				case reg.Eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId):
-				default:
-					logger.Warnf("Unable to send to CC event channel.")
-				}
-			} else if ed.eventConsumerTimeout == 0 {
-				reg.Eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId)
-			} else {
-				select {
-				case reg.Eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId):
-				case <-time.After(ed.eventConsumerTimeout):
-					logger.Warnf("Timed out sending CC event.")
-				}
+		ed.logger.Debugf("Matching CCEvent[%s,%s] against Reg[%s,%s] ...", ccEvent.ChaincodeId, ccEvent.EventName, reg.ChaincodeID, reg.EventFilter)
+		if reg.matches(ccEvent, txValidationCode) {
+			ed.logger.Debugf("... matched CCEvent[%s,%s] against Reg[%s,%s]", ccEvent.ChaincodeId, ccEvent.EventName, reg.ChaincodeID, reg.EventFilter)
+
+			if !ed.deliver(&reg.Consumer, func(skipped uint64, timeout time.Duration) bool {
+				event := NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId)
+				event.Payload = ccEvent.Payload
+				event.BlockNumber = blockNum
+				event.TxValidationCode = txValidationCode
+				event.Skipped = skipped
+				return send(reg.Eventch, event, timeout)
+			}) {
+				disconnected = append(disconnected, reg)
 			}
 		}
 	}
+
+	for _, reg := range disconnected {
+		ed.logger.Warnf("Disconnecting CC event consumer for CC ID [%s] and event filter [%s] that is not keeping up.", reg.ChaincodeID, reg.EventFilter)
+		if err := ed.unregisterCCEvents(reg); err != nil {
+			ed.logger.Warnf("Error disconnecting CC event consumer: %s", err)
+		}
+	}
 }
 
 // RegisterHandler registers an event handler
 func (ed *Dispatcher) RegisterHandler(t interface{}, h Handler) {
 	htype := reflect.TypeOf(t)
 	if _, ok := ed.handlers[htype]; !ok {
-		logger.Debugf("Registering handler for %s on dispatcher %T", htype, ed)
+		ed.logger.Debugf("Registering handler for %s on dispatcher %T", htype, ed)
 		ed.handlers[htype] = h
 	} else {
-		logger.Debugf("Cannot register handler %s on dispatcher %T since it's already registered", htype, ed)
+		ed.logger.Debugf("Cannot register handler %s on dispatcher %T since it's already registered", htype, ed)
 	}
 }
 
-func getCCKey(ccID, eventFilter string) string {
-	return ccID + "/" + eventFilter
+// getCCKey returns the key of a chaincode registration. The chaincode IDs and event filter
+// are quoted so that IDs and filters containing separators can't produce the same key.
+func getCCKey(filter fab.ChaincodeEventFilter) string {
+	return fmt.Sprintf("%q/%q/%t", filter.ChaincodeIDs, filter.EventFilter, filter.IncludeInvalid)
 }
 
-func toFilteredBlock(block *cb.Block) *pb.FilteredBlock {
+func (ed *Dispatcher) toFilteredBlock(block *cb.Block) *pb.FilteredBlock {
 	var channelID string
 	var filteredTxs []*pb.FilteredTransaction
 	txFilter := ledgerutil.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
 
 	for i, data := range block.Data.Data {
-		filteredTx, chID, err := getFilteredTx(data, txFilter.Flag(i))
-		if err != nil {
-			logger.Warnf("error extracting Envelope from block: %v", err)
+		tx, err := blockdecoder.DecodeTransactionEvents(data)
+		if tx == nil {
+			ed.logger.Warnf("error decoding transaction from block: %v", err)
 			continue
 		}
-		channelID = chID
-		filteredTxs = append(filteredTxs, filteredTx)
+		if err != nil {
+			// Still publish the transaction so that its status is reported
+			ed.logger.Warnf("error decoding chaincode events of transaction [%s]: %v", tx.TxID, err)
+		}
+		channelID = tx.ChannelID
+		filteredTxs = append(filteredTxs, toFilteredTx(tx, txFilter.Flag(i)))
 	}
 
 	return &pb.FilteredBlock{
@@ -506,68 +530,21 @@ func toFilteredBlock(block *cb.Block) *pb.FilteredBlock {
 	}
 }
 
-func getFilteredTx(data []byte, txValidationCode pb.TxValidationCode) (*pb.FilteredTransaction, string, error) {
-	env, err := utils.GetEnvelopeFromBlock(data)
-	if err != nil {
-		return nil, "", errors.Wrap(err, "error extracting Envelope from block")
-	}
-	if env == nil {
-		return nil, "", errors.New("nil envelope")
-	}
-
-	payload, err := utils.GetPayload(env)
-	if err != nil {
-		return nil, "", errors.Wrap(err, "error extracting Payload from envelope")
-	}
-
-	channelHeaderBytes := payload.Header.ChannelHeader
-	channelHeader := &cb.ChannelHeader{}
-	if err := proto.Unmarshal(channelHeaderBytes, channelHeader); err != nil {
-		return nil, "", errors.Wrap(err, "error extracting ChannelHeader from payload")
-	}
-
+func toFilteredTx(tx *blockdecoder.Transaction, txValidationCode pb.TxValidationCode) *pb.FilteredTransaction {
 	filteredTx := &pb.FilteredTransaction{
-		Type:             cb.HeaderType(channelHeader.Type),
-		Txid:             channelHeader.TxId,
+		Type:             tx.Type,
+		Txid:             tx.TxID,
 		TxValidationCode: txValidationCode,
 	}
 
-	if cb.HeaderType(channelHeader.Type) == cb.HeaderType_ENDORSER_TRANSACTION {
-		actions, err := getFilteredTransactionActions(payload.Data)
-		if err != nil {
-			return nil, "", errors.Wrap(err, "error getting filtered transaction actions")
+	if tx.Type == cb.HeaderType_ENDORSER_TRANSACTION {
+		actions := &pb.FilteredTransactionActions{}
+		for _, action := range tx.Actions {
+			if action.Event != nil {
+				actions.ChaincodeActions = append(actions.ChaincodeActions, &pb.FilteredChaincodeAction{CcEvent: action.Event})
+			}
 		}
-		filteredTx.Data = actions
-	}
-	return filteredTx, channelHeader.ChannelId, nil
-}
-
-func getFilteredTransactionActions(data []byte) (*pb.FilteredTransaction_TransactionActions, error) {
-	actions := &pb.FilteredTransaction_TransactionActions{
-		TransactionActions: &pb.FilteredTransactionActions{},
-	}
-	tx, err := utils.GetTransaction(data)
-	if err != nil {
-		return nil, errors.Wrap(err, "error unmarshalling transaction payload")
-	}
-	chaincodeActionPayload, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
-	if err != nil {
-		return nil, errors.Wrap(err, "error unmarshalling chaincode action payload")
-	}
-	propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
-	if err != nil {
-		return nil, errors.Wrap(err, "error unmarshalling response payload")
-	}
-	ccAction, err := utils.GetChaincodeAction(propRespPayload.Extension)
-	if err != nil {
-		return nil, errors.Wrap(err, "error unmarshalling chaincode action")
-	}
-	ccEvent, err := utils.GetChaincodeEvents(ccAction.Events)
-	if err != nil {
-		return nil, errors.Wrap(err, "error getting chaincode events")
-	}
-	if ccEvent != nil {
-		actions.TransactionActions.ChaincodeActions = append(actions.TransactionActions.ChaincodeActions, &pb.FilteredChaincodeAction{CcEvent: ccEvent})
+		filteredTx.Data = &pb.FilteredTransaction_TransactionActions{TransactionActions: actions}
 	}
-	return actions, nil
+	return filteredTx
 }
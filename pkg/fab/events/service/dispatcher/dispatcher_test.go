@@ -10,12 +10,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/blockfilter"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/blockfilter/headertypefilter"
 	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
 	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
 )
 
 func TestInvalidUnregister(t *testing.T) {
@@ -425,6 +428,79 @@ func TestTxStatusEvents(t *testing.T) {
 	}
 }
 
+func TestTxStatusEventsForUndecodableTx(t *testing.T) {
+	channelID := "testchannel"
+	dispatcher := New()
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	txID1 := "1234"
+	txCode1 := pb.TxValidationCode_VALID
+	txID2 := "5678"
+	txCode2 := pb.TxValidationCode_MVCC_READ_CONFLICT
+
+	regch := make(chan fab.Registration)
+	errch := make(chan error)
+
+	eventch := make(chan *fab.TxStatusEvent, 10)
+	dispatcherEventch <- NewRegisterTxStatusEvent(txID2, eventch, regch, errch)
+
+	var reg fab.Registration
+	select {
+	case reg = <-regch:
+	case err := <-errch:
+		t.Fatalf("error registering for TxStatus events: %s", err)
+	}
+
+	block := servicemocks.NewBlockProducer().NewBlock(channelID,
+		servicemocks.NewTransactionWithCCEvent(txID1, txCode1, "mycc", "event1"),
+		servicemocks.NewTransactionWithCCEvent(txID2, txCode2, "mycc", "event2"),
+	)
+
+	// Corrupt the body of the second transaction, leaving its header intact
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[1])
+	if err != nil {
+		t.Fatalf("Error extracting envelope: %s", err)
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		t.Fatalf("Error extracting payload: %s", err)
+	}
+	payload.Data = []byte("invalid")
+	if env.Payload, err = proto.Marshal(payload); err != nil {
+		t.Fatalf("Error marshalling payload: %s", err)
+	}
+	if block.Data.Data[1], err = proto.Marshal(env); err != nil {
+		t.Fatalf("Error marshalling envelope: %s", err)
+	}
+
+	dispatcherEventch <- block
+
+	select {
+	case event, ok := <-eventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+		checkTxStatusEvent(t, event, txID2, txCode2)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for TxStatus event")
+	}
+
+	dispatcherEventch <- NewUnregisterEvent(reg)
+
+	stopResp := make(chan error)
+	dispatcherEventch <- NewStopEvent(stopResp)
+	if err := <-stopResp; err != nil {
+		t.Fatalf("Error stopping dispatcher: %s", err)
+	}
+}
+
 func TestCCEvents(t *testing.T) {
 	channelID := "testchannel"
 	dispatcher := New()
@@ -536,6 +612,144 @@ func TestCCEvents(t *testing.T) {
 	}
 }
 
+func TestCCEventsWithFilter(t *testing.T) {
+	channelID := "testchannel"
+	dispatcher := New()
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	register := func(filter fab.ChaincodeEventFilter) chan *fab.CCEvent {
+		eventch := make(chan *fab.CCEvent, 10)
+		regch := make(chan fab.Registration)
+		errch := make(chan error)
+		dispatcherEventch <- NewRegisterChaincodeEventWithFilter(filter, eventch, regch, errch)
+		select {
+		case <-regch:
+		case err := <-errch:
+			t.Fatalf("error registering for chaincode events: %s", err)
+		}
+		return eventch
+	}
+
+	matchPayload := func(payload []byte) bool { return string(payload) == "match" }
+
+	multiCCch := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc1", "mycc2"}, EventFilter: ".*"})
+	anyCCch := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{fab.AnyChaincode}, EventFilter: "event.*", IncludeInvalid: true})
+	payloadch1 := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc1"}, EventFilter: ".*", PayloadFilter: matchPayload})
+	// Registrations with a payload filter are never duplicates
+	payloadch2 := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc1"}, EventFilter: ".*", PayloadFilter: matchPayload})
+
+	tx1 := servicemocks.NewFilteredTxWithCCEvent("txid1", "mycc1", "event1")
+	tx1.GetTransactionActions().ChaincodeActions[0].CcEvent.Payload = []byte("match")
+	tx2 := servicemocks.NewFilteredTxWithCCEvent("txid2", "mycc2", "event2")
+	tx2.TxValidationCode = pb.TxValidationCode_MVCC_READ_CONFLICT
+	tx3 := servicemocks.NewFilteredTxWithCCEvent("txid3", "mycc3", "event3")
+	tx3.GetTransactionActions().ChaincodeActions[0].CcEvent.Payload = []byte("nomatch")
+
+	fblock := servicemocks.NewFilteredBlock(channelID, tx1, tx2, tx3)
+	fblock.Number = 5
+	dispatcherEventch <- fblock
+	flush(t, dispatcherEventch)
+
+	checkCCEvents(t, multiCCch, "txid1")
+	checkCCEvents(t, anyCCch, "txid1", "txid2", "txid3")
+	checkCCEvents(t, payloadch1, "txid1")
+	checkCCEvents(t, payloadch2, "txid1")
+
+	errch := make(chan error)
+	dispatcherEventch <- NewRegisterChaincodeEventWithFilter(fab.ChaincodeEventFilter{EventFilter: ".*"}, make(chan *fab.CCEvent), make(chan fab.Registration), errch)
+	select {
+	case err := <-errch:
+		if err == nil {
+			t.Fatalf("expecting error registering without chaincode IDs")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for registration error")
+	}
+}
+
+func TestCCEventDuplicateRegistration(t *testing.T) {
+	dispatcher := New()
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	register := func(filter fab.ChaincodeEventFilter) error {
+		regch := make(chan fab.Registration)
+		errch := make(chan error)
+		dispatcherEventch <- NewRegisterChaincodeEventWithFilter(filter, make(chan *fab.CCEvent, 10), regch, errch)
+		select {
+		case <-regch:
+			return nil
+		case err := <-errch:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for registration response")
+			return nil
+		}
+	}
+
+	if err := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc"}, EventFilter: "event1"}); err != nil {
+		t.Fatalf("error registering for chaincode events: %s", err)
+	}
+	if err := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc"}, EventFilter: "event1", IncludeInvalid: true}); err != nil {
+		t.Fatalf("expecting registration that includes invalid transactions not to be a duplicate: %s", err)
+	}
+	if err := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc"}, EventFilter: "event1", IncludeInvalid: true}); err == nil {
+		t.Fatalf("expecting error registering the same filter twice")
+	}
+
+	if err := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc1", "mycc2"}, EventFilter: "event1"}); err != nil {
+		t.Fatalf("error registering for chaincode events: %s", err)
+	}
+	if err := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc1,mycc2"}, EventFilter: "event1"}); err != nil {
+		t.Fatalf("expecting chaincode ID containing a comma not to collide with a list of IDs: %s", err)
+	}
+	if err := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc/a"}, EventFilter: "b"}); err != nil {
+		t.Fatalf("error registering for chaincode events: %s", err)
+	}
+	if err := register(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc"}, EventFilter: "a/b"}); err != nil {
+		t.Fatalf("expecting chaincode ID containing a slash not to collide with the event filter: %s", err)
+	}
+
+	stopResp := make(chan error)
+	dispatcherEventch <- NewStopEvent(stopResp)
+	if err := <-stopResp; err != nil {
+		t.Fatalf("Error stopping dispatcher: %s", err)
+	}
+}
+
+// checkCCEvents checks that the events received on the channel are for the given transactions
+func checkCCEvents(t *testing.T, eventch chan *fab.CCEvent, expectedTxIDs ...string) {
+	if len(eventch) != len(expectedTxIDs) {
+		t.Fatalf("expecting %d events but got %d", len(expectedTxIDs), len(eventch))
+	}
+	for _, txID := range expectedTxIDs {
+		event := <-eventch
+		if event.TxID != txID {
+			t.Fatalf("expecting event for TxID [%s] but got [%s]", txID, event.TxID)
+		}
+		expectedCode := pb.TxValidationCode_VALID
+		if txID == "txid2" {
+			expectedCode = pb.TxValidationCode_MVCC_READ_CONFLICT
+		}
+		if event.TxValidationCode != expectedCode || event.BlockNumber != 5 {
+			t.Fatalf("unexpected validation code [%s] or block number [%d] for TxID [%s]", event.TxValidationCode, event.BlockNumber, txID)
+		}
+	}
+}
+
 func checkTxStatusEvent(t *testing.T, event *fab.TxStatusEvent, expectedTxID string, expectedCode pb.TxValidationCode) {
 	if event.TxID != expectedTxID {
 		t.Fatalf("expecting event for TxID [%s] but received event for TxID [%s]", expectedTxID, event.TxID)
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"strings"
+
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
@@ -55,7 +57,8 @@ type UnregisterEvent struct {
 	Reg fab.Registration
 }
 
-// NewRegisterBlockEvent creates a new RegisterBlockEvent
+// NewRegisterBlockEvent creates a new RegisterBlockEvent.
+// The registration's consumer policy may be set on the returned event's Reg before it is submitted.
 func NewRegisterBlockEvent(filter fab.BlockFilter, eventch chan<- *fab.BlockEvent, respch chan<- fab.Registration, errCh chan<- error) *RegisterBlockEvent {
 	return &RegisterBlockEvent{
 		Reg:           &BlockReg{Filter: filter, Eventch: eventch},
@@ -63,7 +66,8 @@ func NewRegisterBlockEvent(filter fab.BlockFilter, eventch chan<- *fab.BlockEven
 	}
 }
 
-// NewRegisterFilteredBlockEvent creates a new RegisterFilterBlockEvent
+// NewRegisterFilteredBlockEvent creates a new RegisterFilterBlockEvent.
+// The registration's consumer policy may be set on the returned event's Reg before it is submitted.
 func NewRegisterFilteredBlockEvent(eventch chan<- *fab.FilteredBlockEvent, respch chan<- fab.Registration, errCh chan<- error) *RegisterFilteredBlockEvent {
 	return &RegisterFilteredBlockEvent{
 		Reg:           &FilteredBlockReg{Eventch: eventch},
@@ -78,19 +82,28 @@ func NewUnregisterEvent(reg fab.Registration) *UnregisterEvent {
 	}
 }
 
-// NewRegisterChaincodeEvent creates a new RegisterChaincodeEvent
+// NewRegisterChaincodeEvent creates a new RegisterChaincodeEvent for the events of the given chaincode.
+// The registration's consumer policy may be set on the returned event's Reg before it is submitted.
 func NewRegisterChaincodeEvent(ccID, eventFilter string, eventch chan<- *fab.CCEvent, respch chan<- fab.Registration, errCh chan<- error) *RegisterChaincodeEvent {
+	return NewRegisterChaincodeEventWithFilter(fab.ChaincodeEventFilter{ChaincodeIDs: []string{ccID}, EventFilter: eventFilter}, eventch, respch, errCh)
+}
+
+// NewRegisterChaincodeEventWithFilter creates a new RegisterChaincodeEvent for the events selected by the given filter.
+// The registration's consumer policy may be set on the returned event's Reg before it is submitted.
+func NewRegisterChaincodeEventWithFilter(filter fab.ChaincodeEventFilter, eventch chan<- *fab.CCEvent, respch chan<- fab.Registration, errCh chan<- error) *RegisterChaincodeEvent {
 	return &RegisterChaincodeEvent{
 		Reg: &ChaincodeReg{
-			ChaincodeID: ccID,
-			EventFilter: eventFilter,
+			ChaincodeID: strings.Join(filter.ChaincodeIDs, ","),
+			EventFilter: filter.EventFilter,
 			Eventch:     eventch,
+			Filter:      filter,
 		},
 		RegisterEvent: NewRegisterEvent(respch, errCh),
 	}
 }
 
-// NewRegisterTxStatusEvent creates a new RegisterTxStatusEvent
+// NewRegisterTxStatusEvent creates a new RegisterTxStatusEvent.
+// The registration's consumer policy may be set on the returned event's Reg before it is submitted.
 func NewRegisterTxStatusEvent(txID string, eventch chan<- *fab.TxStatusEvent, respch chan<- fab.Registration, errCh chan<- error) *RegisterTxStatusEvent {
 	return &RegisterTxStatusEvent{
 		Reg:           &TxStatusReg{TxID: txID, Eventch: eventch},
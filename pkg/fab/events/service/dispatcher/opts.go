@@ -9,12 +9,14 @@ package dispatcher
 import (
 	"time"
 
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/options"
 )
 
 type params struct {
 	eventConsumerBufferSize uint
 	eventConsumerTimeout    time.Duration
+	loggerProvider          logApi.LoggerProvider
 }
 
 func defaultParams() *params {
@@ -45,6 +47,16 @@ func WithEventConsumerTimeout(value time.Duration) options.Opt {
 	}
 }
 
+// WithLoggerProvider sets the logger provider of the event service (e.g. the logger
+// provider of an SDK instance). If not set then the process-wide logger provider is used.
+func WithLoggerProvider(value logApi.LoggerProvider) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(loggerProviderSetter); ok {
+			setter.SetLoggerProvider(value)
+		}
+	}
+}
+
 type eventConsumerBufferSizeSetter interface {
 	SetEventConsumerBufferSize(value uint)
 }
@@ -53,6 +65,10 @@ type eventEventConsumerTimeoutSetter interface {
 	SetEventConsumerTimeout(value time.Duration)
 }
 
+type loggerProviderSetter interface {
+	SetLoggerProvider(value logApi.LoggerProvider)
+}
+
 func (p *params) SetEventConsumerBufferSize(value uint) {
 	logger.Debugf("EventConsumerBufferSize: %d", value)
 	p.eventConsumerBufferSize = value
@@ -62,3 +78,7 @@ func (p *params) SetEventConsumerTimeout(value time.Duration) {
 	logger.Debugf("EventConsumerTimeout: %s", value)
 	p.eventConsumerTimeout = value
 }
+
+func (p *params) SetLoggerProvider(value logApi.LoggerProvider) {
+	p.loggerProvider = value
+}
@@ -7,32 +7,76 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"reflect"
 	"regexp"
+	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
 // BlockReg contains the data for a block registration
 type BlockReg struct {
+	Consumer
 	Filter  fab.BlockFilter
 	Eventch chan<- *fab.BlockEvent
 }
 
 // FilteredBlockReg contains the data for a filtered block registration
 type FilteredBlockReg struct {
+	Consumer
 	Eventch chan<- *fab.FilteredBlockEvent
 }
 
 // ChaincodeReg contains the data for a chaincode registration
 type ChaincodeReg struct {
+	Consumer
+	// ChaincodeID is the comma-separated list of the filter's chaincode IDs
 	ChaincodeID string
 	EventFilter string
 	EventRegExp *regexp.Regexp
 	Eventch     chan<- *fab.CCEvent
+	Filter      fab.ChaincodeEventFilter
+	key         string
 }
 
 // TxStatusReg contains the data for a transaction status registration
 type TxStatusReg struct {
+	Consumer
 	TxID    string
 	Eventch chan<- *fab.TxStatusEvent
 }
+
+// matches returns true if the registration wants the given chaincode event
+func (reg *ChaincodeReg) matches(ccEvent *pb.ChaincodeEvent, txValidationCode pb.TxValidationCode) bool {
+	if txValidationCode != pb.TxValidationCode_VALID && !reg.Filter.IncludeInvalid {
+		return false
+	}
+	if !reg.matchesChaincode(ccEvent.ChaincodeId) || !reg.EventRegExp.MatchString(ccEvent.EventName) {
+		return false
+	}
+	return reg.Filter.PayloadFilter == nil || reg.Filter.PayloadFilter(ccEvent.Payload)
+}
+
+func (reg *ChaincodeReg) matchesChaincode(ccID string) bool {
+	for _, id := range reg.Filter.ChaincodeIDs {
+		if id == ccID || id == fab.AnyChaincode {
+			return true
+		}
+	}
+	return false
+}
+
+// send sends the event to the given event channel. If timeout < 0 then it returns
+// immediately if the channel is full; if 0 then it blocks until the event is sent;
+// otherwise it waits up to the given timeout. False is returned if the event wasn't sent.
+func send(eventch interface{}, event interface{}, timeout time.Duration) bool {
+	cases := []reflect.SelectCase{{Dir: reflect.SelectSend, Chan: reflect.ValueOf(eventch), Send: reflect.ValueOf(event)}}
+	if timeout < 0 {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+	} else if timeout > 0 {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(time.After(timeout))})
+	}
+	chosen, _, _ := reflect.Select(cases)
+	return chosen == 0
+}
@@ -43,6 +43,7 @@ type TxInfo struct {
 	HeaderType       cb.HeaderType
 	ChaincodeID      string
 	EventName        string
+	Payload          []byte
 }
 
 // NewTransaction creates a new transaction
@@ -105,7 +106,7 @@ func NewFilteredTxWithCCEvent(txID, ccID, event string) *pb.FilteredTransaction
 
 func newEnvelope(channelID string, txInfo *TxInfo) *cb.Envelope {
 	tx := &pb.Transaction{
-		Actions: []*pb.TransactionAction{newTxAction(txInfo.TxID, txInfo.ChaincodeID, txInfo.EventName)},
+		Actions: []*pb.TransactionAction{newTxAction(txInfo.TxID, txInfo.ChaincodeID, txInfo.EventName, txInfo.Payload)},
 	}
 	txBytes, err := proto.Marshal(tx)
 	if err != nil {
@@ -132,11 +133,12 @@ func newEnvelope(channelID string, txInfo *TxInfo) *cb.Envelope {
 	}
 }
 
-func newTxAction(txID string, ccID string, eventName string) *pb.TransactionAction {
+func newTxAction(txID string, ccID string, eventName string, payload []byte) *pb.TransactionAction {
 	ccEvent := &pb.ChaincodeEvent{
 		TxId:        txID,
 		ChaincodeId: ccID,
 		EventName:   eventName,
+		Payload:     payload,
 	}
 	eventBytes, err := proto.Marshal(ccEvent)
 	if err != nil {
@@ -6,8 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 
 package service
 
+import (
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+)
+
 type params struct {
 	eventConsumerBufferSize uint
+	loggerProvider          logApi.LoggerProvider
 }
 
 func defaultParams() *params {
@@ -20,3 +25,7 @@ func (p *params) SetEventConsumerBufferSize(value uint) {
 	logger.Debugf("EventConsumerBufferSize: %d", value)
 	p.eventConsumerBufferSize = value
 }
+
+func (p *params) SetLoggerProvider(value logApi.LoggerProvider) {
+	p.loggerProvider = value
+}
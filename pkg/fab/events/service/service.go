@@ -26,7 +26,7 @@ const (
 	stopTimeout = 5 * time.Second
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/events/service")
 
 // EventProducer produces events which are dispatched to clients
 type EventProducer interface {
@@ -52,6 +52,7 @@ type Service struct {
 	params
 	dispatcher   Dispatcher
 	registerOnce sync.Once
+	logger       *logging.Logger
 }
 
 // New returns a new event service initialized with the given Dispatcher
@@ -62,6 +63,7 @@ func New(dispatcher Dispatcher, opts ...options.Opt) *Service {
 	return &Service{
 		params:     *params,
 		dispatcher: dispatcher,
+		logger:     logger.ForProvider(params.loggerProvider),
 	}
 }
 
@@ -74,7 +76,7 @@ func (s *Service) Start() error {
 func (s *Service) Stop() {
 	eventch, err := s.dispatcher.EventCh()
 	if err != nil {
-		logger.Warnf("Error stopping event service: %s", err)
+		s.logger.Warnf("Error stopping event service: %s", err)
 		return
 	}
 
@@ -84,10 +86,10 @@ func (s *Service) Stop() {
 	select {
 	case err := <-regch:
 		if err != nil {
-			logger.Warnf("Error while stopping dispatcher: %s", err)
+			s.logger.Warnf("Error while stopping dispatcher: %s", err)
 		}
 	case <-time.After(stopTimeout):
-		logger.Infof("Timed out waiting for dispatcher to stop")
+		s.logger.Infof("Timed out waiting for dispatcher to stop")
 	}
 }
 
@@ -97,7 +99,7 @@ func (s *Service) Submit(event interface{}) error {
 		// During shutdown, events may still be produced and we may
 		// get a 'send on closed channel' panic. Just log and ignore the error.
 		if p := recover(); p != nil {
-			logger.Warnf("panic while submitting event: %s", p)
+			s.logger.Warnf("panic while submitting event: %s", p)
 			debug.PrintStack()
 		}
 	}()
@@ -119,7 +121,52 @@ func (s *Service) Dispatcher() Dispatcher {
 // RegisterBlockEvent registers for block events. If the client is not authorized to receive
 // block events then an error is returned.
 func (s *Service) RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registration, <-chan *fab.BlockEvent, error) {
-	eventch := make(chan *fab.BlockEvent, s.eventConsumerBufferSize)
+	return s.registerBlockEvent(dispatcher.ConsumerPolicy{}, filter...)
+}
+
+// RegisterFilteredBlockEvent registers for filtered block events. If the client is not authorized to receive
+// filtered block events then an error is returned.
+func (s *Service) RegisterFilteredBlockEvent() (fab.Registration, <-chan *fab.FilteredBlockEvent, error) {
+	return s.registerFilteredBlockEvent(dispatcher.ConsumerPolicy{})
+}
+
+// RegisterChaincodeEvent registers for chaincode events. If the client is not authorized to receive
+// chaincode events then an error is returned.
+// - ccID is the chaincode ID for which events are to be received
+// - eventFilter is the chaincode event name for which events are to be received
+func (s *Service) RegisterChaincodeEvent(ccID, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
+	return s.registerChaincodeEvent(dispatcher.ConsumerPolicy{}, ccID, eventFilter)
+}
+
+// RegisterChaincodeEventWithFilter registers for the chaincode events selected by the given filter.
+// If the client is not authorized to receive chaincode events then an error is returned.
+func (s *Service) RegisterChaincodeEventWithFilter(filter fab.ChaincodeEventFilter) (fab.Registration, <-chan *fab.CCEvent, error) {
+	return s.registerChaincodeEventWithFilter(dispatcher.ConsumerPolicy{}, filter)
+}
+
+// RegisterTxStatusEvent registers for transaction status events. If the client is not authorized to receive
+// transaction status events then an error is returned.
+// - txID is the transaction ID for which events are to be received
+func (s *Service) RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *fab.TxStatusEvent, error) {
+	return s.registerTxStatusEvent(dispatcher.ConsumerPolicy{}, txID)
+}
+
+// WithConsumerPolicy returns an event service whose registrations buffer events according to the
+// given policy instead of the service defaults. The registrations belong to this service, so they
+// may be unregistered from either.
+func (s *Service) WithConsumerPolicy(policy dispatcher.ConsumerPolicy) fab.EventService {
+	return &policyService{service: s, policy: policy}
+}
+
+func (s *Service) bufferSize(policy dispatcher.ConsumerPolicy) uint {
+	if policy.BufferSize > 0 {
+		return policy.BufferSize
+	}
+	return s.eventConsumerBufferSize
+}
+
+func (s *Service) registerBlockEvent(policy dispatcher.ConsumerPolicy, filter ...fab.BlockFilter) (fab.Registration, <-chan *fab.BlockEvent, error) {
+	eventch := make(chan *fab.BlockEvent, s.bufferSize(policy))
 	regch := make(chan fab.Registration)
 	errch := make(chan error)
 
@@ -132,7 +179,10 @@ func (s *Service) RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registratio
 		blockFilter = filter[0]
 	}
 
-	if err := s.Submit(dispatcher.NewRegisterBlockEvent(blockFilter, eventch, regch, errch)); err != nil {
+	event := dispatcher.NewRegisterBlockEvent(blockFilter, eventch, regch, errch)
+	event.Reg.Policy = policy
+	event.Reg.DiscardFrom(eventch)
+	if err := s.Submit(event); err != nil {
 		return nil, nil, errors.WithMessage(err, "error registering for block events")
 	}
 
@@ -144,14 +194,15 @@ func (s *Service) RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registratio
 	}
 }
 
-// RegisterFilteredBlockEvent registers for filtered block events. If the client is not authorized to receive
-// filtered block events then an error is returned.
-func (s *Service) RegisterFilteredBlockEvent() (fab.Registration, <-chan *fab.FilteredBlockEvent, error) {
-	eventch := make(chan *fab.FilteredBlockEvent, s.eventConsumerBufferSize)
+func (s *Service) registerFilteredBlockEvent(policy dispatcher.ConsumerPolicy) (fab.Registration, <-chan *fab.FilteredBlockEvent, error) {
+	eventch := make(chan *fab.FilteredBlockEvent, s.bufferSize(policy))
 	regch := make(chan fab.Registration)
 	errch := make(chan error)
 
-	if err := s.Submit(dispatcher.NewRegisterFilteredBlockEvent(eventch, regch, errch)); err != nil {
+	event := dispatcher.NewRegisterFilteredBlockEvent(eventch, regch, errch)
+	event.Reg.Policy = policy
+	event.Reg.DiscardFrom(eventch)
+	if err := s.Submit(event); err != nil {
 		return nil, nil, errors.WithMessage(err, "error registering for filtered block events")
 	}
 
@@ -163,23 +214,34 @@ func (s *Service) RegisterFilteredBlockEvent() (fab.Registration, <-chan *fab.Fi
 	}
 }
 
-// RegisterChaincodeEvent registers for chaincode events. If the client is not authorized to receive
-// chaincode events then an error is returned.
-// - ccID is the chaincode ID for which events are to be received
-// - eventFilter is the chaincode event name for which events are to be received
-func (s *Service) RegisterChaincodeEvent(ccID, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
+func (s *Service) registerChaincodeEvent(policy dispatcher.ConsumerPolicy, ccID, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
 	if ccID == "" {
 		return nil, nil, errors.New("chaincode ID is required")
 	}
-	if eventFilter == "" {
+	return s.registerChaincodeEventWithFilter(policy, fab.ChaincodeEventFilter{ChaincodeIDs: []string{ccID}, EventFilter: eventFilter})
+}
+
+func (s *Service) registerChaincodeEventWithFilter(policy dispatcher.ConsumerPolicy, filter fab.ChaincodeEventFilter) (fab.Registration, <-chan *fab.CCEvent, error) {
+	if len(filter.ChaincodeIDs) == 0 {
+		return nil, nil, errors.New("at least one chaincode ID is required")
+	}
+	for _, ccID := range filter.ChaincodeIDs {
+		if ccID == "" {
+			return nil, nil, errors.New("chaincode ID is required")
+		}
+	}
+	if filter.EventFilter == "" {
 		return nil, nil, errors.New("event filter is required")
 	}
 
-	eventch := make(chan *fab.CCEvent, s.eventConsumerBufferSize)
+	eventch := make(chan *fab.CCEvent, s.bufferSize(policy))
 	regch := make(chan fab.Registration)
 	errch := make(chan error)
 
-	if err := s.Submit(dispatcher.NewRegisterChaincodeEvent(ccID, eventFilter, eventch, regch, errch)); err != nil {
+	event := dispatcher.NewRegisterChaincodeEventWithFilter(filter, eventch, regch, errch)
+	event.Reg.Policy = policy
+	event.Reg.DiscardFrom(eventch)
+	if err := s.Submit(event); err != nil {
 		return nil, nil, errors.WithMessage(err, "error registering for chaincode events")
 	}
 
@@ -191,19 +253,19 @@ func (s *Service) RegisterChaincodeEvent(ccID, eventFilter string) (fab.Registra
 	}
 }
 
-// RegisterTxStatusEvent registers for transaction status events. If the client is not authorized to receive
-// transaction status events then an error is returned.
-// - txID is the transaction ID for which events are to be received
-func (s *Service) RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *fab.TxStatusEvent, error) {
+func (s *Service) registerTxStatusEvent(policy dispatcher.ConsumerPolicy, txID string) (fab.Registration, <-chan *fab.TxStatusEvent, error) {
 	if txID == "" {
 		return nil, nil, errors.New("txID must be provided")
 	}
 
-	eventch := make(chan *fab.TxStatusEvent, s.eventConsumerBufferSize)
+	eventch := make(chan *fab.TxStatusEvent, s.bufferSize(policy))
 	regch := make(chan fab.Registration)
 	errch := make(chan error)
 
-	if err := s.Submit(dispatcher.NewRegisterTxStatusEvent(txID, eventch, regch, errch)); err != nil {
+	event := dispatcher.NewRegisterTxStatusEvent(txID, eventch, regch, errch)
+	event.Reg.Policy = policy
+	event.Reg.DiscardFrom(eventch)
+	if err := s.Submit(event); err != nil {
 		return nil, nil, errors.WithMessage(err, "error registering for Tx Status events")
 	}
 
@@ -219,6 +281,50 @@ func (s *Service) RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *
 // - reg is the registration handle that was returned from one of the RegisterXXX functions
 func (s *Service) Unregister(reg fab.Registration) {
 	if err := s.Submit(dispatcher.NewUnregisterEvent(reg)); err != nil {
-		logger.Warnf("Error unregistering: %s", err)
+		s.logger.Warnf("Error unregistering: %s", err)
 	}
 }
+
+// DroppedEvents returns the number of events that were not delivered to the given
+// registration because its event channel was full
+func DroppedEvents(reg fab.Registration) (uint64, error) {
+	consumer, ok := reg.(droppedEventsCounter)
+	if !ok {
+		return 0, errors.Errorf("unsupported registration type: %T", reg)
+	}
+	return consumer.DroppedEvents(), nil
+}
+
+type droppedEventsCounter interface {
+	DroppedEvents() uint64
+}
+
+// policyService registers with the event service using a given consumer policy
+type policyService struct {
+	service *Service
+	policy  dispatcher.ConsumerPolicy
+}
+
+func (ps *policyService) RegisterBlockEvent(filter ...fab.BlockFilter) (fab.Registration, <-chan *fab.BlockEvent, error) {
+	return ps.service.registerBlockEvent(ps.policy, filter...)
+}
+
+func (ps *policyService) RegisterFilteredBlockEvent() (fab.Registration, <-chan *fab.FilteredBlockEvent, error) {
+	return ps.service.registerFilteredBlockEvent(ps.policy)
+}
+
+func (ps *policyService) RegisterChaincodeEvent(ccID, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
+	return ps.service.registerChaincodeEvent(ps.policy, ccID, eventFilter)
+}
+
+func (ps *policyService) RegisterChaincodeEventWithFilter(filter fab.ChaincodeEventFilter) (fab.Registration, <-chan *fab.CCEvent, error) {
+	return ps.service.registerChaincodeEventWithFilter(ps.policy, filter)
+}
+
+func (ps *policyService) RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *fab.TxStatusEvent, error) {
+	return ps.service.registerTxStatusEvent(ps.policy, txID)
+}
+
+func (ps *policyService) Unregister(reg fab.Registration) {
+	ps.service.Unregister(reg)
+}
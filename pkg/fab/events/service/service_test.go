@@ -413,7 +413,132 @@ func TestCCEvents(t *testing.T) {
 	}
 }
 
+func TestCCEventsWithFilter(t *testing.T) {
+	channelID := "mychannel"
+	eventService, eventProducer, err := newServiceWithMockProducer(defaultOpts, withBlockLedger())
+	if err != nil {
+		t.Fatalf("error creating channel event client: %s", err)
+	}
+	defer eventProducer.Close()
+	defer eventService.Stop()
+
+	if _, _, err := eventService.RegisterChaincodeEventWithFilter(fab.ChaincodeEventFilter{EventFilter: ".*"}); err == nil {
+		t.Fatalf("expecting error registering for chaincode events without CC IDs but got none")
+	}
+	if _, _, err := eventService.RegisterChaincodeEventWithFilter(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc1", ""}, EventFilter: ".*"}); err == nil {
+		t.Fatalf("expecting error registering for chaincode events with empty CC ID but got none")
+	}
+	if _, _, err := eventService.RegisterChaincodeEventWithFilter(fab.ChaincodeEventFilter{ChaincodeIDs: []string{"mycc1"}}); err == nil {
+		t.Fatalf("expecting error registering for chaincode events without event filter but got none")
+	}
+
+	reg, eventch, err := eventService.RegisterChaincodeEventWithFilter(fab.ChaincodeEventFilter{
+		ChaincodeIDs:   []string{"mycc1", "mycc2"},
+		EventFilter:    ".*",
+		IncludeInvalid: true,
+	})
+	if err != nil {
+		t.Fatalf("error registering for chaincode events: %s", err)
+	}
+	defer eventService.Unregister(reg)
+
+	eventProducer.Ledger().NewBlock(
+		channelID,
+		servicemocks.NewTransactionWithCCEvent("txid1", pb.TxValidationCode_VALID, "mycc1", "event1"),
+		servicemocks.NewTransactionWithCCEvent("txid2", pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, "mycc2", "event2"),
+		servicemocks.NewTransactionWithCCEvent("txid3", pb.TxValidationCode_VALID, "mycc3", "event3"),
+	)
+
+	expected := []struct {
+		txID string
+		code pb.TxValidationCode
+	}{
+		{"txid1", pb.TxValidationCode_VALID},
+		{"txid2", pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE},
+	}
+	for _, e := range expected {
+		select {
+		case event, ok := <-eventch:
+			if !ok {
+				t.Fatalf("unexpected closed channel")
+			}
+			if event.TxID != e.txID || event.TxValidationCode != e.code {
+				t.Fatalf("expecting event for TxID [%s] with code [%s] but got TxID [%s] with code [%s]", e.txID, e.code, event.TxID, event.TxValidationCode)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for CC event for TxID [%s]", e.txID)
+		}
+	}
+
+	select {
+	case event := <-eventch:
+		t.Fatalf("unexpected CC event for chaincode [%s]", event.ChaincodeID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // TestConcurrentEvents ensures that the channel event client is thread-safe
+func TestConsumerPolicy(t *testing.T) {
+	channelID := "mychannel"
+	eventService, eventProducer, err := newServiceWithMockProducer(defaultOpts, withBlockLedger())
+	if err != nil {
+		t.Fatalf("error creating channel event client: %s", err)
+	}
+	defer eventProducer.Close()
+	defer eventService.Stop()
+
+	policyService := eventService.WithConsumerPolicy(dispatcher.ConsumerPolicy{
+		BufferSize:    1,
+		Overflow:      dispatcher.OverflowDropNewest,
+		NotifySkipped: true,
+	})
+
+	registration, eventch, err := policyService.RegisterBlockEvent()
+	if err != nil {
+		t.Fatalf("error registering for block events: %s", err)
+	}
+	defer policyService.Unregister(registration)
+
+	if cap(eventch) != 1 {
+		t.Fatalf("expected event channel with buffer size 1 but got %d", cap(eventch))
+	}
+
+	for i := 0; i < 3; i++ {
+		eventProducer.Ledger().NewBlock(channelID)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		dropped, err := DroppedEvents(registration)
+		if err != nil {
+			t.Fatalf("error getting dropped events: %s", err)
+		}
+		if dropped == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 dropped events but got %d", dropped)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	<-eventch
+	eventProducer.Ledger().NewBlock(channelID)
+
+	select {
+	case event := <-eventch:
+		if event.Skipped != 2 {
+			t.Fatalf("expected event to report 2 skipped events but got %d", event.Skipped)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for block event")
+	}
+
+	if _, err := DroppedEvents("invalid registration"); err == nil {
+		t.Fatalf("expected error getting dropped events for invalid registration")
+	}
+}
+
 func TestConcurrentEvents(t *testing.T) {
 	var numEvents uint = 1000
 	channelID := "mychannel"
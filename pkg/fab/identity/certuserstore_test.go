@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"bytes"
+	"testing"
+
+	fabricCaUtil "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/keyvaluestore"
+)
+
+func TestCertUserStoreWithMemStore(t *testing.T) {
+	cleanup(t, storePathRoot)
+	defer cleanup(t, storePathRoot)
+
+	crypto := crypto(t)
+
+	_, err := fabricCaUtil.ImportBCCSPKeyFromPEMBytes([]byte(testPrivKey1), crypto, false)
+	if err != nil {
+		t.Fatalf("ImportBCCSPKeyFromPEMBytes failed [%s]", err)
+	}
+
+	store, err := NewCertUserStore(keyvaluestore.NewMemKeyValueStore(nil), crypto)
+	if err != nil {
+		t.Fatalf("NewCertUserStore failed [%s]", err)
+	}
+
+	user := &User{
+		mspID:                 "Org1MSP",
+		name:                  "user1",
+		enrollmentCertificate: []byte(testCert1),
+	}
+	if err := store.Store(user); err != nil {
+		t.Fatalf("Store failed [%s]", err)
+	}
+
+	loaded, err := store.Load(api.UserKey{MspID: "Org1MSP", Name: "user1"})
+	if err != nil {
+		t.Fatalf("Load failed [%s]", err)
+	}
+	if !bytes.Equal(loaded.EnrollmentCertificate(), []byte(testCert1)) {
+		t.Fatal("loaded cert doesn't match stored cert")
+	}
+	if loaded.PrivateKey() == nil {
+		t.Fatal("private key should be resolved from the crypto suite")
+	}
+
+	if err := store.Delete(user); err != nil {
+		t.Fatalf("Delete failed [%s]", err)
+	}
+	if _, err := store.Load(api.UserKey{MspID: "Org1MSP", Name: "user1"}); err != api.ErrUserNotFound {
+		t.Fatal("loading a deleted user should return ErrUserNotFound")
+	}
+}
+
+func TestNewCertUserStoreInvalidArgs(t *testing.T) {
+	if _, err := NewCertUserStore(nil, crypto(t)); err == nil {
+		t.Fatal("NewCertUserStore should fail for a nil store")
+	}
+	if _, err := NewCertUserStore(keyvaluestore.NewMemKeyValueStore(nil), nil); err == nil {
+		t.Fatal("NewCertUserStore should fail for a nil crypto suite")
+	}
+}
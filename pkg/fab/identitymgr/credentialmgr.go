@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/identitymgr/persistence"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/wallet"
 	"github.com/pkg/errors"
 )
 
@@ -27,9 +28,12 @@ func (mgr *IdentityManager) GetSigningIdentity(userName string) (*api.SigningIde
 		return nil, errors.New("username is required")
 	}
 
-	var signingIdentity *api.SigningIdentity
+	signingIdentity, err := mgr.getSigningIdentityFromWallet(userName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "loading identity from wallet failed")
+	}
 
-	if mgr.userStore != nil {
+	if signingIdentity == nil && mgr.userStore != nil {
 		user, err := mgr.userStore.Load(api.UserKey{MspID: mgr.orgMspID, Name: userName})
 		if err == nil {
 			signingIdentity = &api.SigningIdentity{MspID: user.MspID(), PrivateKey: user.PrivateKey(), EnrollmentCert: user.EnrollmentCertificate()}
@@ -77,6 +81,26 @@ func (mgr *IdentityManager) GetSigningIdentity(userName string) (*api.SigningIde
 	return signingIdentity, nil
 }
 
+// getSigningIdentityFromWallet returns the identity held in the wallet under userName,
+// or nil if there is no wallet or it holds no identity of this organization under that label
+func (mgr *IdentityManager) getSigningIdentityFromWallet(userName string) (*api.SigningIdentity, error) {
+	if mgr.wallet == nil {
+		return nil, nil
+	}
+	id, err := mgr.wallet.Get(userName)
+	if err != nil {
+		if err == wallet.ErrIdentityNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if id.MspID != mgr.orgMspID {
+		mgr.logger().Debugf("Wallet identity [%s] belongs to MSP [%s], not [%s]", userName, id.MspID, mgr.orgMspID)
+		return nil, nil
+	}
+	return id.SigningIdentity(mgr.cryptoSuite)
+}
+
 func (mgr *IdentityManager) getEmbeddedCertBytes(userName string) ([]byte, error) {
 	certPem := mgr.embeddedUsers[strings.ToLower(userName)].Cert.Pem
 	certPath := mgr.embeddedUsers[strings.ToLower(userName)].Cert.Path
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/identity"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/wallet"
 	"github.com/pkg/errors"
 )
 
@@ -84,7 +85,7 @@ func TestCredentialManager(t *testing.T) {
 		t.Fatalf("Failed to setup userStore: %s", err)
 	}
 
-	credentialMgr, err := New(msp, config, cryptoSuite)
+	credentialMgr, err := New(msp, config, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("Failed to setup credential manager: %s", err)
 	}
@@ -156,7 +157,7 @@ func TestInvalidOrgCredentialManager(t *testing.T) {
 	}
 
 	// Invalid Org
-	_, err = New("invalidOrg", config, &fcmocks.MockCryptoSuite{})
+	_, err = New("invalidOrg", config, &fcmocks.MockCryptoSuite{}, nil)
 	if err == nil {
 		t.Fatalf("Should have failed to setup manager for invalid org")
 	}
@@ -170,7 +171,7 @@ func TestCredentialManagerFromEmbeddedCryptoConfig(t *testing.T) {
 		t.Fatalf(err.Error())
 	}
 
-	credentialMgr, err := New(msp, config, cryptosuite.GetDefault())
+	credentialMgr, err := New(msp, config, cryptosuite.GetDefault(), nil)
 	if err != nil {
 		t.Fatalf("Failed to setup credential manager: %s", err)
 	}
@@ -205,3 +206,45 @@ func TestCredentialManagerFromEmbeddedCryptoConfig(t *testing.T) {
 func createRandomName() string {
 	return "user" + strconv.Itoa(rand.Intn(500000))
 }
+
+func TestCredentialManagerFromWallet(t *testing.T) {
+	config, err := config.FromFile("../../../test/fixtures/config/config_test.yaml")()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	cryptoSuite, err := sw.GetSuiteByConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to setup cryptoSuite: %s", err)
+	}
+	credentialMgr, err := New(msp, config, cryptoSuite, nil)
+	if err != nil {
+		t.Fatalf("Failed to setup credential manager: %s", err)
+	}
+	credentialMgr.wallet = wallet.NewInMemoryWallet()
+
+	walletUserName := createRandomName()
+	if err := checkSigningIdentity(credentialMgr, walletUserName); err != api.ErrUserNotFound {
+		t.Fatalf("expected ErrUserNotFound, got: %s", err)
+	}
+
+	id, err := wallet.NewIdentity("Org2MSP", []byte(testCert), []byte(testPrivKey))
+	if err != nil {
+		t.Fatalf("NewIdentity failed: %s", err)
+	}
+	if err := credentialMgr.wallet.Put(walletUserName, id); err != nil {
+		t.Fatalf("Put failed: %s", err)
+	}
+
+	// Identities of other organizations are ignored
+	if err := checkSigningIdentity(credentialMgr, walletUserName); err != api.ErrUserNotFound {
+		t.Fatalf("expected ErrUserNotFound, got: %s", err)
+	}
+
+	id.MspID = credentialMgr.orgMspID
+	if err := credentialMgr.wallet.Put(walletUserName, id); err != nil {
+		t.Fatalf("Put failed: %s", err)
+	}
+	if err := checkSigningIdentity(credentialMgr, walletUserName); err != nil {
+		t.Fatalf("checkSigningIdentity failed: %s", err)
+	}
+}
@@ -49,7 +49,7 @@ func TestCredentialManagerWithEnrollment(t *testing.T) {
 
 	cs, err := sw.GetSuiteByConfig(config)
 
-	credentialMgr, err := New(orgName, config, cs)
+	credentialMgr, err := New(orgName, config, cs, nil)
 	if err != nil {
 		t.Fatalf("Failed to setup credential manager: %s", err)
 	}
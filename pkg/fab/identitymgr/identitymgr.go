@@ -9,6 +9,8 @@ package identitymgr
 import (
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -17,14 +19,17 @@ import (
 	config "github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/identity"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/identitymgr/persistence"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/keyvaluestore"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/wallet"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
 
 	contextApi "github.com/hyperledger/fabric-sdk-go/pkg/context/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/ca")
 
 // IdentityManager implements fab/IdentityManager
 type IdentityManager struct {
@@ -37,18 +42,28 @@ type IdentityManager struct {
 	mspPrivKeyStore contextApi.KVStore
 	mspCertStore    contextApi.KVStore
 	userStore       contextApi.UserStore
+	wallet          *wallet.Wallet
+
+	// Key rotation state
+	rotationStore contextApi.KVStore
+	rotationMutex sync.Mutex
+	keyRetention  time.Duration
 
 	// CA Client state
 	caClient  *calib.Client
 	registrar config.EnrollCredentials
+
+	loggerProvider logApi.LoggerProvider
 }
 
 // New creates a new instance of IdentityManager
 // @param {string} organization for this CA
 // @param {Config} client config for fabric-ca services
+// @param {CryptoSuite} crypto suite holding user keys
+// @param {KVStore} optional store backing the user store (e.g. the SDK state store)
 // @returns {IdentityManager} IdentityManager instance
 // @returns {error} error, if any
-func New(orgName string, config config.Config, cryptoSuite core.CryptoSuite) (*IdentityManager, error) {
+func New(orgName string, config config.Config, cryptoSuite core.CryptoSuite, stateStore contextApi.KVStore) (*IdentityManager, error) {
 
 	netConfig, err := config.NetworkConfig()
 	if err != nil {
@@ -85,13 +100,40 @@ func New(orgName string, config config.Config, cryptoSuite core.CryptoSuite) (*I
 		logger.Warnf("Cryptopath not provided for organization [%s], MSP stores not created", orgName)
 	}
 
-	// In the future, shared UserStore from the SDK context will be used
+	// Users are kept in the shared state store when one is provided;
+	// otherwise fall back to a file store at the credential store path
 	var userStore contextApi.UserStore
-	if config.CredentialStorePath() != "" {
+	rotationStore := stateStore
+	if stateStore != nil {
+		userStore, err = identity.NewCertUserStore(stateStore, cryptoSuite)
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating a user store failed")
+		}
+	} else if config.CredentialStorePath() != "" {
 		userStore, err = identity.NewCertFileUserStore(config.CredentialStorePath(), cryptoSuite)
 		if err != nil {
 			return nil, errors.Wrapf(err, "creating a user store failed")
 		}
+		rotationStore, err = keyvaluestore.New(&keyvaluestore.FileKeyValueStoreOptions{Path: config.CredentialStorePath()})
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating a key rotation store failed")
+		}
+	} else {
+		rotationStore = keyvaluestore.NewMemKeyValueStore(nil)
+	}
+
+	clientConfig, err := config.Client()
+	if err != nil {
+		return nil, errors.WithMessage(err, "client config retrieval failed")
+	}
+
+	// Identities held in a wallet take precedence over the other stores
+	var w *wallet.Wallet
+	if clientConfig.CredentialStore.Wallet != "" {
+		w, err = wallet.NewFileSystemWallet(clientConfig.CredentialStore.Wallet)
+		if err != nil {
+			return nil, errors.WithMessage(err, "creating a wallet failed")
+		}
 	}
 
 	var caName string
@@ -109,6 +151,9 @@ func New(orgName string, config config.Config, cryptoSuite core.CryptoSuite) (*I
 		mspCertStore:    mspCertStore,
 		embeddedUsers:   orgConfig.Users,
 		userStore:       userStore,
+		wallet:          w,
+		rotationStore:   rotationStore,
+		keyRetention:    config.TimeoutOrDefault(core.Execute),
 		// CA Client state is created lazily, when (if) needed
 	}
 	return mgr, nil
@@ -119,6 +164,17 @@ func (im *IdentityManager) CAName() string {
 	return im.caName
 }
 
+// SetLoggerProvider sets the logger provider of the identity manager (e.g. the logger
+// provider of an SDK instance). If not set then the process-wide logger provider is used.
+func (im *IdentityManager) SetLoggerProvider(loggerProvider logApi.LoggerProvider) {
+	im.loggerProvider = loggerProvider
+}
+
+// logger returns the package logger bound to the logger provider of the identity manager
+func (im *IdentityManager) logger() *logging.Logger {
+	return logger.ForProvider(im.loggerProvider)
+}
+
 // Enroll a registered user in order to receive a signed X509 certificate.
 // enrollmentID The registered ID to use for enrollment
 // enrollmentSecret The secret associated with the enrollment ID
@@ -133,11 +189,16 @@ func (im *IdentityManager) Enroll(enrollmentID string, enrollmentSecret string)
 	if enrollmentSecret == "" {
 		return nil, nil, errors.New("enrollmentSecret is required")
 	}
+	csr, err := im.csrInfo()
+	if err != nil {
+		return nil, nil, err
+	}
 	// TODO add attributes
 	careq := &caapi.EnrollmentRequest{
 		CAName: im.caClient.Config.CAName,
 		Name:   enrollmentID,
 		Secret: enrollmentSecret,
+		CSR:    csr,
 	}
 	caresp, err := im.caClient.Enroll(careq)
 	if err != nil {
@@ -163,16 +224,21 @@ func (im *IdentityManager) Reenroll(user contextApi.User) (core.Key, []byte, err
 		return nil, nil, errors.New("user required")
 	}
 	if user.Name() == "" {
-		logger.Infof("Invalid re-enroll request, missing argument user")
+		im.logger().Infof("Invalid re-enroll request, missing argument user")
 		return nil, nil, errors.New("user name missing")
 	}
+	csr, err := im.csrInfo()
+	if err != nil {
+		return nil, nil, err
+	}
 	req := &caapi.ReenrollmentRequest{
 		CAName: im.caClient.Config.CAName,
+		CSR:    csr,
 	}
 	// Create signing identity
 	identity, err := im.createSigningIdentity(user)
 	if err != nil {
-		logger.Debugf("Invalid re-enroll request, %s is not a valid user  %s\n", user.Name(), err)
+		im.logger().Debugf("Invalid re-enroll request, %s is not a valid user  %s\n", user.Name(), err)
 		return nil, nil, errors.Wrap(err, "createSigningIdentity failed")
 	}
 
@@ -183,6 +249,20 @@ func (im *IdentityManager) Reenroll(user contextApi.User) (core.Key, []byte, err
 	return reenrollmentResponse.Identity.GetECert().Key(), reenrollmentResponse.Identity.GetECert().Cert(), nil
 }
 
+// csrInfo returns the CSR info requesting an ECDSA key on the curve matching
+// the configured security level (P-256 or P-384), or nil for the CA client's
+// default key request if no security level is configured
+func (im *IdentityManager) csrInfo() (*caapi.CSRInfo, error) {
+	level := im.config.SecurityLevel()
+	switch level {
+	case 0:
+		return nil, nil
+	case 256, 384:
+		return &caapi.CSRInfo{KeyRequest: &caapi.BasicKeyRequest{Algo: "ecdsa", Size: level}}, nil
+	}
+	return nil, errors.Errorf("unsupported security level for enrollment: %d", level)
+}
+
 // Register a User with the Fabric CA
 // request: Registration Request
 // Returns Enrolment Secret
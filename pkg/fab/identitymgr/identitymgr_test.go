@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package identitymgr
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -86,7 +89,7 @@ func TestMain(m *testing.M) {
 // TestEnrollAndReenroll tests enrol/reenroll scenarios
 func TestEnrollAndReenroll(t *testing.T) {
 
-	identityManager, err := New(org1, fullConfig, cryptoSuite)
+	identityManager, err := New(org1, fullConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("NewidentityManagerClient return error: %v", err)
 	}
@@ -145,7 +148,7 @@ func TestEnrollAndReenroll(t *testing.T) {
 	}
 
 	// Try going against wrong CA URL
-	identityManager, err = New(org1, wrongURLConfig, cryptoSuite)
+	identityManager, err = New(org1, wrongURLConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("NewidentityManagerClient return error: %v", err)
 	}
@@ -156,10 +159,68 @@ func TestEnrollAndReenroll(t *testing.T) {
 
 }
 
+type securityLevelConfig struct {
+	core.Config
+	level int
+}
+
+func (c *securityLevelConfig) SecurityLevel() int {
+	return c.level
+}
+
+// TestEnrollmentKeyRequest tests that enrollment keys follow the configured security level
+func TestEnrollmentKeyRequest(t *testing.T) {
+	identityManager, err := New(org1, &securityLevelConfig{Config: fullConfig, level: 384}, cryptoSuite, nil)
+	if err != nil {
+		t.Fatalf("NewidentityManagerClient return error: %v", err)
+	}
+	if err := identityManager.initCAClient(); err != nil {
+		t.Fatalf("initCAClient return error: %v", err)
+	}
+
+	csrInfo, err := identityManager.csrInfo()
+	if err != nil {
+		t.Fatalf("csrInfo return error: %v", err)
+	}
+	_, key, err := identityManager.caClient.GenCSR(csrInfo, "enrollmentID")
+	if err != nil {
+		t.Fatalf("GenCSR return error: %v", err)
+	}
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey return error: %v", err)
+	}
+	raw, err := publicKey.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes return error: %v", err)
+	}
+	pk, err := x509.ParsePKIXPublicKey(raw)
+	if err != nil {
+		t.Fatalf("Failed to parse public key: %v", err)
+	}
+	if ecPK, ok := pk.(*ecdsa.PublicKey); !ok || ecPK.Curve != elliptic.P384() {
+		t.Fatalf("Expected ECDSA P-384 enrollment key")
+	}
+
+	_, _, err = identityManager.Enroll("enrollmentID", "enrollmentSecret")
+	if err != nil {
+		t.Fatalf("identityManager Enroll return error %v", err)
+	}
+
+	identityManager, err = New(org1, &securityLevelConfig{Config: fullConfig, level: 512}, cryptoSuite, nil)
+	if err != nil {
+		t.Fatalf("NewidentityManagerClient return error: %v", err)
+	}
+	_, _, err = identityManager.Enroll("enrollmentID", "enrollmentSecret")
+	if err == nil || !strings.Contains(err.Error(), "unsupported security level") {
+		t.Fatalf("Expected unsupported security level error, got %v", err)
+	}
+}
+
 // TestRegister tests multiple scenarios of registering a test (mocked or nil user) and their certs
 func TestRegister(t *testing.T) {
 
-	identityManager, err := New(org1, fullConfig, cryptoSuite)
+	identityManager, err := New(org1, fullConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("NewidentityManagerClient returned error: %v", err)
 	}
@@ -198,7 +259,7 @@ func TestRevoke(t *testing.T) {
 		t.Fatalf("cryptosuite.GetSuiteByConfig returned error: %v", err)
 	}
 
-	identityManager, err := New(org1, fullConfig, cryptoSuite)
+	identityManager, err := New(org1, fullConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("NewidentityManagerClient returned error: %v", err)
 	}
@@ -223,7 +284,7 @@ func TestRevoke(t *testing.T) {
 // TestGetCAName will test the CAName is properly created once a new identityManagerClient is created
 func TestGetCAName(t *testing.T) {
 
-	identityManager, err := New(org1, fullConfig, cryptoSuite)
+	identityManager, err := New(org1, fullConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("NewidentityManagerClient returned error: %v", err)
 	}
@@ -252,7 +313,9 @@ func TestCreateNewidentityManagerClientCAConfigMissingFailure(t *testing.T) {
 	mockConfig.EXPECT().CryptoConfigPath().Return(fullConfig.CryptoConfigPath()).AnyTimes()
 	mockConfig.EXPECT().CAConfig(org1).Return(nil, errors.New("CAConfig error"))
 	mockConfig.EXPECT().CredentialStorePath().Return(dummyUserStorePath).AnyTimes()
-	mgr, err := New(org1, mockConfig, cryptoSuite)
+	mockConfig.EXPECT().Client().Return(fullConfig.Client()).AnyTimes()
+	mockConfig.EXPECT().TimeoutOrDefault(core.Execute).Return(fullConfig.TimeoutOrDefault(core.Execute)).AnyTimes()
+	mgr, err := New(org1, mockConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("failed to create IdentityManager: %v", err)
 	}
@@ -273,8 +336,10 @@ func TestCreateNewidentityManagerClientCertFilesMissingFailure(t *testing.T) {
 	mockConfig.EXPECT().CryptoConfigPath().Return(fullConfig.CryptoConfigPath()).AnyTimes()
 	mockConfig.EXPECT().CAConfig(org1).Return(&core.CAConfig{}, nil).AnyTimes()
 	mockConfig.EXPECT().CredentialStorePath().Return(dummyUserStorePath).AnyTimes()
+	mockConfig.EXPECT().Client().Return(fullConfig.Client()).AnyTimes()
+	mockConfig.EXPECT().TimeoutOrDefault(core.Execute).Return(fullConfig.TimeoutOrDefault(core.Execute)).AnyTimes()
 	mockConfig.EXPECT().CAServerCertPaths(org1).Return(nil, errors.New("CAServerCertPaths error"))
-	mgr, err := New(org1, mockConfig, cryptoSuite)
+	mgr, err := New(org1, mockConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("failed to create IdentityManager: %v", err)
 	}
@@ -294,9 +359,11 @@ func TestCreateNewidentityManagerClientCertFileErrorFailure(t *testing.T) {
 	mockConfig.EXPECT().CryptoConfigPath().Return(fullConfig.CryptoConfigPath()).AnyTimes()
 	mockConfig.EXPECT().CAConfig(org1).Return(&core.CAConfig{}, nil).AnyTimes()
 	mockConfig.EXPECT().CredentialStorePath().Return(dummyUserStorePath).AnyTimes()
+	mockConfig.EXPECT().Client().Return(fullConfig.Client()).AnyTimes()
+	mockConfig.EXPECT().TimeoutOrDefault(core.Execute).Return(fullConfig.TimeoutOrDefault(core.Execute)).AnyTimes()
 	mockConfig.EXPECT().CAServerCertPaths(org1).Return([]string{"test"}, nil)
 	mockConfig.EXPECT().CAClientCertPath(org1).Return("", errors.New("CAClientCertPath error"))
-	mgr, err := New(org1, mockConfig, cryptoSuite)
+	mgr, err := New(org1, mockConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("failed to create IdentityManager: %v", err)
 	}
@@ -316,10 +383,12 @@ func TestCreateNewidentityManagerClientKeyFileErrorFailure(t *testing.T) {
 	mockConfig.EXPECT().CryptoConfigPath().Return(fullConfig.CryptoConfigPath()).AnyTimes()
 	mockConfig.EXPECT().CAConfig(org1).Return(&core.CAConfig{}, nil).AnyTimes()
 	mockConfig.EXPECT().CredentialStorePath().Return(dummyUserStorePath).AnyTimes()
+	mockConfig.EXPECT().Client().Return(fullConfig.Client()).AnyTimes()
+	mockConfig.EXPECT().TimeoutOrDefault(core.Execute).Return(fullConfig.TimeoutOrDefault(core.Execute)).AnyTimes()
 	mockConfig.EXPECT().CAServerCertPaths(org1).Return([]string{"test"}, nil)
 	mockConfig.EXPECT().CAClientCertPath(org1).Return("", nil)
 	mockConfig.EXPECT().CAClientKeyPath(org1).Return("", errors.New("CAClientKeyPath error"))
-	mgr, err := New(org1, mockConfig, cryptoSuite)
+	mgr, err := New(org1, mockConfig, cryptoSuite, nil)
 	if err != nil {
 		t.Fatalf("failed to create IdentityManager: %v", err)
 	}
@@ -337,7 +406,7 @@ func TestCreateValidBCCSPOptsForNewFabricClient(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Expected fabric client ryptosuite to be created with SW BCCS provider, but got %v", err.Error())
 	}
-	_, err = New(org1, fullConfig, newCryptosuiteProvider)
+	_, err = New(org1, fullConfig, newCryptosuiteProvider, nil)
 	if err != nil {
 		t.Fatalf("Expected fabric client to be created with SW BCCS provider, but got %v", err.Error())
 	}
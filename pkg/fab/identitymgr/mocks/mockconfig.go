@@ -211,6 +211,11 @@ func (c *MockConfig) SecurityProviderLabel() string {
 	return ""
 }
 
+// SecurityProviderConfig ...
+func (c *MockConfig) SecurityProviderConfig(provider string) map[string]interface{} {
+	return nil
+}
+
 // IsSecurityEnabled ...
 func (c *MockConfig) IsSecurityEnabled() bool {
 	return false
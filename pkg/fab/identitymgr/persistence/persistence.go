@@ -10,7 +10,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/ca/persistence")
 
 // PrivKeyKey is a composite key for accessing a private key in the key store
 type PrivKeyKey struct {
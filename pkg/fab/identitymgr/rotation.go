@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identitymgr
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	contextApi "github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/identity"
+	"github.com/pkg/errors"
+)
+
+// KeyRotation records the replacement of a user's enrollment key and certificate.
+// The retired key remains in the key store until RetainUntil, so that in-flight
+// transactions signed with it can complete. After that it is purged by PurgeRetiredKeys.
+// Transactions carry the creator certificate, so audits can map a past transaction
+// to the key that signed it by matching the certificate against the history.
+type KeyRotation struct {
+	UserName    string     `json:"userName"`
+	MspID       string     `json:"mspId"`
+	RetiredSKI  []byte     `json:"retiredSki"`
+	RetiredCert []byte     `json:"retiredCert"`
+	SKI         []byte     `json:"ski"`
+	Cert        []byte     `json:"cert"`
+	RotatedAt   time.Time  `json:"rotatedAt"`
+	RetainUntil time.Time  `json:"retainUntil"`
+	PurgedAt    *time.Time `json:"purgedAt,omitempty"`
+}
+
+// RotateKey re-enrolls user with a newly generated key and stores the new
+// enrollment certificate in the user store. The previous key is retained for the
+// execute timeout (see core.Execute), the longest a transaction signed before the
+// rotation can be in flight, and the rotation is recorded in the user's history.
+func (im *IdentityManager) RotateKey(user contextApi.User) (*KeyRotation, error) {
+	if im.userStore == nil {
+		return nil, errors.New("user store is required for key rotation")
+	}
+	if user == nil {
+		return nil, errors.New("user required")
+	}
+	if user.PrivateKey() == nil {
+		return nil, errors.New("user private key required")
+	}
+
+	im.rotationMutex.Lock()
+	defer im.rotationMutex.Unlock()
+
+	key, cert, err := im.Reenroll(user)
+	if err != nil {
+		return nil, errors.WithMessage(err, "key rotation failed")
+	}
+
+	rotatedAt := time.Now().UTC()
+	rotation := &KeyRotation{
+		UserName:    user.Name(),
+		MspID:       im.orgMspID,
+		RetiredSKI:  user.PrivateKey().SKI(),
+		RetiredCert: user.EnrollmentCertificate(),
+		SKI:         key.SKI(),
+		Cert:        cert,
+		RotatedAt:   rotatedAt,
+		RetainUntil: rotatedAt.Add(im.keyRetention),
+	}
+
+	// The history is written first so that a stored user never has an unrecorded
+	// retired key. If storing the user fails then the history is restored.
+	history, err := im.loadRotationHistory(user.Name())
+	if err != nil {
+		return nil, err
+	}
+	if err := im.storeRotationHistory(user.Name(), append(history, rotation)); err != nil {
+		return nil, err
+	}
+
+	rotated := identity.NewUser(im.orgMspID, user.Name())
+	rotated.SetEnrollmentCertificate(cert)
+	rotated.SetPrivateKey(key)
+	if err := im.userStore.Store(rotated); err != nil {
+		if rerr := im.storeRotationHistory(user.Name(), history); rerr != nil {
+			im.logger().Errorf("Restoring key rotation history of user [%s] failed: %s", user.Name(), rerr)
+		}
+		return nil, errors.Wrap(err, "storing rotated user failed")
+	}
+
+	im.logger().Infof("Rotated key of user [%s]: retired key [%x], new key [%x]", user.Name(), rotation.RetiredSKI, rotation.SKI)
+	return rotation, nil
+}
+
+// PurgeRetiredKeys removes the retired keys of userName from the key store.
+// Keys that are still retained for in-flight transactions are left for a later purge.
+// Returns the rotations whose keys were purged.
+func (im *IdentityManager) PurgeRetiredKeys(userName string) ([]*KeyRotation, error) {
+	im.rotationMutex.Lock()
+	defer im.rotationMutex.Unlock()
+
+	history, err := im.loadRotationHistory(userName)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+	current := history[len(history)-1].SKI
+
+	now := time.Now().UTC()
+	var purged []*KeyRotation
+	for _, rotation := range history {
+		if rotation.PurgedAt != nil || bytes.Equal(rotation.RetiredSKI, current) {
+			continue
+		}
+		if now.Before(rotation.RetainUntil) {
+			im.logger().Debugf("Retired key [%x] of user [%s] is retained until %s, not purging", rotation.RetiredSKI, userName, rotation.RetainUntil)
+			continue
+		}
+		if err := im.deleteKey(rotation.RetiredSKI); err != nil {
+			return nil, err
+		}
+		purgedAt := now
+		rotation.PurgedAt = &purgedAt
+		purged = append(purged, rotation)
+	}
+
+	if len(purged) > 0 {
+		if err := im.storeRotationHistory(userName, history); err != nil {
+			return nil, err
+		}
+	}
+	return purged, nil
+}
+
+// KeyRotationHistory returns the key rotations of userName, oldest first
+func (im *IdentityManager) KeyRotationHistory(userName string) ([]*KeyRotation, error) {
+	im.rotationMutex.Lock()
+	defer im.rotationMutex.Unlock()
+
+	return im.loadRotationHistory(userName)
+}
+
+// deleteKey removes a private key from the key store of the crypto suite
+func (im *IdentityManager) deleteKey(ski []byte) error {
+	deleter, ok := im.cryptoSuite.(core.KeyDeleter)
+	if !ok {
+		return errors.New("crypto suite does not support deleting keys")
+	}
+	return errors.WithMessage(deleter.DeleteKey(ski), "deleting retired key failed")
+}
+
+func rotationHistoryKey(mspID string, userName string) string {
+	return "rotations/" + userName + "@" + mspID + ".json"
+}
+
+func (im *IdentityManager) loadRotationHistory(userName string) ([]*KeyRotation, error) {
+	value, err := im.rotationStore.Load(rotationHistoryKey(im.orgMspID, userName))
+	if err != nil {
+		if err == contextApi.ErrNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "loading key rotation history failed")
+	}
+	valueBytes, ok := value.([]byte)
+	if !ok {
+		return nil, errors.New("key rotation history is not []byte")
+	}
+	var history []*KeyRotation
+	if err := json.Unmarshal(valueBytes, &history); err != nil {
+		return nil, errors.Wrap(err, "unmarshal key rotation history failed")
+	}
+	return history, nil
+}
+
+func (im *IdentityManager) storeRotationHistory(userName string, history []*KeyRotation) error {
+	valueBytes, err := json.Marshal(history)
+	if err != nil {
+		return errors.Wrap(err, "marshal key rotation history failed")
+	}
+	err = im.rotationStore.Store(rotationHistoryKey(im.orgMspID, userName), valueBytes)
+	return errors.Wrap(err, "storing key rotation history failed")
+}
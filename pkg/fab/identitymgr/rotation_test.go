@@ -0,0 +1,180 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identitymgr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	contextApi "github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/identitymgr/mocks"
+)
+
+func TestRotateKey(t *testing.T) {
+	identityManager, err := New(org1, fullConfig, cryptoSuite, nil)
+	if err != nil {
+		t.Fatalf("NewidentityManagerClient return error: %v", err)
+	}
+
+	if _, err := identityManager.RotateKey(nil); err == nil {
+		t.Fatalf("Expected error with nil user")
+	}
+
+	// Persistent key, so that purging can be verified
+	key, err := cryptoSuite.KeyGen(cryptosuite.GetECDSAP256KeyGenOpts(false))
+	if err != nil {
+		t.Fatalf("KeyGen return error %v", err)
+	}
+	retiredKeyFile := filepath.Join(fullConfig.KeyStorePath(), hex.EncodeToString(key.SKI())+"_sk")
+	if _, err := os.Stat(retiredKeyFile); err != nil {
+		t.Fatalf("Expected key in key store: %v", err)
+	}
+
+	user := mocks.NewMockUser("rotationUser")
+	user.SetEnrollmentCertificate(readCert(t))
+	user.SetPrivateKey(key)
+
+	// Don't retain the retired key, so that it can be purged straight away
+	identityManager.keyRetention = 0
+
+	rotation, err := identityManager.RotateKey(user)
+	if err != nil {
+		t.Fatalf("RotateKey return error %v", err)
+	}
+	if !bytes.Equal(rotation.RetiredSKI, key.SKI()) || bytes.Equal(rotation.SKI, key.SKI()) {
+		t.Fatalf("Expected a new key to replace the retired key")
+	}
+	if !bytes.Equal(rotation.RetiredCert, user.EnrollmentCertificate()) || len(rotation.Cert) == 0 {
+		t.Fatalf("Expected rotation to record retired and new certificates")
+	}
+
+	// History survives the identity manager
+	identityManager, err = New(org1, fullConfig, cryptoSuite, nil)
+	if err != nil {
+		t.Fatalf("NewidentityManagerClient return error: %v", err)
+	}
+	history, err := identityManager.KeyRotationHistory("rotationUser")
+	if err != nil {
+		t.Fatalf("KeyRotationHistory return error %v", err)
+	}
+	if len(history) != 1 || !bytes.Equal(history[0].SKI, rotation.SKI) || history[0].PurgedAt != nil {
+		t.Fatalf("Unexpected rotation history: %+v", history)
+	}
+
+	purged, err := identityManager.PurgeRetiredKeys("rotationUser")
+	if err != nil {
+		t.Fatalf("PurgeRetiredKeys return error %v", err)
+	}
+	if len(purged) != 1 || purged[0].PurgedAt == nil {
+		t.Fatalf("Expected retired key to be purged: %+v", purged)
+	}
+	if _, err := os.Stat(retiredKeyFile); !os.IsNotExist(err) {
+		t.Fatalf("Expected retired key to be removed from key store: %v", err)
+	}
+
+	history, err = identityManager.KeyRotationHistory("rotationUser")
+	if err != nil {
+		t.Fatalf("KeyRotationHistory return error %v", err)
+	}
+	if len(history) != 1 || history[0].PurgedAt == nil {
+		t.Fatalf("Expected purge to be recorded in history: %+v", history)
+	}
+
+	purged, err = identityManager.PurgeRetiredKeys("rotationUser")
+	if err != nil || len(purged) != 0 {
+		t.Fatalf("Expected nothing left to purge, got %v, %v", purged, err)
+	}
+}
+
+func TestPurgeRetainedKey(t *testing.T) {
+	identityManager, err := New(org1, fullConfig, cryptoSuite, nil)
+	if err != nil {
+		t.Fatalf("NewidentityManagerClient return error: %v", err)
+	}
+	identityManager.keyRetention = 500 * time.Millisecond
+
+	key, err := cryptoSuite.KeyGen(cryptosuite.GetECDSAP256KeyGenOpts(false))
+	if err != nil {
+		t.Fatalf("KeyGen return error %v", err)
+	}
+	retiredKeyFile := filepath.Join(fullConfig.KeyStorePath(), hex.EncodeToString(key.SKI())+"_sk")
+
+	user := mocks.NewMockUser("retentionUser")
+	user.SetEnrollmentCertificate(readCert(t))
+	user.SetPrivateKey(key)
+
+	rotation, err := identityManager.RotateKey(user)
+	if err != nil {
+		t.Fatalf("RotateKey return error %v", err)
+	}
+	if !rotation.RetainUntil.Equal(rotation.RotatedAt.Add(identityManager.keyRetention)) {
+		t.Fatalf("Expected retired key to be retained for %s: %+v", identityManager.keyRetention, rotation)
+	}
+
+	// The retired key may still be signing in-flight transactions
+	purged, err := identityManager.PurgeRetiredKeys("retentionUser")
+	if err != nil || len(purged) != 0 {
+		t.Fatalf("Expected retained key not to be purged, got %v, %v", purged, err)
+	}
+	if _, err := os.Stat(retiredKeyFile); err != nil {
+		t.Fatalf("Expected retained key in key store: %v", err)
+	}
+
+	time.Sleep(time.Until(rotation.RetainUntil))
+
+	purged, err = identityManager.PurgeRetiredKeys("retentionUser")
+	if err != nil || len(purged) != 1 {
+		t.Fatalf("Expected retired key to be purged after retention, got %v, %v", purged, err)
+	}
+	if _, err := os.Stat(retiredKeyFile); !os.IsNotExist(err) {
+		t.Fatalf("Expected retired key to be removed from key store: %v", err)
+	}
+}
+
+func TestRotateKeyRestoresHistory(t *testing.T) {
+	identityManager, err := New(org1, fullConfig, cryptoSuite, nil)
+	if err != nil {
+		t.Fatalf("NewidentityManagerClient return error: %v", err)
+	}
+	identityManager.userStore = &failingUserStore{}
+
+	key, err := cryptoSuite.KeyGen(cryptosuite.GetECDSAP256KeyGenOpts(true))
+	if err != nil {
+		t.Fatalf("KeyGen return error %v", err)
+	}
+	user := mocks.NewMockUser("failedRotationUser")
+	user.SetEnrollmentCertificate(readCert(t))
+	user.SetPrivateKey(key)
+
+	if _, err := identityManager.RotateKey(user); err == nil {
+		t.Fatalf("Expected RotateKey to fail when the user can't be stored")
+	}
+	history, err := identityManager.KeyRotationHistory("failedRotationUser")
+	if err != nil {
+		t.Fatalf("KeyRotationHistory return error %v", err)
+	}
+	if len(history) != 0 {
+		t.Fatalf("Expected failed rotation not to be recorded: %+v", history)
+	}
+}
+
+type failingUserStore struct{}
+
+func (s *failingUserStore) Store(contextApi.User) error {
+	return errors.New("store failed")
+}
+
+func (s *failingUserStore) Load(contextApi.UserKey) (contextApi.User, error) {
+	return nil, contextApi.ErrNotFound
+}
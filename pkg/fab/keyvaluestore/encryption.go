@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// EncryptingMarshaller wraps a Marshaller so that marshalled values are sealed
+// with AES-GCM using the given key (16, 24 or 32 bytes long).
+// The random nonce is prepended to the ciphertext.
+// If marshaller is nil, default Marshaller is used.
+func EncryptingMarshaller(key []byte, marshaller Marshaller) (Marshaller, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if marshaller == nil {
+		marshaller = defaultMarshaller
+	}
+	return func(value interface{}) ([]byte, error) {
+		plaintext, err := marshaller(value)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, errors.Wrap(err, "generating nonce failed")
+		}
+		return aead.Seal(nonce, nonce, plaintext, nil), nil
+	}, nil
+}
+
+// DecryptingUnmarshaller wraps an Unmarshaller so that values sealed by
+// EncryptingMarshaller (with the same key) are opened before being unmarshalled.
+// If unmarshaller is nil, default Unmarshaller is used.
+func DecryptingUnmarshaller(key []byte, unmarshaller Unmarshaller) (Unmarshaller, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if unmarshaller == nil {
+		unmarshaller = defaultUnmarshaller
+	}
+	return func(value []byte) (interface{}, error) {
+		if len(value) < aead.NonceSize() {
+			return nil, errors.New("encrypted value is too short")
+		}
+		nonce, ciphertext := value[:aead.NonceSize()], value[aead.NonceSize():]
+		plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "decrypting value failed")
+		}
+		return unmarshaller(plaintext)
+	}, nil
+}
+
+// NewEncryptedFileKeyValueStore creates a FileKeyValueStore whose files are
+// encrypted with AES-GCM using the given key. Marshaller and Unmarshaller from
+// opts, if any, are applied to the plaintext.
+func NewEncryptedFileKeyValueStore(opts *FileKeyValueStoreOptions, key []byte) (*FileKeyValueStore, error) {
+	if opts == nil {
+		return nil, errors.New("FileKeyValueStoreOptions is nil")
+	}
+	marshaller, err := EncryptingMarshaller(key, opts.Marshaller)
+	if err != nil {
+		return nil, err
+	}
+	unmarshaller, err := DecryptingUnmarshaller(key, opts.Unmarshaller)
+	if err != nil {
+		return nil, err
+	}
+	return New(&FileKeyValueStoreOptions{
+		Path:          opts.Path,
+		KeySerializer: opts.KeySerializer,
+		Marshaller:    marshaller,
+		Unmarshaller:  unmarshaller,
+	})
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating AES cipher failed")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating GCM failed")
+	}
+	return aead, nil
+}
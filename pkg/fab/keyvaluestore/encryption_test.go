@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+var testEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncryptedFKVS(t *testing.T) {
+	if err := cleanup(storePath); err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer cleanup(storePath)
+
+	store, err := NewEncryptedFileKeyValueStore(&FileKeyValueStoreOptions{Path: storePath}, testEncryptionKey)
+	if err != nil {
+		t.Fatalf("NewEncryptedFileKeyValueStore failed [%s]", err)
+	}
+	testKVS(t, store)
+
+	value := []byte("secret value")
+	if err := store.Store("secret", value); err != nil {
+		t.Fatalf("Store failed [%s]", err)
+	}
+	fileBytes, err := ioutil.ReadFile(path.Join(storePath, "secret"))
+	if err != nil {
+		t.Fatalf("reading store file failed [%s]", err)
+	}
+	if bytes.Contains(fileBytes, value) {
+		t.Fatal("value should not be stored in plain text")
+	}
+
+	// A store with a different key can't read the value
+	otherStore, err := NewEncryptedFileKeyValueStore(&FileKeyValueStoreOptions{Path: storePath}, []byte("fedcba9876543210"))
+	if err != nil {
+		t.Fatalf("NewEncryptedFileKeyValueStore failed [%s]", err)
+	}
+	if _, err := otherStore.Load("secret"); err == nil {
+		t.Fatal("loading a value encrypted with another key should fail")
+	}
+}
+
+func TestEncryptedMemKVS(t *testing.T) {
+	marshaller, err := EncryptingMarshaller(testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("EncryptingMarshaller failed [%s]", err)
+	}
+	unmarshaller, err := DecryptingUnmarshaller(testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("DecryptingUnmarshaller failed [%s]", err)
+	}
+	testKVS(t, NewMemKeyValueStore(&MemKeyValueStoreOptions{Marshaller: marshaller, Unmarshaller: unmarshaller}))
+}
+
+func TestEncryptionInvalidKey(t *testing.T) {
+	if _, err := EncryptingMarshaller([]byte("short"), nil); err == nil {
+		t.Fatal("EncryptingMarshaller should fail for an invalid key size")
+	}
+	if _, err := DecryptingUnmarshaller(nil, nil); err == nil {
+		t.Fatal("DecryptingUnmarshaller should fail for an invalid key size")
+	}
+	if _, err := NewEncryptedFileKeyValueStore(nil, testEncryptionKey); err == nil {
+		t.Fatal("NewEncryptedFileKeyValueStore should fail for nil options")
+	}
+
+	unmarshaller, err := DecryptingUnmarshaller(testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("DecryptingUnmarshaller failed [%s]", err)
+	}
+	if _, err := unmarshaller([]byte("x")); err == nil {
+		t.Fatal("unmarshalling a truncated value should fail")
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+	"github.com/pkg/errors"
+)
+
+// MemKeyValueStore stores values in memory. Nothing is persisted,
+// so the contents are lost when the process exits.
+// KeySerializer maps a key to a unique string; Marshaller and Unmarshaller
+// are applied to values so that stored values are copies rather than
+// references held by the caller.
+type MemKeyValueStore struct {
+	mutex         sync.RWMutex
+	values        map[string][]byte
+	keySerializer KeySerializer
+	marshaller    Marshaller
+	unmarshaller  Unmarshaller
+}
+
+// MemKeyValueStoreOptions allow overriding store defaults
+type MemKeyValueStoreOptions struct {
+	// Optional. If not provided, default key serializer is used.
+	KeySerializer KeySerializer
+	// Optional. If not provided, default Marshaller is used.
+	Marshaller Marshaller
+	// Optional. If not provided, default Unmarshaller is used.
+	Unmarshaller Unmarshaller
+}
+
+// Default key serializer for stores that are not backed by a file system
+func defaultKeySerializer(key interface{}) (string, error) {
+	keyString, ok := key.(string)
+	if !ok {
+		return "", errors.New("converting key to string failed")
+	}
+	return keyString, nil
+}
+
+// NewMemKeyValueStore creates a new instance of MemKeyValueStore using provided options.
+// opts may be nil, in which case defaults are used.
+func NewMemKeyValueStore(opts *MemKeyValueStoreOptions) *MemKeyValueStore {
+	if opts == nil {
+		opts = &MemKeyValueStoreOptions{}
+	}
+	if opts.KeySerializer == nil {
+		opts.KeySerializer = defaultKeySerializer
+	}
+	if opts.Marshaller == nil {
+		opts.Marshaller = defaultMarshaller
+	}
+	if opts.Unmarshaller == nil {
+		opts.Unmarshaller = defaultUnmarshaller
+	}
+	return &MemKeyValueStore{
+		values:        make(map[string][]byte),
+		keySerializer: opts.KeySerializer,
+		marshaller:    opts.Marshaller,
+		unmarshaller:  opts.Unmarshaller,
+	}
+}
+
+// Load returns the value stored in the store for a key.
+// If a value for the key was not found, returns (nil, ErrNotFound)
+func (m *MemKeyValueStore) Load(key interface{}) (interface{}, error) {
+	k, err := m.keySerializer(key)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.RLock()
+	valueBytes, ok := m.values[k]
+	m.mutex.RUnlock()
+
+	if !ok {
+		return nil, api.ErrNotFound
+	}
+	return m.unmarshaller(copyBytes(valueBytes))
+}
+
+// Store sets the value for the key.
+func (m *MemKeyValueStore) Store(key interface{}, value interface{}) error {
+	if key == nil {
+		return errors.New("key is nil")
+	}
+	if value == nil {
+		return errors.New("value is nil")
+	}
+	k, err := m.keySerializer(key)
+	if err != nil {
+		return err
+	}
+	valueBytes, err := m.marshaller(value)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.values[k] = copyBytes(valueBytes)
+	return nil
+}
+
+// Delete deletes the value for a key.
+func (m *MemKeyValueStore) Delete(key interface{}) error {
+	if key == nil {
+		return errors.New("key is nil")
+	}
+	k, err := m.keySerializer(key)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.values, k)
+	return nil
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
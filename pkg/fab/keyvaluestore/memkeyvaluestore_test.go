@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+)
+
+func TestMemKVS(t *testing.T) {
+	testKVS(t, NewMemKeyValueStore(nil))
+}
+
+func TestMemKVSValueIsCopied(t *testing.T) {
+	store := NewMemKeyValueStore(nil)
+
+	value := []byte("value")
+	if err := store.Store("key", value); err != nil {
+		t.Fatalf("Store failed [%s]", err)
+	}
+	value[0] = 'X'
+
+	if err := checkValue(store, "key", []byte("value")); err != nil {
+		t.Fatalf("stored value was modified through caller's slice [%s]", err)
+	}
+}
+
+// testKVS exercises the api.KVStore contract against any store
+// using the default key serializer and marshallers
+func testKVS(t *testing.T, store api.KVStore) {
+	err := store.Store(nil, []byte("1234"))
+	if err == nil || err.Error() != "key is nil" {
+		t.Fatal("Store(nil, ...) should throw error")
+	}
+	err = store.Store("key", nil)
+	if err == nil || err.Error() != "value is nil" {
+		t.Fatal("Store(..., nil) should throw error")
+	}
+	err = store.Store(1, []byte("1234"))
+	if err == nil {
+		t.Fatal("Store with a non-string key should throw error")
+	}
+
+	key1 := "key1"
+	value1 := []byte("value1")
+	key2 := "key2"
+	value2 := []byte("value2")
+	if err := store.Store(key1, value1); err != nil {
+		t.Fatalf("Store %s failed [%s]", key1, err)
+	}
+	if err := store.Store(key2, value2); err != nil {
+		t.Fatalf("Store %s failed [%s]", key2, err)
+	}
+	if err := checkValue(store, key1, value1); err != nil {
+		t.Fatalf("checkValue %s failed [%s]", key1, err)
+	}
+	if err := checkValue(store, key2, value2); err != nil {
+		t.Fatalf("checkValue %s failed [%s]", key2, err)
+	}
+
+	// Overwrite
+	value1b := []byte("value1b")
+	if err := store.Store(key1, value1b); err != nil {
+		t.Fatalf("Store %s failed [%s]", key1, err)
+	}
+	if err := checkValue(store, key1, value1b); err != nil {
+		t.Fatalf("checkValue %s failed [%s]", key1, err)
+	}
+
+	// Delete
+	if err := store.Delete(key1); err != nil {
+		t.Fatalf("Delete %s failed [%s]", key1, err)
+	}
+	if err := checkValue(store, key1, nil); err != nil {
+		t.Fatalf("checkValue %s failed [%s]", key1, err)
+	}
+	if err := store.Delete(key1); err != nil {
+		t.Fatalf("Deleting a non-existing key shouldn't fail [%s]", err)
+	}
+	if err := checkValue(store, key2, value2); err != nil {
+		t.Fatalf("checkValue %s failed [%s]", key2, err)
+	}
+
+	// Check non-existing key
+	_, err = store.Load("non-existing")
+	if err != api.ErrNotFound {
+		t.Fatal("fetching value for non-existing key should return ErrNotFound")
+	}
+
+	// Check empty string value
+	if err := store.Store("empty-string", []byte("")); err != nil {
+		t.Fatal("setting an empty string value shouldn't fail")
+	}
+	if err := checkValue(store, "empty-string", []byte("")); err != nil {
+		t.Fatalf("checkValue empty-string failed [%s]", err)
+	}
+}
+
+func checkValue(store api.KVStore, key interface{}, expected []byte) error {
+	v, err := store.Load(key)
+	if err != nil {
+		if err == api.ErrNotFound && expected == nil {
+			return nil
+		}
+		return err
+	}
+	return compare(v, expected)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSQLTable      = "fabric_sdk_kvstore"
+	defaultSQLBinaryType = "BLOB"
+)
+
+var validTableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PlaceholderFormat returns the bind parameter placeholder for the n-th (1-based)
+// parameter of a statement, e.g. "?" for SQLite and MySQL or "$1" for PostgreSQL.
+type PlaceholderFormat func(n int) string
+
+// QuestionPlaceholder is the PlaceholderFormat used by SQLite and MySQL drivers
+func QuestionPlaceholder(n int) string {
+	return "?"
+}
+
+// DollarPlaceholder is the PlaceholderFormat used by PostgreSQL drivers
+func DollarPlaceholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+// SQLKeyValueStore stores each value as a row in a database/sql table.
+// The table has two columns: a text primary key (derived from the key by
+// KeySerializer) and a binary value (produced by Marshaller).
+// The SQL driver must be registered by the application (e.g. by a blank import).
+type SQLKeyValueStore struct {
+	db            *sql.DB
+	loadStmt      string
+	insertStmt    string
+	deleteStmt    string
+	keySerializer KeySerializer
+	marshaller    Marshaller
+	unmarshaller  Unmarshaller
+}
+
+// SQLKeyValueStoreOptions allow overriding store defaults
+type SQLKeyValueStoreOptions struct {
+	// Database handle, mandatory
+	DB *sql.DB
+	// Optional. Table holding the values. If not provided, "fabric_sdk_kvstore" is used.
+	Table string
+	// Optional. If not provided, the table is created when missing.
+	SkipCreateTable bool
+	// Optional. If not provided, QuestionPlaceholder is used.
+	Placeholder PlaceholderFormat
+	// Optional. Column type of the values when the table is created, e.g. "BYTEA" for
+	// PostgreSQL. If not provided, "BLOB" is used.
+	BinaryType string
+	// Optional. If not provided, default key serializer is used.
+	KeySerializer KeySerializer
+	// Optional. If not provided, default Marshaller is used.
+	Marshaller Marshaller
+	// Optional. If not provided, default Unmarshaller is used.
+	Unmarshaller Unmarshaller
+}
+
+// NewSQLKeyValueStore creates a new instance of SQLKeyValueStore using provided options
+func NewSQLKeyValueStore(opts *SQLKeyValueStoreOptions) (*SQLKeyValueStore, error) {
+	if opts == nil {
+		return nil, errors.New("SQLKeyValueStoreOptions is nil")
+	}
+	if opts.DB == nil {
+		return nil, errors.New("SQLKeyValueStore database is nil")
+	}
+	table := opts.Table
+	if table == "" {
+		table = defaultSQLTable
+	}
+	if !validTableName.MatchString(table) {
+		return nil, errors.Errorf("invalid SQLKeyValueStore table name [%s]", table)
+	}
+	placeholder := opts.Placeholder
+	if placeholder == nil {
+		placeholder = QuestionPlaceholder
+	}
+	binaryType := opts.BinaryType
+	if binaryType == "" {
+		binaryType = defaultSQLBinaryType
+	}
+	keySerializer := opts.KeySerializer
+	if keySerializer == nil {
+		keySerializer = defaultKeySerializer
+	}
+	marshaller := opts.Marshaller
+	if marshaller == nil {
+		marshaller = defaultMarshaller
+	}
+	unmarshaller := opts.Unmarshaller
+	if unmarshaller == nil {
+		unmarshaller = defaultUnmarshaller
+	}
+
+	if !opts.SkipCreateTable {
+		if _, err := opts.DB.Exec(createTableStmt(table, binaryType)); err != nil {
+			return nil, errors.Wrapf(err, "creating table [%s] failed", table)
+		}
+	}
+
+	return &SQLKeyValueStore{
+		db:            opts.DB,
+		loadStmt:      fmt.Sprintf("SELECT v FROM %s WHERE k = %s", table, placeholder(1)),
+		insertStmt:    fmt.Sprintf("INSERT INTO %s (k, v) VALUES (%s, %s)", table, placeholder(1), placeholder(2)),
+		deleteStmt:    fmt.Sprintf("DELETE FROM %s WHERE k = %s", table, placeholder(1)),
+		keySerializer: keySerializer,
+		marshaller:    marshaller,
+		unmarshaller:  unmarshaller,
+	}, nil
+}
+
+func createTableStmt(table, binaryType string) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (k VARCHAR(512) NOT NULL PRIMARY KEY, v %s)", table, binaryType)
+}
+
+// Load returns the value stored in the store for a key.
+// If a value for the key was not found, returns (nil, ErrNotFound)
+func (s *SQLKeyValueStore) Load(key interface{}) (interface{}, error) {
+	k, err := s.keySerializer(key)
+	if err != nil {
+		return nil, err
+	}
+	var valueBytes []byte
+	err = s.db.QueryRow(s.loadStmt, k).Scan(&valueBytes)
+	if err == sql.ErrNoRows {
+		return nil, api.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading key [%s] failed", k)
+	}
+	if valueBytes == nil {
+		valueBytes = []byte{}
+	}
+	return s.unmarshaller(valueBytes)
+}
+
+// Store sets the value for the key.
+func (s *SQLKeyValueStore) Store(key interface{}, value interface{}) error {
+	if key == nil {
+		return errors.New("key is nil")
+	}
+	if value == nil {
+		return errors.New("value is nil")
+	}
+	k, err := s.keySerializer(key)
+	if err != nil {
+		return err
+	}
+	valueBytes, err := s.marshaller(value)
+	if err != nil {
+		return err
+	}
+	if valueBytes == nil {
+		valueBytes = []byte{}
+	}
+
+	// Delete followed by insert, within a transaction, works across SQL dialects
+	// that disagree on upsert syntax.
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction failed")
+	}
+	if _, err := tx.Exec(s.deleteStmt, k); err != nil {
+		tx.Rollback()
+		return errors.Wrapf(err, "replacing key [%s] failed", k)
+	}
+	if _, err := tx.Exec(s.insertStmt, k, valueBytes); err != nil {
+		tx.Rollback()
+		return errors.Wrapf(err, "storing key [%s] failed", k)
+	}
+	return errors.Wrap(tx.Commit(), "commit transaction failed")
+}
+
+// Delete deletes the value for a key.
+func (s *SQLKeyValueStore) Delete(key interface{}) error {
+	if key == nil {
+		return errors.New("key is nil")
+	}
+	k, err := s.keySerializer(key)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(s.deleteStmt, k); err != nil {
+		return errors.Wrapf(err, "deleting key [%s] failed", k)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// +build sqlite
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// The SQL store tests use the cgo sqlite3 driver, which isn't part of the default
+// build. Run them with: go test -tags sqlite
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("opening sqlite3 database failed [%s]", err)
+	}
+	// Each connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	return db
+}
+
+func TestSQLKVS(t *testing.T) {
+	db := newTestDB(t)
+	defer db.Close()
+
+	store, err := NewSQLKeyValueStore(&SQLKeyValueStoreOptions{DB: db})
+	if err != nil {
+		t.Fatalf("NewSQLKeyValueStore failed [%s]", err)
+	}
+	testKVS(t, store)
+}
+
+func TestSQLKVSSharedTable(t *testing.T) {
+	db := newTestDB(t)
+	defer db.Close()
+
+	store1, err := NewSQLKeyValueStore(&SQLKeyValueStoreOptions{DB: db, Table: "users"})
+	if err != nil {
+		t.Fatalf("NewSQLKeyValueStore failed [%s]", err)
+	}
+	if err := store1.Store("key", []byte("value")); err != nil {
+		t.Fatalf("Store failed [%s]", err)
+	}
+
+	// A second store over an existing table sees the same values
+	store2, err := NewSQLKeyValueStore(&SQLKeyValueStoreOptions{DB: db, Table: "users"})
+	if err != nil {
+		t.Fatalf("NewSQLKeyValueStore failed [%s]", err)
+	}
+	if err := checkValue(store2, "key", []byte("value")); err != nil {
+		t.Fatalf("checkValue failed [%s]", err)
+	}
+}
+
+func TestSQLKVSTableValidation(t *testing.T) {
+	db := newTestDB(t)
+	defer db.Close()
+
+	_, err := NewSQLKeyValueStore(&SQLKeyValueStoreOptions{DB: db, Table: "users; DROP TABLE x"})
+	if err == nil {
+		t.Fatal("table name validation on NewSQLKeyValueStore is not working as expected")
+	}
+
+	_, err = NewSQLKeyValueStore(&SQLKeyValueStoreOptions{DB: db, Table: "missing", SkipCreateTable: true})
+	if err != nil {
+		t.Fatalf("creating a store without creating the table shouldn't fail [%s]", err)
+	}
+}
+
+func TestNewFromConfigSQLite(t *testing.T) {
+	storeConfig := core.CredentialStoreType{
+		Type: SQLStoreType,
+		SQL: core.CredentialStoreSQL{
+			Driver:     "sqlite3",
+			DataSource: ":memory:",
+		},
+	}
+	store, err := newStoreFromConfig(t, storeConfig)
+	if err != nil {
+		t.Fatalf("NewFromConfig failed [%s]", err)
+	}
+	if _, ok := store.(*SQLKeyValueStore); !ok {
+		t.Fatal("expecting an SQL store")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"testing"
+)
+
+func TestCreateNewSQLKeyValueStore(t *testing.T) {
+	_, err := NewSQLKeyValueStore(nil)
+	if err == nil || err.Error() != "SQLKeyValueStoreOptions is nil" {
+		t.Fatal("options validation on NewSQLKeyValueStore is not working as expected")
+	}
+
+	_, err = NewSQLKeyValueStore(&SQLKeyValueStoreOptions{})
+	if err == nil || err.Error() != "SQLKeyValueStore database is nil" {
+		t.Fatal("database validation on NewSQLKeyValueStore is not working as expected")
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	if QuestionPlaceholder(2) != "?" {
+		t.Fatal("unexpected question placeholder")
+	}
+	if DollarPlaceholder(2) != "$2" {
+		t.Fatal("unexpected dollar placeholder")
+	}
+}
+
+func TestCreateTableStmt(t *testing.T) {
+	tests := []struct {
+		driver      string
+		expected    string
+		placeholder string
+	}{
+		{"sqlite3", "CREATE TABLE IF NOT EXISTS kv (k VARCHAR(512) NOT NULL PRIMARY KEY, v BLOB)", "?"},
+		{"mysql", "CREATE TABLE IF NOT EXISTS kv (k VARCHAR(512) NOT NULL PRIMARY KEY, v BLOB)", "?"},
+		{"postgres", "CREATE TABLE IF NOT EXISTS kv (k VARCHAR(512) NOT NULL PRIMARY KEY, v BYTEA)", "$1"},
+		{"pgx", "CREATE TABLE IF NOT EXISTS kv (k VARCHAR(512) NOT NULL PRIMARY KEY, v BYTEA)", "$1"},
+	}
+	for _, test := range tests {
+		placeholder, binaryType := sqlDialect(test.driver)
+		if stmt := createTableStmt("kv", binaryType); stmt != test.expected {
+			t.Fatalf("unexpected DDL for driver [%s]: %s", test.driver, stmt)
+		}
+		if p := placeholder(1); p != test.placeholder {
+			t.Fatalf("unexpected placeholder for driver [%s]: %s", test.driver, p)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FileStoreType stores each value in a separate file under client.credentialStore.path
+	FileStoreType = "file"
+	// MemoryStoreType stores values in memory only
+	MemoryStoreType = "memory"
+	// SQLStoreType stores values in a database/sql table
+	SQLStoreType = "sql"
+)
+
+// NewFromConfig creates the KVStore selected by client.credentialStore.type.
+// Values are encrypted when client.credentialStore.encryption provides a key.
+func NewFromConfig(config core.Config) (api.KVStore, error) {
+	clientConfig, err := config.Client()
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to retrieve client config")
+	}
+	storeConfig := clientConfig.CredentialStore
+
+	var marshaller Marshaller
+	var unmarshaller Unmarshaller
+
+	key, err := encryptionKey(&storeConfig.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		if marshaller, err = EncryptingMarshaller(key, nil); err != nil {
+			return nil, err
+		}
+		if unmarshaller, err = DecryptingUnmarshaller(key, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	switch strings.ToLower(storeConfig.Type) {
+	case "", FileStoreType:
+		return New(&FileKeyValueStoreOptions{
+			Path:         storeConfig.Path,
+			Marshaller:   marshaller,
+			Unmarshaller: unmarshaller,
+		})
+	case MemoryStoreType:
+		return NewMemKeyValueStore(&MemKeyValueStoreOptions{
+			Marshaller:   marshaller,
+			Unmarshaller: unmarshaller,
+		}), nil
+	case SQLStoreType:
+		return newSQLStoreFromConfig(&storeConfig.SQL, marshaller, unmarshaller)
+	default:
+		return nil, errors.Errorf("unsupported credential store type [%s]", storeConfig.Type)
+	}
+}
+
+func newSQLStoreFromConfig(sqlConfig *core.CredentialStoreSQL, marshaller Marshaller, unmarshaller Unmarshaller) (api.KVStore, error) {
+	if sqlConfig.Driver == "" || sqlConfig.DataSource == "" {
+		return nil, errors.New("credential store SQL driver and data source are required")
+	}
+	db, err := sql.Open(sqlConfig.Driver, sqlConfig.DataSource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s database failed", sqlConfig.Driver)
+	}
+	placeholder, binaryType := sqlDialect(sqlConfig.Driver)
+	store, err := NewSQLKeyValueStore(&SQLKeyValueStoreOptions{
+		DB:           db,
+		Table:        sqlConfig.Table,
+		Placeholder:  placeholder,
+		BinaryType:   binaryType,
+		Marshaller:   marshaller,
+		Unmarshaller: unmarshaller,
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// sqlDialect returns the placeholder format and the binary column type of the driver
+func sqlDialect(driver string) (PlaceholderFormat, string) {
+	switch driver {
+	case "postgres", "pgx":
+		return DollarPlaceholder, "BYTEA"
+	default:
+		return QuestionPlaceholder, defaultSQLBinaryType
+	}
+}
+
+func encryptionKey(encConfig *core.CredentialStoreEncryption) ([]byte, error) {
+	encodedKey := encConfig.Key
+	if encodedKey == "" && encConfig.KeyPath != "" {
+		keyBytes, err := ioutil.ReadFile(encConfig.KeyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading credential store encryption key failed")
+		}
+		encodedKey = string(keyBytes)
+	}
+	encodedKey = strings.TrimSpace(encodedKey)
+	if encodedKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding credential store encryption key failed")
+	}
+	return key, nil
+}
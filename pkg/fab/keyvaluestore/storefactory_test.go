@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core/mocks"
+)
+
+func newStoreFromConfig(t *testing.T, storeConfig core.CredentialStoreType) (interface{}, error) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfig := mock_core.NewMockConfig(mockCtrl)
+	mockConfig.EXPECT().Client().Return(&core.ClientConfig{CredentialStore: storeConfig}, nil)
+
+	return NewFromConfig(mockConfig)
+}
+
+func TestNewFromConfigFile(t *testing.T) {
+	store, err := newStoreFromConfig(t, core.CredentialStoreType{Path: storePath})
+	if err != nil {
+		t.Fatalf("NewFromConfig failed [%s]", err)
+	}
+	if _, ok := store.(*FileKeyValueStore); !ok {
+		t.Fatal("expecting a file store by default")
+	}
+
+	_, err = newStoreFromConfig(t, core.CredentialStoreType{Type: FileStoreType})
+	if err == nil {
+		t.Fatal("file store without a path should fail")
+	}
+}
+
+func TestNewFromConfigMemory(t *testing.T) {
+	store, err := newStoreFromConfig(t, core.CredentialStoreType{Type: "Memory"})
+	if err != nil {
+		t.Fatalf("NewFromConfig failed [%s]", err)
+	}
+	if _, ok := store.(*MemKeyValueStore); !ok {
+		t.Fatal("expecting a memory store")
+	}
+}
+
+func TestNewFromConfigSQL(t *testing.T) {
+	_, err := newStoreFromConfig(t, core.CredentialStoreType{Type: SQLStoreType})
+	if err == nil {
+		t.Fatal("SQL store without a driver should fail")
+	}
+
+	storeConfig := core.CredentialStoreType{
+		Type: SQLStoreType,
+		SQL: core.CredentialStoreSQL{
+			Driver:     "nodriver",
+			DataSource: ":memory:",
+		},
+	}
+	_, err = newStoreFromConfig(t, storeConfig)
+	if err == nil {
+		t.Fatal("SQL store with an unregistered driver should fail")
+	}
+}
+
+func TestNewFromConfigEncrypted(t *testing.T) {
+	if err := cleanup(storePath); err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer cleanup(storePath)
+
+	if err := os.MkdirAll(storePath, newDirMode); err != nil {
+		t.Fatalf("creating store path failed [%s]", err)
+	}
+	keyPath := path.Join(storePath, "store.key")
+	encodedKey := base64.StdEncoding.EncodeToString(testEncryptionKey)
+	if err := ioutil.WriteFile(keyPath, []byte(encodedKey+"\n"), 0600); err != nil {
+		t.Fatalf("writing key file failed [%s]", err)
+	}
+
+	for _, encryption := range []core.CredentialStoreEncryption{{Key: encodedKey}, {KeyPath: keyPath}} {
+		store, err := newStoreFromConfig(t, core.CredentialStoreType{Type: MemoryStoreType, Encryption: encryption})
+		if err != nil {
+			t.Fatalf("NewFromConfig failed [%s]", err)
+		}
+		memStore := store.(*MemKeyValueStore)
+		if err := memStore.Store("key", []byte("value")); err != nil {
+			t.Fatalf("Store failed [%s]", err)
+		}
+		if string(memStore.values["key"]) == "value" {
+			t.Fatal("value should be encrypted")
+		}
+		if err := checkValue(memStore, "key", []byte("value")); err != nil {
+			t.Fatalf("checkValue failed [%s]", err)
+		}
+	}
+
+	_, err := newStoreFromConfig(t, core.CredentialStoreType{Encryption: core.CredentialStoreEncryption{Key: "not base64!"}})
+	if err == nil {
+		t.Fatal("invalid encryption key should fail")
+	}
+}
+
+func TestNewFromConfigUnsupported(t *testing.T) {
+	_, err := newStoreFromConfig(t, core.CredentialStoreType{Type: "unknown"})
+	if err == nil {
+		t.Fatal("unsupported store type should fail")
+	}
+}
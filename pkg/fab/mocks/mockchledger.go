@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// MockChannelLedger is a mock ChannelLedger that holds a block height
+// and a set of committed transactions for each target
+type MockChannelLedger struct {
+	mutex   sync.RWMutex
+	heights map[fab.ProposalProcessor]uint64
+	txs     map[fab.ProposalProcessor]map[fab.TransactionID]pb.TxValidationCode
+}
+
+// NewMockChannelLedger returns a new mock ChannelLedger
+func NewMockChannelLedger() *MockChannelLedger {
+	return &MockChannelLedger{
+		heights: make(map[fab.ProposalProcessor]uint64),
+		txs:     make(map[fab.ProposalProcessor]map[fab.TransactionID]pb.TxValidationCode),
+	}
+}
+
+// SetHeight sets the block height of the target
+func (l *MockChannelLedger) SetHeight(target fab.ProposalProcessor, height uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.heights[target] = height
+}
+
+// CommitTransaction adds the transaction to the target's ledger
+func (l *MockChannelLedger) CommitTransaction(target fab.ProposalProcessor, txID fab.TransactionID, code pb.TxValidationCode) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.txs[target] == nil {
+		l.txs[target] = make(map[fab.TransactionID]pb.TxValidationCode)
+	}
+	l.txs[target][txID] = code
+}
+
+// QueryInfo returns the block height of each target
+func (l *MockChannelLedger) QueryInfo(targets []fab.ProposalProcessor) ([]*common.BlockchainInfo, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	var responses []*common.BlockchainInfo
+	var errs error
+	for _, target := range targets {
+		height, ok := l.heights[target]
+		if !ok {
+			errs = multi.Append(errs, errors.New("unknown target"))
+			continue
+		}
+		responses = append(responses, &common.BlockchainInfo{Height: height})
+	}
+	return responses, errs
+}
+
+// QueryTransaction returns the transaction from each target that has committed it
+func (l *MockChannelLedger) QueryTransaction(transactionID fab.TransactionID, targets []fab.ProposalProcessor) ([]*pb.ProcessedTransaction, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	var responses []*pb.ProcessedTransaction
+	var errs error
+	for _, target := range targets {
+		code, ok := l.txs[target][transactionID]
+		if !ok {
+			errs = multi.Append(errs, errors.Errorf("transaction %s not found", transactionID))
+			continue
+		}
+		responses = append(responses, &pb.ProcessedTransaction{ValidationCode: int32(code)})
+	}
+	return responses, errs
+}
+
+// QueryBlock is not implemented
+func (l *MockChannelLedger) QueryBlock(blockNumber int, targets []fab.ProposalProcessor) ([]*common.Block, error) {
+	return nil, errors.New("not implemented")
+}
+
+// QueryBlockByHash is not implemented
+func (l *MockChannelLedger) QueryBlockByHash(blockHash []byte, targets []fab.ProposalProcessor) ([]*common.Block, error) {
+	return nil, errors.New("not implemented")
+}
+
+// QueryInstantiatedChaincodes is not implemented
+func (l *MockChannelLedger) QueryInstantiatedChaincodes(targets []fab.ProposalProcessor) ([]*pb.ChaincodeQueryResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// QueryConfigBlock is not implemented
+func (l *MockChannelLedger) QueryConfigBlock(targets []fab.ProposalProcessor, minResponses int) (*common.ConfigEnvelope, error) {
+	return nil, errors.New("not implemented")
+}
@@ -24,6 +24,8 @@ type MockChannelService struct {
 	provider   *MockChannelProvider
 	channelID  string
 	transactor fab.Transactor
+	ledger     fab.ChannelLedger
+	ledgerErr  error
 }
 
 // NewMockChannelProvider returns a mock ChannelProvider
@@ -88,7 +90,13 @@ func (cs *MockChannelService) Config() (fab.ChannelConfig, error) {
 	return nil, nil
 }
 
+// SetLedger changes the return values of Ledger
+func (cs *MockChannelService) SetLedger(ledger fab.ChannelLedger, err error) {
+	cs.ledger = ledger
+	cs.ledgerErr = err
+}
+
 // Ledger ...
 func (cs *MockChannelService) Ledger() (fab.ChannelLedger, error) {
-	return nil, nil
+	return cs.ledger, cs.ledgerErr
 }
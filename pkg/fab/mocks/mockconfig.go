@@ -243,6 +243,11 @@ func (c *MockConfig) SecurityProviderLabel() string {
 	return ""
 }
 
+// SecurityProviderConfig ...
+func (c *MockConfig) SecurityProviderConfig(provider string) map[string]interface{} {
+	return nil
+}
+
 //SecurityProviderPin ...
 func (c *MockConfig) SecurityProviderPin() string {
 	return ""
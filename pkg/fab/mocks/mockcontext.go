@@ -18,13 +18,19 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	metricsApi "github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+	tracingApi "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
 )
 
 // MockProviderContext holds core providers to enable mocking.
 type MockProviderContext struct {
-	config         config.Config
-	cryptoSuite    core.CryptoSuite
-	signingManager api.SigningManager
+	config          config.Config
+	cryptoSuite     core.CryptoSuite
+	signingManager  api.SigningManager
+	loggerProvider  logApi.LoggerProvider
+	metricsProvider metricsApi.Provider
+	tracer          tracingApi.Tracer
 }
 
 // NewMockProviderContext creates a MockProviderContext consisting of defaults
@@ -67,6 +73,36 @@ func (pc *MockProviderContext) SigningManager() api.SigningManager {
 	return pc.signingManager
 }
 
+// LoggerProvider returns the mock logger provider (nil unless set).
+func (pc *MockProviderContext) LoggerProvider() logApi.LoggerProvider {
+	return pc.loggerProvider
+}
+
+// SetLoggerProvider sets the mock logger provider.
+func (pc *MockProviderContext) SetLoggerProvider(loggerProvider logApi.LoggerProvider) {
+	pc.loggerProvider = loggerProvider
+}
+
+// MetricsProvider returns the mock metrics provider (nil unless set).
+func (pc *MockProviderContext) MetricsProvider() metricsApi.Provider {
+	return pc.metricsProvider
+}
+
+// SetMetricsProvider sets the mock metrics provider.
+func (pc *MockProviderContext) SetMetricsProvider(metricsProvider metricsApi.Provider) {
+	pc.metricsProvider = metricsProvider
+}
+
+// Tracer returns the mock tracer (nil unless set).
+func (pc *MockProviderContext) Tracer() tracingApi.Tracer {
+	return pc.tracer
+}
+
+// SetTracer sets the mock tracer.
+func (pc *MockProviderContext) SetTracer(tracer tracingApi.Tracer) {
+	pc.tracer = tracer
+}
+
 // MockContext holds core providers and identity to enable mocking.
 type MockContext struct {
 	*MockProviderContext
@@ -99,6 +99,11 @@ func (o *mockOrderer) SendDeliver(envelope *fab.SignedEnvelope) (chan *common.Bl
 	return o.Deliveries, o.DeliveryErrors, func() {}
 }
 
+// SendDeliverContext returns the channels for delivery of prepared mock values and errors (if any)
+func (o *mockOrderer) SendDeliverContext(ctx context.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error, context.CancelFunc) {
+	return o.Deliveries, o.DeliveryErrors, func() {}
+}
+
 func (o *mockOrderer) EnqueueSendBroadcastError(err error) {
 	o.BroadcastErrors <- err
 }
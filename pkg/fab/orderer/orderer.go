@@ -27,10 +27,11 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/urlutil"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
 	"github.com/pkg/errors"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/orderer")
 
 // Orderer allows a client to broadcast a transaction.
 type Orderer struct {
@@ -45,6 +46,7 @@ type Orderer struct {
 	transportCredentials credentials.TransportCredentials
 	secured              bool
 	allowInsecure        bool
+	loggerProvider       logApi.LoggerProvider
 }
 
 // Option describes a functional parameter for the New constructor
@@ -145,6 +147,16 @@ func FromOrdererConfig(ordererCfg *core.OrdererConfig) Option {
 	}
 }
 
+// WithLoggerProvider is a functional option for the orderer.New constructor that configures the
+// logger provider of the orderer (e.g. the logger provider of an SDK instance)
+func WithLoggerProvider(loggerProvider logApi.LoggerProvider) Option {
+	return func(o *Orderer) error {
+		o.loggerProvider = loggerProvider
+
+		return nil
+	}
+}
+
 // FromOrdererName is a functional option for the orderer.New constructor that obtains an apiconfig.OrdererConfig
 // by name from the apiconfig.Config supplied to the constructor, and then constructs a new orderer from it
 func FromOrdererName(name string) Option {
@@ -207,6 +219,11 @@ func (o *Orderer) URL() string {
 	return o.url
 }
 
+// logger returns a logger tagged with the orderer URL
+func (o *Orderer) logger() *logging.Logger {
+	return logger.ForProvider(o.loggerProvider).With(logging.OrdererKey, o.url)
+}
+
 // SendBroadcast Send the created transaction to Orderer.
 func (o *Orderer) SendBroadcast(envelope *fab.SignedEnvelope) (*common.Status, error) {
 	return o.sendBroadcast(envelope, o.secured)
@@ -238,10 +255,10 @@ func (o *Orderer) sendBroadcast(envelope *fab.SignedEnvelope, secured bool) (*co
 		if ok {
 			err = status.NewFromGRPCStatus(rpcStatus)
 		}
-		logger.Error("NewAtomicBroadcastClient failed, cause : ", err)
+		o.logger().Error("NewAtomicBroadcastClient failed, cause : ", err)
 		if secured && o.allowInsecure {
 			//If secured mode failed and allow insecure is enabled then retry in insecure mode
-			logger.Debug("Secured sendBroadcast failed, attempting insecured")
+			o.logger().Debug("Secured sendBroadcast failed, attempting insecured")
 			return o.sendBroadcast(envelope, false)
 		}
 		return nil, errors.Wrap(err, "NewAtomicBroadcastClient failed")
@@ -253,7 +270,7 @@ func (o *Orderer) sendBroadcast(envelope *fab.SignedEnvelope, secured bool) (*co
 	go func() {
 		for {
 			broadcastResponse, err := broadcastStream.Recv()
-			logger.Debugf("Orderer.broadcastStream - response:%v, error:%v\n", broadcastResponse, err)
+			o.logger().Debugf("Orderer.broadcastStream - response:%v, error:%v\n", broadcastResponse, err)
 			if err != nil {
 				rpcStatus, ok := grpcstatus.FromError(err)
 				if ok {
@@ -287,16 +304,29 @@ func (o *Orderer) sendBroadcast(envelope *fab.SignedEnvelope, secured bool) (*co
 }
 
 // SendDeliver sends a deliver request to the ordering service and returns the
-// blocks requested
+// blocks requested. The orderer's connection timeout applies to the whole stream.
 // envelope: contains the seek request for blocks
 func (o *Orderer) SendDeliver(envelope *fab.SignedEnvelope) (chan *common.Block, chan error, grpcContext.CancelFunc) {
-	return o.sendDeliver(envelope, o.secured)
+	ctx, cancel := grpcContext.WithTimeout(grpcContext.Background(), o.dialTimeout)
+	blocks, errs, cancelStream := o.sendDeliver(ctx, envelope, o.secured)
+	return blocks, errs, func() {
+		cancelStream()
+		cancel()
+	}
 }
 
-// SendDeliver sends a deliver request to the ordering service and returns the
-// blocks requested
+// SendDeliverContext sends a deliver request to the ordering service and returns the
+// blocks requested. The orderer's connection timeout only applies to establishing
+// the connection; the stream is bound to the given context.
+// envelope: contains the seek request for blocks
+func (o *Orderer) SendDeliverContext(ctx grpcContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error, grpcContext.CancelFunc) {
+	return o.sendDeliver(ctx, envelope, o.secured)
+}
+
+// sendDeliver sends a deliver request to the ordering service and returns the
+// blocks requested on a stream bound to reqCtx
 // envelope: contains the seek request for blocks
-func (o *Orderer) sendDeliver(envelope *fab.SignedEnvelope, secured bool) (chan *common.Block, chan error, grpcContext.CancelFunc) {
+func (o *Orderer) sendDeliver(reqCtx grpcContext.Context, envelope *fab.SignedEnvelope, secured bool) (chan *common.Block, chan error, grpcContext.CancelFunc) {
 	responses := make(chan *common.Block)
 	errs := make(chan error, 1)
 
@@ -309,10 +339,11 @@ func (o *Orderer) sendDeliver(envelope *fab.SignedEnvelope, secured bool) (chan
 		grpcOpts = append(o.grpcDialOption, grpc.WithInsecure())
 	}
 
-	ctx := grpcContext.Background()
-	ctx, cancel := grpcContext.WithTimeout(ctx, o.dialTimeout)
+	ctx, cancel := grpcContext.WithCancel(reqCtx)
 
-	conn, err := grpc.DialContext(ctx, o.url, grpcOpts...)
+	dialCtx, cancelDial := grpcContext.WithTimeout(ctx, o.dialTimeout)
+	conn, err := grpc.DialContext(dialCtx, o.url, grpcOpts...)
+	cancelDial()
 	if err != nil {
 		errs <- err
 		return responses, errs, cancel
@@ -321,19 +352,19 @@ func (o *Orderer) sendDeliver(envelope *fab.SignedEnvelope, secured bool) (chan
 	// Create atomic broadcast client
 	broadcastStream, err := ab.NewAtomicBroadcastClient(conn).Deliver(ctx)
 	if err != nil {
-		logger.Error("NewAtomicBroadcastClient failed, cause : ", err)
+		o.logger().Error("NewAtomicBroadcastClient failed, cause : ", err)
 		if secured && o.allowInsecure {
 			//If secured mode failed and allow insecure is enabled then retry in insecure mode
-			logger.Debug("Secured sendBroadcast failed, attempting insecured")
+			o.logger().Debug("Secured sendBroadcast failed, attempting insecured")
 
 			cancel()
-			return o.sendDeliver(envelope, false)
+			return o.sendDeliver(reqCtx, envelope, false)
 		}
 		errs <- errors.Wrap(err, "NewAtomicBroadcastClient failed")
 		return responses, errs, cancel
 	}
 	// Send block request envelope
-	logger.Debugf("Requesting blocks from ordering service")
+	o.logger().Debugf("Requesting blocks from ordering service")
 	if err := broadcastStream.Send(&common.Envelope{
 		Payload:   envelope.Payload,
 		Signature: envelope.Signature,
@@ -367,7 +398,7 @@ func (o *Orderer) sendDeliver(envelope *fab.SignedEnvelope, secured bool) (chan
 
 			// Response is a requested block
 			case *ab.DeliverResponse_Block:
-				logger.Debug("Received block from ordering service")
+				o.logger().Debug("Received block from ordering service")
 				responses <- response.GetBlock()
 			// Unknown response
 			default:
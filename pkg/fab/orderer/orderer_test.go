@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package orderer
 
 import (
+	"context"
 	"crypto/x509"
 	"fmt"
 	"net"
@@ -96,6 +97,59 @@ func TestSendDeliverErr(t *testing.T) {
 	}
 }
 
+func TestSendDeliverContext(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	lis, err := net.Listen("tcp", testOrdererURL)
+	if err != nil {
+		t.Fatalf("Error starting test server %s", err)
+	}
+	ab.RegisterAtomicBroadcastServer(grpcServer, &delayedDeliverServer{delay: time.Second})
+	go grpcServer.Serve(lis)
+
+	orderer, _ := New(mocks.NewMockConfig(), WithURL(lis.Addr().String()), FromOrdererConfig(getGRPCOpts(lis.Addr().String(), true, false)))
+	orderer.dialTimeout = 200 * time.Millisecond
+
+	// The connection timeout applies to the whole stream
+	blocks, errs, cancel := orderer.SendDeliver(&fab.SignedEnvelope{})
+	defer cancel()
+
+	select {
+	case block := <-blocks:
+		t.Fatalf("Expected deadline exceeded error got block: %#v", block)
+	case <-errs:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("Did not receive error from SendDeliver")
+	}
+
+	// The connection timeout only applies to connecting
+	blocks, errs, cancel = orderer.SendDeliverContext(context.Background(), &fab.SignedEnvelope{})
+	defer cancel()
+
+	select {
+	case block := <-blocks:
+		if string(block.Data.Data[0]) != "test" {
+			t.Fatalf("Expected test block got: %#v", block)
+		}
+	case err := <-errs:
+		t.Fatalf("Unexpected error from SendDeliverContext(): %s", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("Did not receive block or error from SendDeliverContext")
+	}
+}
+
+// delayedDeliverServer delays its response to deliver requests
+type delayedDeliverServer struct {
+	mocks.MockBroadcastServer
+	delay time.Duration
+}
+
+func (m *delayedDeliverServer) Deliver(server ab.AtomicBroadcast_DeliverServer) error {
+	server.Recv()
+	time.Sleep(m.delay)
+	return server.Send(mocks.TestBlock)
+}
+
 func TestSendDeliver(t *testing.T) {
 	orderer, _ := New(mocks.NewMockConfig(), WithURL(testOrdererURL+"invalid-test"))
 
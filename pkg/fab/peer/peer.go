@@ -17,11 +17,12 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/urlutil"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
 	"github.com/spf13/cast"
 	"google.golang.org/grpc/keepalive"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/peer")
 
 const (
 	connBlocking = true
@@ -42,6 +43,7 @@ type Peer struct {
 	kap                   keepalive.ClientParameters
 	failFast              bool
 	inSecure              bool
+	loggerProvider        logApi.LoggerProvider
 }
 
 // Option describes a functional parameter for the New constructor
@@ -71,6 +73,7 @@ func New(config core.Config, opts ...Option) (*Peer, error) {
 			kap:                peer.kap,
 			failFast:           peer.failFast,
 			allowInsecure:      peer.inSecure,
+			loggerProvider:     peer.loggerProvider,
 		}
 		peer.processor, err = newPeerEndorser(&endorseRequest)
 
@@ -197,6 +200,16 @@ func WithPeerProcessor(processor fab.ProposalProcessor) Option {
 	}
 }
 
+// WithLoggerProvider is a functional option for the peer.New constructor that configures the
+// logger provider of the peer's connections (e.g. the logger provider of an SDK instance)
+func WithLoggerProvider(loggerProvider logApi.LoggerProvider) Option {
+	return func(p *Peer) error {
+		p.loggerProvider = loggerProvider
+
+		return nil
+	}
+}
+
 // Name gets the Peer name.
 func (p *Peer) Name() string {
 	return p.name
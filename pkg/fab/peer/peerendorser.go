@@ -22,6 +22,8 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/comm"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/urlutil"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -34,6 +36,7 @@ type peerEndorser struct {
 	transportCredentials credentials.TransportCredentials
 	secured              bool
 	allowInsecure        bool
+	loggerProvider       logApi.LoggerProvider
 }
 
 type peerEndorserRequest struct {
@@ -45,6 +48,7 @@ type peerEndorserRequest struct {
 	kap                keepalive.ClientParameters
 	failFast           bool
 	allowInsecure      bool
+	loggerProvider     logApi.LoggerProvider
 }
 
 func newPeerEndorser(endorseReq *peerEndorserRequest) (*peerEndorser, error) {
@@ -72,14 +76,14 @@ func newPeerEndorser(endorseReq *peerEndorserRequest) (*peerEndorser, error) {
 
 	pc := &peerEndorser{grpcDialOption: opts, target: urlutil.ToAddress(endorseReq.target), dialTimeout: timeout,
 		transportCredentials: credentials.NewTLS(tlsConfig), secured: urlutil.AttemptSecured(endorseReq.target),
-		allowInsecure: endorseReq.allowInsecure}
+		allowInsecure: endorseReq.allowInsecure, loggerProvider: endorseReq.loggerProvider}
 
 	return pc, nil
 }
 
 // ProcessTransactionProposal sends the transaction proposal to a peer and returns the response.
 func (p *peerEndorser) ProcessTransactionProposal(request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
-	logger.Debugf("Processing proposal using endorser: %s", p.target)
+	p.logger().Debugf("Processing proposal")
 
 	proposalResponse, err := p.sendProposal(request, p.secured)
 	if err != nil {
@@ -95,6 +99,11 @@ func (p *peerEndorser) ProcessTransactionProposal(request fab.ProcessProposalReq
 	return &tpr, nil
 }
 
+// logger returns a logger tagged with the endorser URL
+func (p *peerEndorser) logger() *logging.Logger {
+	return logger.ForProvider(p.loggerProvider).With(logging.EndorserKey, p.target)
+}
+
 func (p *peerEndorser) conn(secured bool) (*grpc.ClientConn, error) {
 	// Establish connection to Ordering Service
 	var grpcOpts []grpc.DialOption
@@ -120,7 +129,7 @@ func (p *peerEndorser) sendProposal(proposal fab.ProcessProposalRequest, secured
 	if err != nil {
 		if secured && p.allowInsecure {
 			//If secured mode failed and allow insecure is enabled then retry in insecure mode
-			logger.Debug("Secured NewEndorserClient failed, attempting insecured")
+			p.logger().Debug("Secured NewEndorserClient failed, attempting insecured")
 			return p.sendProposal(proposal, false)
 		}
 		return nil, status.New(status.EndorserClientStatus, status.ConnectionFailed.ToInt32(), err.Error(), []interface{}{p.target})
@@ -130,10 +139,10 @@ func (p *peerEndorser) sendProposal(proposal fab.ProcessProposalRequest, secured
 	endorserClient := pb.NewEndorserClient(conn)
 	resp, err := endorserClient.ProcessProposal(grpccontext.Background(), proposal.SignedProposal)
 	if err != nil {
-		logger.Error("NewEndorserClient failed, cause : ", err)
+		p.logger().Error("NewEndorserClient failed, cause : ", err)
 		if secured && p.allowInsecure {
 			//If secured mode failed and allow insecure is enabled then retry in insecure mode
-			logger.Debug("Secured NewEndorserClient failed, attempting insecured")
+			p.logger().Debug("Secured NewEndorserClient failed, attempting insecured")
 			return p.sendProposal(proposal, false)
 		}
 
@@ -25,7 +25,7 @@ import (
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/resource")
 
 // Resource is a client that provides access to fabric network resource management.
 type Resource struct {
@@ -38,6 +38,11 @@ func New(ctx context.Context) *Resource {
 	return &c
 }
 
+// logger returns the package logger bound to the logger provider of the client context
+func (c *Resource) logger() *logging.Logger {
+	return logger.ForProvider(c.clientContext.LoggerProvider())
+}
+
 type fabCtx struct {
 	context.ProviderContext
 	context.IdentityContext
@@ -45,7 +50,7 @@ type fabCtx struct {
 
 // SignChannelConfig signs a configuration.
 func (c *Resource) SignChannelConfig(config []byte, signer context.IdentityContext) (*common.ConfigSignature, error) {
-	logger.Debug("SignChannelConfig - start")
+	c.logger().Debug("SignChannelConfig - start")
 
 	if config == nil {
 		return nil, errors.New("channel configuration required")
@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/pkg/errors"
+)
+
+// CryptoSuiteSigner signs digests with private keys held by a crypto suite.
+// It is the in-process stand-in for a signing service, and the backend
+// of a service built with NewHandler.
+type CryptoSuiteSigner struct {
+	cryptoSuite core.CryptoSuite
+}
+
+// NewCryptoSuiteSigner creates a signer using keys held by cryptoSuite
+func NewCryptoSuiteSigner(cryptoSuite core.CryptoSuite) *CryptoSuiteSigner {
+	return &CryptoSuiteSigner{cryptoSuite: cryptoSuite}
+}
+
+// Sign signs digest with the private key identified by ski
+func (s *CryptoSuiteSigner) Sign(ski []byte, digest []byte) ([]byte, error) {
+	key, err := s.cryptoSuite.GetKey(ski)
+	if err != nil {
+		return nil, errors.WithMessage(err, "key not found")
+	}
+	if !key.Private() {
+		return nil, errors.Errorf("key [%x] is not a private key", ski)
+	}
+	return s.cryptoSuite.Sign(key, digest, nil)
+}
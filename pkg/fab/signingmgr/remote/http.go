@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabric_sdk_go/signingmgr/remote")
+
+const (
+	defaultTimeout = 10 * time.Second
+	// maxMessageSize bounds sign requests and responses, which only carry digests and signatures
+	maxMessageSize = 64 * 1024
+)
+
+// signRequest is the JSON body posted to the signing service.
+// Byte fields are base64 encoded by encoding/json.
+type signRequest struct {
+	SKI    []byte `json:"ski"`
+	Digest []byte `json:"digest"`
+}
+
+// signResponse is the JSON body returned by the signing service
+type signResponse struct {
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// HTTPSigner forwards sign requests to a signing service over HTTP(S)
+type HTTPSigner struct {
+	url    string
+	client *http.Client
+}
+
+// HTTPSignerOptions configure an HTTPSigner
+type HTTPSignerOptions struct {
+	// URL of the sign endpoint, mandatory
+	URL string
+	// Optional. TLS configuration, typically created by NewMutualTLSConfig.
+	TLSConfig *tls.Config
+	// Optional. Request timeout. If not provided, 10 seconds is used.
+	Timeout time.Duration
+}
+
+// NewHTTPSigner creates a signer posting sign requests to opts.URL
+func NewHTTPSigner(opts *HTTPSignerOptions) (*HTTPSigner, error) {
+	if opts == nil || opts.URL == "" {
+		return nil, errors.New("signing service URL is required")
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	return &HTTPSigner{
+		url: opts.URL,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{TLSClientConfig: opts.TLSConfig},
+		},
+	}, nil
+}
+
+// NewHTTPSignerFromConfig creates a signer for the signing service configured by
+// client.signingService. The service's TLS certificate is verified against the configured
+// CA certificates (or the system roots if there are none) and the client key pair, if
+// configured, is presented for mutual TLS.
+func NewHTTPSignerFromConfig(config *core.SigningServiceConfig) (*HTTPSigner, error) {
+	if config == nil {
+		return nil, errors.New("signing service config is required")
+	}
+	tlsConfig, err := tlsConfigFromConfig(&config.TLSCerts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "signing service TLS config failed")
+	}
+	return NewHTTPSigner(&HTTPSignerOptions{
+		URL:       config.URL,
+		TLSConfig: tlsConfig,
+		Timeout:   config.Timeout,
+	})
+}
+
+func tlsConfigFromConfig(config *core.MutualTLSConfig) (*tls.Config, error) {
+	var caCertsPEM []byte
+	for _, pemCert := range config.Pem {
+		caCertsPEM = append(caCertsPEM, []byte(pemCert+"\n")...)
+	}
+	if config.Path != "" {
+		for _, path := range strings.Split(config.Path, ",") {
+			pemCert, err := ioutil.ReadFile(strings.TrimSpace(path))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to load CA certificates from path %s", path)
+			}
+			caCertsPEM = append(caCertsPEM, pemCert...)
+		}
+	}
+
+	certPEM, err := config.Client.Cert.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := config.Client.Key.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(certPEM) > 0 && len(keyPEM) > 0:
+		if len(caCertsPEM) == 0 {
+			return nil, errors.New("CA certificates are required for mutual TLS")
+		}
+		return NewMutualTLSConfig(caCertsPEM, certPEM, keyPEM)
+	case len(certPEM) > 0 || len(keyPEM) > 0:
+		return nil, errors.New("both the client certificate and key are required for mutual TLS")
+	case len(caCertsPEM) > 0:
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caCertsPEM) {
+			return nil, errors.New("no CA certificates found")
+		}
+		return &tls.Config{RootCAs: rootCAs}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// NewMutualTLSConfig creates a TLS configuration trusting the PEM encoded CA
+// certificates and presenting the PEM encoded client certificate and key
+func NewMutualTLSConfig(caCertsPEM []byte, certPEM []byte, keyPEM []byte) (*tls.Config, error) {
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caCertsPEM) {
+		return nil, errors.New("no CA certificates found")
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading client key pair failed")
+	}
+	return &tls.Config{
+		RootCAs:      rootCAs,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
+
+// Sign signs digest with the private key identified by ski
+func (s *HTTPSigner) Sign(ski []byte, digest []byte) ([]byte, error) {
+	body, err := json.Marshal(&signRequest{SKI: ski, Digest: digest})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal sign request failed")
+	}
+	httpResp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "sign request failed")
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, maxMessageSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading sign response failed")
+	}
+	resp := &signResponse{}
+	if err := json.Unmarshal(respBody, resp); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal sign response failed (status %d)", httpResp.StatusCode)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("signing service returned status %d: %s", httpResp.StatusCode, resp.Error)
+	}
+	if len(resp.Signature) == 0 {
+		return nil, errors.New("signing service returned an empty signature")
+	}
+	return resp.Signature, nil
+}
+
+// NewHandler returns an http.Handler serving sign requests with signer.
+// Authenticating clients (e.g. by requiring client certificates) is left to the server.
+func NewHandler(signer Signer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeSignResponse(w, http.StatusMethodNotAllowed, &signResponse{Error: "method not allowed"})
+			return
+		}
+		req := &signRequest{}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxMessageSize)).Decode(req); err != nil {
+			writeSignResponse(w, http.StatusBadRequest, &signResponse{Error: "invalid sign request"})
+			return
+		}
+		if len(req.SKI) == 0 || len(req.Digest) == 0 {
+			writeSignResponse(w, http.StatusBadRequest, &signResponse{Error: "ski and digest are required"})
+			return
+		}
+		signature, err := signer.Sign(req.SKI, req.Digest)
+		if err != nil {
+			logger.Debugf("Sign request for key [%x] failed: %s", req.SKI, err)
+			writeSignResponse(w, http.StatusInternalServerError, &signResponse{Error: "signing failed"})
+			return
+		}
+		writeSignResponse(w, http.StatusOK, &signResponse{Signature: signature})
+	})
+}
+
+func writeSignResponse(w http.ResponseWriter, status int, resp *signResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.Warnf("Writing sign response failed: %s", err)
+	}
+}
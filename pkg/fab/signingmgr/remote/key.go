@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/cryptoutil"
+	"github.com/pkg/errors"
+)
+
+// Key is an opaque handle to a private key held by a remote signing service.
+// Only the public key and its SKI are available locally; the private key
+// cannot be exported.
+type Key struct {
+	publicKey core.Key
+}
+
+// NewKey creates a handle to the remote private key matching publicKey
+func NewKey(publicKey core.Key) (*Key, error) {
+	if publicKey == nil {
+		return nil, errors.New("public key is required")
+	}
+	if publicKey.Private() || publicKey.Symmetric() {
+		return nil, errors.New("an asymmetric public key is required")
+	}
+	return &Key{publicKey: publicKey}, nil
+}
+
+// NewKeyFromCert creates a handle to the remote private key matching the public key
+// of a PEM encoded certificate
+func NewKeyFromCert(cert []byte, cryptoSuite core.CryptoSuite) (*Key, error) {
+	publicKey, err := cryptoutil.GetPublicKeyFromCert(cert, cryptoSuite)
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetching public key from cert failed")
+	}
+	return NewKey(publicKey)
+}
+
+// Bytes returns an error since remote private keys cannot be exported
+func (k *Key) Bytes() ([]byte, error) {
+	return nil, errors.New("remote private key is not exportable")
+}
+
+// SKI returns the subject key identifier of the key
+func (k *Key) SKI() []byte {
+	return k.publicKey.SKI()
+}
+
+// Symmetric returns false
+func (k *Key) Symmetric() bool {
+	return false
+}
+
+// Private returns true
+func (k *Key) Private() bool {
+	return true
+}
+
+// PublicKey returns the public key held locally
+func (k *Key) PublicKey() (core.Key, error) {
+	return k.publicKey, nil
+}
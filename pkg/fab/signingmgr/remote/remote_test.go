@@ -0,0 +1,348 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
+)
+
+// signingServiceConfig overrides the signing service of the client config
+type signingServiceConfig struct {
+	core.Config
+	signingService core.SigningServiceConfig
+}
+
+func (c *signingServiceConfig) Client() (*core.ClientConfig, error) {
+	clientConfig, err := c.Config.Client()
+	if err != nil {
+		return nil, err
+	}
+	clientConfig.SigningService = c.signingService
+	return clientConfig, nil
+}
+
+func setupCryptoSuite(t *testing.T) (core.Config, core.CryptoSuite) {
+	cfg, err := config.FromFile("../../../../test/fixtures/config/config_test.yaml")()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	cs, err := sw.GetSuiteByConfig(cfg)
+	if err != nil {
+		t.Fatalf("Failed to setup cryptoSuite: %v", err)
+	}
+	return cfg, cs
+}
+
+// newRemoteKey generates a private key held by the "service" crypto suite
+// and returns the local handle to it
+func newRemoteKey(t *testing.T, cs core.CryptoSuite) (*Key, core.Key) {
+	privateKey, err := cs.KeyGen(cryptosuite.GetECDSAP256KeyGenOpts(false))
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	key, err := NewKey(publicKey)
+	if err != nil {
+		t.Fatalf("NewKey failed: %v", err)
+	}
+	return key, publicKey
+}
+
+func verify(t *testing.T, cs core.CryptoSuite, publicKey core.Key, object []byte, signature []byte) {
+	digest, err := cs.Hash(object, cryptosuite.GetSHAOpts())
+	if err != nil {
+		t.Fatalf("Hash failed: %v", err)
+	}
+	valid, err := cs.Verify(publicKey, signature, digest, nil)
+	if err != nil || !valid {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+}
+
+func TestKey(t *testing.T) {
+	_, cs := setupCryptoSuite(t)
+	key, publicKey := newRemoteKey(t, cs)
+
+	if _, err := key.Bytes(); err == nil {
+		t.Fatalf("Expected remote key not to be exportable")
+	}
+	if !key.Private() || key.Symmetric() {
+		t.Fatalf("Expected asymmetric private key")
+	}
+	if string(key.SKI()) != string(publicKey.SKI()) {
+		t.Fatalf("Expected SKI of the public key")
+	}
+	if pk, err := key.PublicKey(); err != nil || pk != publicKey {
+		t.Fatalf("Expected public key, got %v, %v", pk, err)
+	}
+
+	if _, err := NewKey(nil); err == nil {
+		t.Fatalf("Expected error for nil public key")
+	}
+	if _, err := NewKey(key); err == nil {
+		t.Fatalf("Expected error for private key")
+	}
+}
+
+func TestSigningManagerInProcess(t *testing.T) {
+	cfg, cs := setupCryptoSuite(t)
+	key, publicKey := newRemoteKey(t, cs)
+
+	signingMgr, err := New(NewCryptoSuiteSigner(cs), cs, cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if _, err := signingMgr.Sign(nil, key); err == nil {
+		t.Fatalf("Expected error for nil object")
+	}
+	if _, err := signingMgr.Sign([]byte("Hello"), nil); err == nil {
+		t.Fatalf("Expected error for nil key")
+	}
+
+	signature, err := signingMgr.Sign([]byte("Hello"), key)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	verify(t, cs, publicKey, []byte("Hello"), signature)
+
+	// Local keys are signed locally
+	localKey, err := cs.KeyGen(cryptosuite.GetECDSAP256KeyGenOpts(true))
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	localSigner, err := New(&failingSigner{}, cs, cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	signature, err = localSigner.Sign([]byte("Hello"), localKey)
+	if err != nil {
+		t.Fatalf("Sign with local key failed: %v", err)
+	}
+	localPublicKey, _ := localKey.PublicKey()
+	verify(t, cs, localPublicKey, []byte("Hello"), signature)
+
+	if _, err := localSigner.Sign([]byte("Hello"), key); err == nil || !strings.Contains(err.Error(), "remote signing failed") {
+		t.Fatalf("Expected remote signing error, got %v", err)
+	}
+
+	if _, err := New(nil, cs, cfg); err == nil {
+		t.Fatalf("Expected error for nil signer")
+	}
+}
+
+func TestHTTPSignerMutualTLS(t *testing.T) {
+	cfg, cs := setupCryptoSuite(t)
+	key, publicKey := newRemoteKey(t, cs)
+
+	caCert, caKey := newTestCert(t, nil, nil)
+	clientCertPEM, clientKeyPEM := newTestCertPEM(t, caCert, caKey)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(caCert)
+
+	server := httptest.NewUnstartedServer(NewHandler(NewCryptoSuiteSigner(cs)))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+	serverCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tlsConfig, err := NewMutualTLSConfig(serverCertPEM, clientCertPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatalf("NewMutualTLSConfig failed: %v", err)
+	}
+	signer, err := NewHTTPSigner(&HTTPSignerOptions{URL: server.URL, TLSConfig: tlsConfig})
+	if err != nil {
+		t.Fatalf("NewHTTPSigner failed: %v", err)
+	}
+	signingMgr, err := New(signer, cs, cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	signature, err := signingMgr.Sign([]byte("Hello"), key)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	verify(t, cs, publicKey, []byte("Hello"), signature)
+
+	// Unknown keys are reported by the service
+	_, err = signer.Sign([]byte("unknown"), []byte("digest"))
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("Expected service error for unknown key, got %v", err)
+	}
+
+	// Clients without a certificate are rejected
+	noClientCert, err := NewHTTPSigner(&HTTPSignerOptions{URL: server.URL, TLSConfig: &tls.Config{RootCAs: tlsConfig.RootCAs}})
+	if err != nil {
+		t.Fatalf("NewHTTPSigner failed: %v", err)
+	}
+	if _, err := noClientCert.Sign(key.SKI(), []byte("digest")); err == nil {
+		t.Fatalf("Expected TLS handshake to fail without a client certificate")
+	}
+
+	if _, err := NewHTTPSigner(&HTTPSignerOptions{}); err == nil {
+		t.Fatalf("Expected error for missing URL")
+	}
+	if _, err := NewMutualTLSConfig([]byte("invalid"), clientCertPEM, clientKeyPEM); err == nil {
+		t.Fatalf("Expected error for invalid CA certificates")
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	cfg, cs := setupCryptoSuite(t)
+	key, publicKey := newRemoteKey(t, cs)
+
+	caCert, caKey := newTestCert(t, nil, nil)
+	clientCertPEM, clientKeyPEM := newTestCertPEM(t, caCert, caKey)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(caCert)
+
+	server := httptest.NewUnstartedServer(NewHandler(NewCryptoSuiteSigner(cs)))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+	serverCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	serviceConfig := &signingServiceConfig{
+		Config: cfg,
+		signingService: core.SigningServiceConfig{
+			URL: server.URL,
+			TLSCerts: core.MutualTLSConfig{
+				Pem: []string{string(serverCertPEM)},
+				Client: core.TLSKeyPair{
+					Key:  core.TLSConfig{Pem: string(clientKeyPEM)},
+					Cert: core.TLSConfig{Pem: string(clientCertPEM)},
+				},
+			},
+		},
+	}
+	signingMgr, err := NewFromConfig(cs, serviceConfig)
+	if err != nil {
+		t.Fatalf("NewFromConfig failed: %v", err)
+	}
+	signature, err := signingMgr.Sign([]byte("Hello"), key)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	verify(t, cs, publicKey, []byte("Hello"), signature)
+
+	// A client key pair requires both the key and the certificate
+	serviceConfig.signingService.TLSCerts.Client.Key = core.TLSConfig{}
+	if _, err := NewFromConfig(cs, serviceConfig); err == nil {
+		t.Fatalf("Expected error for client certificate without a key")
+	}
+
+	serviceConfig.signingService = core.SigningServiceConfig{}
+	if _, err := NewFromConfig(cs, serviceConfig); err == nil {
+		t.Fatalf("Expected error for missing URL")
+	}
+}
+
+func TestHandlerInvalidRequests(t *testing.T) {
+	server := httptest.NewServer(NewHandler(&failingSigner{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected status %d, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+
+	resp, err = http.Post(server.URL, "application/json", strings.NewReader("{"))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+
+	signer, err := NewHTTPSigner(&HTTPSignerOptions{URL: server.URL})
+	if err != nil {
+		t.Fatalf("NewHTTPSigner failed: %v", err)
+	}
+	if _, err := signer.Sign(nil, []byte("digest")); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Fatalf("Expected bad request for missing SKI, got %v", err)
+	}
+}
+
+type failingSigner struct{}
+
+func (s *failingSigner) Sign(ski []byte, digest []byte) ([]byte, error) {
+	return nil, http.ErrNotSupported
+}
+
+// newTestCert creates a CA certificate if parent is nil, otherwise a client certificate signed by parent
+func newTestCert(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.Subject.CommonName = "ca"
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate failed: %v", err)
+	}
+	return cert, key
+}
+
+func newTestCertPEM(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, []byte) {
+	cert, key := newTestCert(t, parent, parentKey)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey failed: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package remote provides a signing manager that signs with keys held by an
+// external signing service, so that private keys never enter the SDK process.
+//
+// Keys are referenced through opaque Key handles and digests are forwarded to the
+// service through a Signer. HTTPSigner talks to a service over HTTP(S), with mutual TLS
+// if a client key pair is configured. Only the HTTP transport is provided; other
+// transports (e.g. gRPC) plug in by implementing Signer.
+//
+// The default core provider factory (fabsdk/factory/defcore) selects the remote
+// signing manager when a signing service is configured:
+//
+//	client:
+//	  signingService:
+//	    url: https://signer.example.com:7443/sign
+//	    timeout: 10s
+//	    tlsCerts:
+//	      path: /path/to/signer-ca.pem
+//	      client:
+//	        key:
+//	          path: /path/to/client-key.pem
+//	        cert:
+//	          path: /path/to/client-cert.pem
+//
+// A custom Signer is wired into the SDK by a core provider factory:
+//
+//	type remoteSigningFactory struct {
+//		defcore.ProviderFactory
+//		signer remote.Signer
+//	}
+//
+//	func (f *remoteSigningFactory) CreateSigningManager(cs core.CryptoSuite, config core.Config) (api.SigningManager, error) {
+//		return remote.New(f.signer, cs, config)
+//	}
+package remote
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/signingmgr"
+	"github.com/pkg/errors"
+)
+
+// Signer signs digests with private keys held by a signing service.
+// ECDSA signatures must be DER encoded with a low S value, as required by Fabric.
+type Signer interface {
+	// Sign signs digest with the private key identified by ski
+	Sign(ski []byte, digest []byte) ([]byte, error)
+}
+
+// SigningManager signs with remote keys through a Signer.
+// Objects are hashed locally so that only digests leave the process.
+// Keys other than remote Key handles are signed locally by the crypto suite.
+type SigningManager struct {
+	signer         Signer
+	cryptoProvider core.CryptoSuite
+	hashOpts       core.HashOpts
+	local          api.SigningManager
+}
+
+// New creates a signing manager forwarding digests to signer
+func New(signer Signer, cryptoProvider core.CryptoSuite, config core.Config) (*SigningManager, error) {
+	if signer == nil {
+		return nil, errors.New("signer is required")
+	}
+	if cryptoProvider == nil {
+		return nil, errors.New("crypto suite is required")
+	}
+	local, err := signingmgr.New(cryptoProvider, config)
+	if err != nil {
+		return nil, err
+	}
+	hashOpts, err := signingmgr.HashOpts(config)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningManager{
+		signer:         signer,
+		cryptoProvider: cryptoProvider,
+		hashOpts:       hashOpts,
+		local:          local,
+	}, nil
+}
+
+// NewFromConfig creates a signing manager forwarding digests to the signing service
+// configured by client.signingService
+func NewFromConfig(cryptoProvider core.CryptoSuite, config core.Config) (*SigningManager, error) {
+	clientConfig, err := config.Client()
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to retrieve client config")
+	}
+	signer, err := NewHTTPSignerFromConfig(&clientConfig.SigningService)
+	if err != nil {
+		return nil, err
+	}
+	return New(signer, cryptoProvider, config)
+}
+
+// Sign will sign the given object using provided key
+func (mgr *SigningManager) Sign(object []byte, key core.Key) ([]byte, error) {
+	if len(object) == 0 {
+		return nil, errors.New("object (to sign) required")
+	}
+	if key == nil {
+		return nil, errors.New("key (for signing) required")
+	}
+
+	remoteKey, ok := key.(*Key)
+	if !ok {
+		return mgr.local.Sign(object, key)
+	}
+
+	digest, err := mgr.cryptoProvider.Hash(object, mgr.hashOpts)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := mgr.signer.Sign(remoteKey.SKI(), digest)
+	if err != nil {
+		return nil, errors.WithMessage(err, "remote signing failed")
+	}
+	return signature, nil
+}
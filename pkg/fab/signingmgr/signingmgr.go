@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package signingmgr
 
 import (
-	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"strings"
 
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
 	"github.com/pkg/errors"
 )
@@ -25,7 +27,28 @@ type SigningManager struct {
 // @param {Config} config - configuration provider
 // @returns {SigningManager} new signing manager
 func New(cryptoProvider core.CryptoSuite, config core.Config) (*SigningManager, error) {
-	return &SigningManager{cryptoProvider: cryptoProvider, hashOpts: cryptosuite.GetSHAOpts()}, nil
+	hashOpts, err := HashOpts(config)
+	if err != nil {
+		return nil, errors.WithMessage(err, "signing hash options from config failed")
+	}
+	return &SigningManager{cryptoProvider: cryptoProvider, hashOpts: hashOpts}, nil
+}
+
+// HashOpts returns the options of the hash function used for signing. Peers and orderers
+// verify signatures with the digest of the MSP's signature hash family, which is SHA-256
+// for SHA2 (the default) whatever the security level, so the configured security algorithm
+// selects the family and the security level only selects the curve of the key.
+//
+// Ed25519 isn't supported since Fabric MSPs only verify ECDSA signatures.
+func HashOpts(config core.Config) (core.HashOpts, error) {
+	hashFamily := bccsp.SHA2
+	if config != nil && config.SecurityAlgorithm() != "" {
+		hashFamily = config.SecurityAlgorithm()
+	}
+	if strings.EqualFold(hashFamily, "Ed25519") {
+		return nil, errors.New("Ed25519 is not supported, Fabric MSPs only verify ECDSA signatures")
+	}
+	return cryptosuite.GetSignatureHashOpts(hashFamily)
 }
 
 // Sign will sign the given object using provided key
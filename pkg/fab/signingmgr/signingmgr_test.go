@@ -8,8 +8,14 @@ package signingmgr
 
 import (
 	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
 	"testing"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
 	bccspwrapper "github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/wrapper"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/identitymgr/mocks"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
@@ -48,3 +54,81 @@ func TestSigningManager(t *testing.T) {
 	}
 
 }
+
+type securityConfig struct {
+	fcmocks.MockConfig
+	algorithm string
+	level     int
+}
+
+func (c *securityConfig) SecurityAlgorithm() string {
+	return c.algorithm
+}
+
+func (c *securityConfig) SecurityLevel() int {
+	return c.level
+}
+
+func TestSigningManagerECDSAP384(t *testing.T) {
+	cs, err := sw.GetSuiteWithDefaultEphemeral()
+	if err != nil {
+		t.Fatalf("Failed to setup cryptoSuite: %s", err)
+	}
+
+	signingMgr, err := New(cs, &securityConfig{algorithm: "SHA2", level: 384})
+	if err != nil {
+		t.Fatalf("Failed to setup signing manager: %s", err)
+	}
+
+	privateKey, err := cs.KeyGen(cryptosuite.GetECDSAP384KeyGenOpts(true))
+	if err != nil {
+		t.Fatalf("KeyGen failed: %s", err)
+	}
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey failed: %s", err)
+	}
+
+	object := []byte("Hello")
+	// MSPs verify with the SHA-256 digest whatever the security level
+	digest, err := cs.Hash(object, cryptosuite.GetSHA256Opts())
+	if err != nil {
+		t.Fatalf("Hash failed: %s", err)
+	}
+	if len(digest) != sha256.Size {
+		t.Fatalf("Expected SHA-256 digest, got %d bytes", len(digest))
+	}
+
+	halfOrder := new(big.Int).Rsh(elliptic.P384().Params().N, 1)
+	for i := 0; i < 20; i++ {
+		signature, err := signingMgr.Sign(object, privateKey)
+		if err != nil {
+			t.Fatalf("Failed to sign object: %s", err)
+		}
+
+		valid, err := cs.Verify(publicKey, signature, digest, nil)
+		if err != nil || !valid {
+			t.Fatalf("Signature verification failed: %v", err)
+		}
+
+		var sig struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(signature, &sig); err != nil {
+			t.Fatalf("Failed to unmarshal signature: %s", err)
+		}
+		if sig.S.Cmp(halfOrder) > 0 {
+			t.Fatalf("Expected low-S signature")
+		}
+	}
+}
+
+func TestSigningManagerUnsupportedSecurityAlgorithm(t *testing.T) {
+	_, err := New(&fcmocks.MockCryptoSuite{}, &securityConfig{algorithm: "MD5", level: 256})
+	if err == nil {
+		t.Fatalf("Expected error for unsupported security algorithm")
+	}
+
+	_, err = New(&fcmocks.MockCryptoSuite{}, &securityConfig{algorithm: "Ed25519", level: 256})
+	if err == nil {
+		t.Fatalf("Expected error for Ed25519")
+	}
+}
@@ -65,7 +65,9 @@ func NewHeader(ctx contextApi.Context, channelID string) (*TransactionHeader, er
 		return nil, errors.WithMessage(err, "identity from context failed")
 	}
 
-	ho := cryptosuite.GetSHA256Opts() // TODO: make configurable
+	// Peers recompute the transaction ID as SHA-256(nonce + creator), so the
+	// hash function doesn't follow the configured security level and algorithm
+	ho := cryptosuite.GetSHA256Opts()
 	h, err := ctx.CryptoSuite().GetHash(ho)
 	if err != nil {
 		return nil, errors.WithMessage(err, "hash function creation failed")
@@ -99,8 +101,8 @@ func computeTxnID(nonce, creator []byte, h hash.Hash) (string, error) {
 	return id, nil
 }
 
-// signPayload signs payload
-func signPayload(ctx context, payload *common.Payload) (*fab.SignedEnvelope, error) {
+// SignPayload signs the payload with the context's identity and returns the signed envelope
+func SignPayload(ctx context, payload *common.Payload) (*fab.SignedEnvelope, error) {
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
 		return nil, errors.WithMessage(err, "marshaling of payload failed")
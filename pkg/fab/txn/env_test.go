@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/signingmgr"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+)
+
+const testMSPID = "Org1MSP"
+
+type sha384Config struct {
+	mocks.MockConfig
+}
+
+func (c *sha384Config) SecurityLevel() int {
+	return 384
+}
+
+func TestECDSAP384Identity(t *testing.T) {
+	cs, err := sw.GetSuiteWithDefaultEphemeral()
+	if err != nil {
+		t.Fatalf("Failed to setup cryptoSuite: %s", err)
+	}
+	config := &sha384Config{}
+	signingMgr, err := signingmgr.New(cs, config)
+	if err != nil {
+		t.Fatalf("Failed to setup signing manager: %s", err)
+	}
+
+	privateKey, err := cs.KeyGen(cryptosuite.GetECDSAP384KeyGenOpts(true))
+	if err != nil {
+		t.Fatalf("KeyGen failed: %s", err)
+	}
+	user := mocks.NewMockUserWithMSPID("test", testMSPID).(*mocks.MockUser)
+	user.SetPrivateKey(privateKey)
+
+	ctx := &mocks.MockContext{
+		MockProviderContext: mocks.NewMockProviderContextCustom(config, cs, signingMgr),
+		IdentityContext:     user,
+	}
+
+	// The transaction ID stays SHA-256 as peers recompute it that way
+	txh, err := NewHeader(ctx, "test")
+	if err != nil {
+		t.Fatalf("NewHeader failed: %s", err)
+	}
+	digest := sha256.Sum256(append(append([]byte{}, txh.Nonce()...), txh.Creator()...))
+	if string(txh.TransactionID()) != hex.EncodeToString(digest[:]) {
+		t.Fatalf("Unexpected transaction ID: %s", txh.TransactionID())
+	}
+
+	signedEnv, err := SignPayload(ctx, &common.Payload{Data: []byte("data")})
+	if err != nil {
+		t.Fatalf("SignPayload failed: %s", err)
+	}
+
+	// Verify the signature the way peers and orderers do, through the MSP identity
+	caCert, caKey := newP384CA(t)
+	identity := deserializeIdentity(t, cs, caCert, newP384Cert(t, privateKey, caCert, caKey))
+	if err := identity.Verify(signedEnv.Payload, signedEnv.Signature); err != nil {
+		t.Fatalf("Envelope signature verification by MSP identity failed: %s", err)
+	}
+}
+
+// deserializeIdentity returns the identity of the certificate, deserialized by an MSP with the given root CA
+func deserializeIdentity(t *testing.T, cs core.CryptoSuite, caCert *x509.Certificate, cert *x509.Certificate) msp.Identity {
+	verifyingMSP, err := msp.NewBccspMsp(msp.MSPv1_0, cs)
+	if err != nil {
+		t.Fatalf("NewBccspMsp failed: %s", err)
+	}
+	fabricConfig, err := proto.Marshal(&mb.FabricMSPConfig{Name: testMSPID, RootCerts: [][]byte{toPEM(caCert)}})
+	if err != nil {
+		t.Fatalf("Marshal of MSP config failed: %s", err)
+	}
+	if err := verifyingMSP.Setup(&mb.MSPConfig{Config: fabricConfig}); err != nil {
+		t.Fatalf("MSP setup failed: %s", err)
+	}
+
+	serializedIdentity, err := proto.Marshal(&mb.SerializedIdentity{Mspid: testMSPID, IdBytes: toPEM(cert)})
+	if err != nil {
+		t.Fatalf("Marshal of serialized identity failed: %s", err)
+	}
+	identity, err := verifyingMSP.DeserializeIdentity(serializedIdentity)
+	if err != nil {
+		t.Fatalf("DeserializeIdentity failed: %s", err)
+	}
+	return identity
+}
+
+func newP384CA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("CA key generation failed: %s", err)
+	}
+	template := certTemplate(1, "ca.org1.example.com")
+	template.IsCA = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	return createCert(t, template, template, &caKey.PublicKey, caKey), caKey
+}
+
+func newP384Cert(t *testing.T, key core.Key, caCert *x509.Certificate, caKey *ecdsa.PrivateKey) *x509.Certificate {
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey failed: %s", err)
+	}
+	publicKeyBytes, err := publicKey.Bytes()
+	if err != nil {
+		t.Fatalf("PublicKey bytes failed: %s", err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(publicKeyBytes)
+	if err != nil {
+		t.Fatalf("ParsePKIXPublicKey failed: %s", err)
+	}
+	template := certTemplate(2, "user1@org1.example.com")
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	return createCert(t, template, caCert, pub, caKey)
+}
+
+func certTemplate(serialNumber int64, commonName string) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber:          big.NewInt(serialNumber),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+	}
+}
+
+func createCert(t *testing.T, template, parent *x509.Certificate, pub interface{}, signer *ecdsa.PrivateKey) *x509.Certificate {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate failed: %s", err)
+	}
+	return cert
+}
+
+func toPEM(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
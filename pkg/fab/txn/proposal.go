@@ -14,6 +14,7 @@ import (
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	protos_utils "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
@@ -91,6 +92,7 @@ func SendProposal(ctx context, proposal *fab.TransactionProposal, targets []fab.
 	}
 
 	request := fab.ProcessProposalRequest{SignedProposal: signedProposal}
+	txLogger := logger.ForProvider(ctx.LoggerProvider()).With(logging.TxIDKey, proposal.TxnID)
 
 	var responseMtx sync.Mutex
 	var transactionProposalResponses []*fab.TransactionProposalResponse
@@ -104,7 +106,11 @@ func SendProposal(ctx context, proposal *fab.TransactionProposal, targets []fab.
 
 			resp, err := processor.ProcessTransactionProposal(request)
 			if err != nil {
-				logger.Debugf("Received error response from txn proposal processing: %v", err)
+				if resp != nil {
+					txLogger.With(logging.EndorserKey, resp.Endorser).Debugf("Received error response from txn proposal processing: %v", err)
+				} else {
+					txLogger.Debugf("Received error response from txn proposal processing: %v", err)
+				}
 				responseMtx.Lock()
 				errs = append(errs, err)
 				responseMtx.Unlock()
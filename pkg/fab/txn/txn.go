@@ -24,7 +24,7 @@ import (
 	protos_utils "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
 )
 
-var logger = logging.NewLogger("fabric_sdk_go")
+var logger = logging.NewLogger("fabric_sdk_go/txn")
 
 // CCProposalType reflects transitions in the chaincode lifecycle
 type CCProposalType int
@@ -151,14 +151,28 @@ func BroadcastPayload(ctx context, payload *common.Payload, orderers []fab.Order
 		return nil, errors.New("orderers not set")
 	}
 
-	envelope, err := signPayload(ctx, payload)
+	envelope, err := SignPayload(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
 
+	payloadLogger(ctx, payload).Debugf("Broadcasting payload to %d orderer(s)", len(orderers))
 	return broadcastEnvelope(ctx, envelope, orderers)
 }
 
+// payloadLogger returns a logger tagged with the channel and transaction ID of the payload
+func payloadLogger(ctx context, payload *common.Payload) *logging.Logger {
+	ctxLogger := logger.ForProvider(ctx.LoggerProvider())
+	if payload.Header == nil {
+		return ctxLogger
+	}
+	chdr, err := protos_utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return ctxLogger
+	}
+	return ctxLogger.With(logging.ChannelIDKey, chdr.ChannelId, logging.TxIDKey, chdr.TxId)
+}
+
 // broadcastEnvelope will send the given envelope to some orderer, picking random endpoints
 // until all are exhausted
 func broadcastEnvelope(ctx context, envelope *fab.SignedEnvelope, orderers []fab.Orderer) (*fab.TransactionResponse, error) {
@@ -176,7 +190,7 @@ func broadcastEnvelope(ctx context, envelope *fab.SignedEnvelope, orderers []fab
 	// Iterate them in a random order and try broadcasting 1 by 1
 	var errResp *fab.TransactionResponse
 	for _, i := range rand.Perm(len(randOrderers)) {
-		resp := sendBroadcast(envelope, randOrderers[i])
+		resp := sendBroadcast(ctx, envelope, randOrderers[i])
 		if resp.Err != nil {
 			errResp = resp
 		} else {
@@ -186,15 +200,16 @@ func broadcastEnvelope(ctx context, envelope *fab.SignedEnvelope, orderers []fab
 	return errResp, nil
 }
 
-func sendBroadcast(envelope *fab.SignedEnvelope, orderer fab.Orderer) *fab.TransactionResponse {
-	logger.Debugf("Broadcasting envelope to orderer :%s\n", orderer.URL())
+func sendBroadcast(ctx context, envelope *fab.SignedEnvelope, orderer fab.Orderer) *fab.TransactionResponse {
+	ordererLogger := logger.ForProvider(ctx.LoggerProvider()).With(logging.OrdererKey, orderer.URL())
+	ordererLogger.Debugf("Broadcasting envelope to orderer")
 	if _, err := orderer.SendBroadcast(envelope); err != nil {
-		logger.Debugf("Receive Error Response from orderer :%v\n", err)
+		ordererLogger.Debugf("Receive Error Response from orderer :%v\n", err)
 		return &fab.TransactionResponse{Orderer: orderer.URL(),
 			Err: errors.Wrapf(err, "calling orderer '%s' failed", orderer.URL())}
 	}
 
-	logger.Debugf("Receive Success Response from orderer\n")
+	ordererLogger.Debugf("Receive Success Response from orderer\n")
 	return &fab.TransactionResponse{Orderer: orderer.URL(), Err: nil}
 }
 
@@ -204,7 +219,7 @@ func SendPayload(ctx context, payload *common.Payload, orderers []fab.Orderer) (
 		return nil, errors.New("orderers not set")
 	}
 
-	envelope, err := signPayload(ctx, payload)
+	envelope, err := SignPayload(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -225,7 +240,7 @@ func sendEnvelope(ctx context, envelope *fab.SignedEnvelope, orderers []fab.Orde
 	for _, o := range orderers {
 
 		go func(orderer fab.Orderer) {
-			logger.Debugf("Broadcasting envelope to orderer :%s\n", orderer.URL())
+			logger.ForProvider(ctx.LoggerProvider()).Debugf("Broadcasting envelope to orderer :%s\n", orderer.URL())
 
 			blocks, errs, cancel := orderer.SendDeliver(envelope)
 			defer cancel()
@@ -293,7 +308,7 @@ func RegisterStatus(txID fab.TransactionID, eventHub fab.EventHub) chan Status {
 	statusNotifier := make(chan Status)
 
 	eventHub.RegisterTxEvent(txID, func(txId fab.TransactionID, code pb.TxValidationCode, err error) {
-		logger.Debugf("Received code(%s) for txid(%s) and err(%s)\n", code, txId, err)
+		logger.With(logging.TxIDKey, txId).Debugf("Received code(%s) and err(%s)\n", code, err)
 		statusNotifier <- Status{Code: code, Error: err}
 	})
 
@@ -314,7 +314,7 @@ func TestSignPayload(t *testing.T) {
 
 	payload := common.Payload{}
 
-	signedEnv, err := signPayload(ctx, &payload)
+	signedEnv, err := SignPayload(ctx, &payload)
 
 	if err != nil || signedEnv == nil {
 		t.Fatal("Test Sign Payload Failed")
@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	identityFileExt = ".id"
+	newDirMode      = 0700
+	newFileMode     = 0600
+)
+
+// FileSystemStore stores each identity in a file named <label>.id in a directory
+type FileSystemStore struct {
+	path string
+}
+
+// NewFileSystemStore creates a filesystem wallet store rooted at path
+func NewFileSystemStore(path string) (*FileSystemStore, error) {
+	if path == "" {
+		return nil, errors.New("wallet path is empty")
+	}
+	return &FileSystemStore{path: path}, nil
+}
+
+// NewFileSystemWallet creates a wallet backed by a FileSystemStore rooted at path
+func NewFileSystemWallet(path string) (*Wallet, error) {
+	store, err := NewFileSystemStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(store)
+}
+
+// Put stores content for the label, replacing any existing content
+func (s *FileSystemStore) Put(label string, content []byte) error {
+	if err := os.MkdirAll(s.path, newDirMode); err != nil {
+		return errors.Wrap(err, "creating wallet directory failed")
+	}
+	// Write to a temporary file first so that readers never observe a partial identity
+	tmpFile, err := ioutil.TempFile(s.path, label)
+	if err != nil {
+		return errors.Wrap(err, "creating identity file failed")
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return errors.Wrap(err, "writing identity file failed")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err, "writing identity file failed")
+	}
+	if err := os.Chmod(tmpFile.Name(), newFileMode); err != nil {
+		return errors.Wrap(err, "writing identity file failed")
+	}
+	return errors.Wrap(os.Rename(tmpFile.Name(), s.file(label)), "writing identity file failed")
+}
+
+// Get returns the content stored for the label or ErrIdentityNotFound
+func (s *FileSystemStore) Get(label string) ([]byte, error) {
+	content, err := ioutil.ReadFile(s.file(label))
+	if os.IsNotExist(err) {
+		return nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading identity file failed")
+	}
+	return content, nil
+}
+
+// List returns the labels of all stored identities
+func (s *FileSystemStore) List() ([]string, error) {
+	files, err := ioutil.ReadDir(s.path)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading wallet directory failed")
+	}
+	labels := []string{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), identityFileExt) {
+			continue
+		}
+		labels = append(labels, strings.TrimSuffix(f.Name(), identityFileExt))
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+// Remove deletes the content stored for the label
+func (s *FileSystemStore) Remove(label string) error {
+	err := os.Remove(s.file(label))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing identity file failed")
+	}
+	return nil
+}
+
+func (s *FileSystemStore) file(label string) string {
+	return filepath.Join(s.path, label+identityFileExt)
+}
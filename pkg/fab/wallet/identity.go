@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+
+	fabricCaUtil "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
+	"github.com/pkg/errors"
+)
+
+// identityVersion is the version of the portable identity format
+const identityVersion = 1
+
+// Identity is the portable representation of an X.509 identity held in a wallet.
+// Certificates and keys are PEM encoded; the identity serializes to JSON.
+type Identity struct {
+	Version     int         `json:"version"`
+	MspID       string      `json:"mspId"`
+	Certificate string      `json:"certificate"`
+	PrivateKey  string      `json:"privateKey"`
+	TLS         *TLSKeyPair `json:"tls,omitempty"`
+}
+
+// TLSKeyPair is an optional client TLS certificate and key held alongside an identity
+type TLSKeyPair struct {
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"privateKey"`
+}
+
+// NewIdentity creates an identity from PEM encoded enrollment certificate and private key
+func NewIdentity(mspID string, certPEM []byte, keyPEM []byte) (*Identity, error) {
+	id := &Identity{
+		Version:     identityVersion,
+		MspID:       mspID,
+		Certificate: string(certPEM),
+		PrivateKey:  string(keyPEM),
+	}
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// NewIdentityFromMSPDir creates an identity from an MSP directory laid out by cryptogen
+// or the fabric-ca client: the first certificate in signcerts and the matching key in keystore.
+func NewIdentityFromMSPDir(mspID string, mspDir string) (*Identity, error) {
+	certPEM, err := readFirstFile(filepath.Join(mspDir, "signcerts"), nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading signcerts failed")
+	}
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := readFirstFile(filepath.Join(mspDir, "keystore"), func(content []byte) bool {
+		key, err := parsePrivateKey(content)
+		return err == nil && publicKeyMatches(key, cert.PublicKey)
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading keystore failed")
+	}
+	return NewIdentity(mspID, certPEM, keyPEM)
+}
+
+// WithTLS sets the client TLS key pair of the identity
+func (id *Identity) WithTLS(certPEM []byte, keyPEM []byte) *Identity {
+	id.TLS = &TLSKeyPair{
+		Certificate: string(certPEM),
+		PrivateKey:  string(keyPEM),
+	}
+	return id
+}
+
+// Validate checks that the identity is complete and that its private keys match its certificates
+func (id *Identity) Validate() error {
+	if id.MspID == "" {
+		return errors.New("MSP ID is required")
+	}
+	if err := validateKeyPair([]byte(id.Certificate), []byte(id.PrivateKey)); err != nil {
+		return errors.WithMessage(err, "invalid enrollment certificate and key")
+	}
+	if id.TLS != nil {
+		if err := validateKeyPair([]byte(id.TLS.Certificate), []byte(id.TLS.PrivateKey)); err != nil {
+			return errors.WithMessage(err, "invalid TLS certificate and key")
+		}
+	}
+	return nil
+}
+
+// SigningIdentity imports the private key into the crypto suite (as an ephemeral key)
+// and returns the signing identity used by the SDK
+func (id *Identity) SigningIdentity(cryptoSuite core.CryptoSuite) (*api.SigningIdentity, error) {
+	privateKey, err := fabricCaUtil.ImportBCCSPKeyFromPEMBytes([]byte(id.PrivateKey), cryptoSuite, true)
+	if err != nil {
+		return nil, errors.WithMessage(err, "import private key failed")
+	}
+	return &api.SigningIdentity{
+		MspID:          id.MspID,
+		EnrollmentCert: []byte(id.Certificate),
+		PrivateKey:     privateKey,
+	}, nil
+}
+
+// Marshal serializes the identity into its portable JSON form
+func (id *Identity) Marshal() ([]byte, error) {
+	return json.MarshalIndent(id, "", "  ")
+}
+
+// Unmarshal parses and validates an identity in its portable JSON form
+func Unmarshal(content []byte) (*Identity, error) {
+	id := &Identity{}
+	if err := json.Unmarshal(content, id); err != nil {
+		return nil, errors.Wrap(err, "unmarshal identity failed")
+	}
+	if id.Version != identityVersion {
+		return nil, errors.Errorf("unsupported identity version %d", id.Version)
+	}
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func validateKeyPair(certPEM []byte, keyPEM []byte) error {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return err
+	}
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return err
+	}
+	if !publicKeyMatches(key, cert.PublicKey) {
+		return errors.New("private key does not match certificate")
+	}
+	return nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse certificate failed")
+	}
+	return cert, nil
+}
+
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key type")
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("parse private key failed")
+}
+
+func publicKeyMatches(key crypto.Signer, pub crypto.PublicKey) bool {
+	switch pub.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+	default:
+		return false
+	}
+	expected, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return false
+	}
+	actual, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(expected, actual)
+}
+
+// readFirstFile returns the content of the first file in dir accepted by filter (any file if nil)
+func readFirstFile(dir string, filter func([]byte) bool) ([]byte, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading directory %s failed", dir)
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading file %s failed", f.Name())
+		}
+		if filter == nil || filter(content) {
+			return content, nil
+		}
+	}
+	return nil, errors.Errorf("no suitable file found in %s", dir)
+}
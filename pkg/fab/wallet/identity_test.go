@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
+)
+
+const (
+	testMspID   = "Org1MSP"
+	testUserDir = "../../../test/fixtures/fabric/v1/crypto-config/peerOrganizations/org1.example.com/users/User1@org1.example.com"
+	testUser2   = "../../../test/fixtures/fabric/v1/crypto-config/peerOrganizations/org1.example.com/users/Admin@org1.example.com"
+)
+
+func newTestIdentity(t *testing.T, userDir string) *Identity {
+	id, err := NewIdentityFromMSPDir(testMspID, filepath.Join(userDir, "msp"))
+	if err != nil {
+		t.Fatalf("NewIdentityFromMSPDir failed: %v", err)
+	}
+	return id
+}
+
+func readTestFile(t *testing.T, path string) []byte {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s failed: %v", path, err)
+	}
+	return content
+}
+
+func TestIdentityMarshalRoundTrip(t *testing.T) {
+	id := newTestIdentity(t, testUserDir)
+	id.WithTLS(readTestFile(t, filepath.Join(testUserDir, "tls", "server.crt")), readTestFile(t, filepath.Join(testUserDir, "tls", "server.key")))
+	if err := id.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	content, err := id.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	id2, err := Unmarshal(content)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if *id2.TLS != *id.TLS || id2.MspID != id.MspID || id2.Certificate != id.Certificate || id2.PrivateKey != id.PrivateKey {
+		t.Fatalf("Unmarshalled identity does not match: %+v", id2)
+	}
+}
+
+func TestIdentityValidation(t *testing.T) {
+	id1 := newTestIdentity(t, testUserDir)
+	id2 := newTestIdentity(t, testUser2)
+
+	if _, err := NewIdentity("", []byte(id1.Certificate), []byte(id1.PrivateKey)); err == nil {
+		t.Fatalf("Expected error for missing MSP ID")
+	}
+	if _, err := NewIdentity(testMspID, []byte(id1.Certificate), []byte(id2.PrivateKey)); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("Expected error for mismatched key, got %v", err)
+	}
+	if _, err := NewIdentity(testMspID, []byte("not a cert"), []byte(id1.PrivateKey)); err == nil {
+		t.Fatalf("Expected error for invalid certificate")
+	}
+	if _, err := Unmarshal([]byte(`{"version":2}`)); err == nil {
+		t.Fatalf("Expected error for unsupported version")
+	}
+	if _, err := Unmarshal([]byte(`{`)); err == nil {
+		t.Fatalf("Expected error for invalid JSON")
+	}
+
+	id1.WithTLS([]byte(id1.Certificate), []byte(id2.PrivateKey))
+	if err := id1.Validate(); err == nil || !strings.Contains(err.Error(), "TLS") {
+		t.Fatalf("Expected error for mismatched TLS key, got %v", err)
+	}
+}
+
+func TestIdentitySigningIdentity(t *testing.T) {
+	cfg, err := config.FromFile("../../../test/fixtures/config/config_test.yaml")()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	cryptoSuite, err := sw.GetSuiteByConfig(cfg)
+	if err != nil {
+		t.Fatalf("Failed to setup cryptoSuite: %v", err)
+	}
+
+	id := newTestIdentity(t, testUserDir)
+	signingIdentity, err := id.SigningIdentity(cryptoSuite)
+	if err != nil {
+		t.Fatalf("SigningIdentity failed: %v", err)
+	}
+	if signingIdentity.MspID != testMspID || string(signingIdentity.EnrollmentCert) != id.Certificate {
+		t.Fatalf("Unexpected signing identity: %+v", signingIdentity)
+	}
+	if signingIdentity.PrivateKey == nil || !signingIdentity.PrivateKey.Private() {
+		t.Fatalf("Expected private key in signing identity")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"sort"
+	"sync"
+)
+
+// InMemoryStore holds identities in memory only
+type InMemoryStore struct {
+	mutex      sync.RWMutex
+	identities map[string][]byte
+}
+
+// NewInMemoryStore creates an empty in-memory wallet store
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{identities: make(map[string][]byte)}
+}
+
+// NewInMemoryWallet creates a wallet backed by a new InMemoryStore
+func NewInMemoryWallet() *Wallet {
+	return &Wallet{store: NewInMemoryStore()}
+}
+
+// Put stores content for the label, replacing any existing content
+func (s *InMemoryStore) Put(label string, content []byte) error {
+	c := make([]byte, len(content))
+	copy(c, content)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.identities[label] = c
+	return nil
+}
+
+// Get returns the content stored for the label or ErrIdentityNotFound
+func (s *InMemoryStore) Get(label string) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	content, ok := s.identities[label]
+	if !ok {
+		return nil, ErrIdentityNotFound
+	}
+	c := make([]byte, len(content))
+	copy(c, content)
+	return c, nil
+}
+
+// List returns the labels of all stored identities
+func (s *InMemoryStore) List() ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	labels := make([]string, 0, len(s.identities))
+	for label := range s.identities {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+// Remove deletes the content stored for the label
+func (s *InMemoryStore) Remove(label string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.identities, label)
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrIdentityNotFound indicates that the wallet holds no identity for a label
+	ErrIdentityNotFound = errors.New("identity not found in wallet")
+)
+
+// Store persists serialized identities by label.
+// Implementations are provided for the filesystem and memory.
+type Store interface {
+	// Put stores content for the label, replacing any existing content
+	Put(label string, content []byte) error
+	// Get returns the content stored for the label or ErrIdentityNotFound
+	Get(label string) ([]byte, error)
+	// List returns the labels of all stored identities
+	List() ([]string, error)
+	// Remove deletes the content stored for the label; removing a missing label is not an error
+	Remove(label string) error
+}
+
+// Wallet holds identities (certificate, private key, MSP ID and optional TLS key pair)
+// under a label, and imports and exports them in a portable JSON/PEM format.
+type Wallet struct {
+	store Store
+}
+
+// New creates a wallet backed by store
+func New(store Store) (*Wallet, error) {
+	if store == nil {
+		return nil, errors.New("wallet store is nil")
+	}
+	return &Wallet{store: store}, nil
+}
+
+// Put adds an identity to the wallet, replacing any identity with the same label
+func (w *Wallet) Put(label string, id *Identity) error {
+	if err := validateLabel(label); err != nil {
+		return err
+	}
+	if id == nil {
+		return errors.New("identity is nil")
+	}
+	if id.Version == 0 {
+		id.Version = identityVersion
+	}
+	if err := id.Validate(); err != nil {
+		return errors.WithMessage(err, "invalid identity")
+	}
+	content, err := id.Marshal()
+	if err != nil {
+		return errors.Wrap(err, "marshal identity failed")
+	}
+	return w.store.Put(label, content)
+}
+
+// Get returns the identity held under label or ErrIdentityNotFound
+func (w *Wallet) Get(label string) (*Identity, error) {
+	if err := validateLabel(label); err != nil {
+		return nil, err
+	}
+	content, err := w.store.Get(label)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(content)
+}
+
+// Exists returns true if the wallet holds an identity under label
+func (w *Wallet) Exists(label string) (bool, error) {
+	_, err := w.Get(label)
+	if err == ErrIdentityNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// List returns the labels of the identities held in the wallet
+func (w *Wallet) List() ([]string, error) {
+	return w.store.List()
+}
+
+// Remove deletes the identity held under label
+func (w *Wallet) Remove(label string) error {
+	if err := validateLabel(label); err != nil {
+		return err
+	}
+	return w.store.Remove(label)
+}
+
+// Import adds an identity in its portable JSON form to the wallet
+func (w *Wallet) Import(label string, content []byte) error {
+	id, err := Unmarshal(content)
+	if err != nil {
+		return err
+	}
+	return w.Put(label, id)
+}
+
+// Export returns the identity held under label in its portable JSON form
+func (w *Wallet) Export(label string) ([]byte, error) {
+	id, err := w.Get(label)
+	if err != nil {
+		return nil, err
+	}
+	return id.Marshal()
+}
+
+func validateLabel(label string) error {
+	if label == "" {
+		return errors.New("label is required")
+	}
+	if strings.ContainsAny(label, `/\`) || label == "." || label == ".." {
+		return errors.Errorf("invalid label [%s]", label)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInMemoryWallet(t *testing.T) {
+	testWallet(t, NewInMemoryWallet())
+}
+
+func TestFileSystemWallet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatalf("TempDir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wallet")
+	w, err := NewFileSystemWallet(path)
+	if err != nil {
+		t.Fatalf("NewFileSystemWallet failed: %v", err)
+	}
+	testWallet(t, w)
+
+	// Identities outlive the wallet instance
+	if err := w.Put("user1", newTestIdentity(t, testUserDir)); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	w2, err := NewFileSystemWallet(path)
+	if err != nil {
+		t.Fatalf("NewFileSystemWallet failed: %v", err)
+	}
+	if _, err := w2.Get("user1"); err != nil {
+		t.Fatalf("Get from reopened wallet failed: %v", err)
+	}
+
+	// Unrelated files are not listed
+	if err := ioutil.WriteFile(filepath.Join(path, "README"), []byte("x"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	checkLabels(t, w2, []string{"user1"})
+
+	if _, err := NewFileSystemWallet(""); err == nil {
+		t.Fatalf("Expected error for empty path")
+	}
+}
+
+func TestNewWalletNilStore(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Fatalf("Expected error for nil store")
+	}
+}
+
+func testWallet(t *testing.T, w *Wallet) {
+	checkLabels(t, w, []string{})
+
+	if _, err := w.Get("user1"); err != ErrIdentityNotFound {
+		t.Fatalf("Expected ErrIdentityNotFound, got %v", err)
+	}
+	if ok, err := w.Exists("user1"); err != nil || ok {
+		t.Fatalf("Expected user1 not to exist, got %t, %v", ok, err)
+	}
+
+	id1 := newTestIdentity(t, testUserDir)
+	id2 := newTestIdentity(t, testUser2)
+	if err := w.Put("user1", id1); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := w.Put("admin", id2); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	checkLabels(t, w, []string{"admin", "user1"})
+
+	got, err := w.Get("user1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, id1) {
+		t.Fatalf("Get returned a different identity")
+	}
+	if ok, err := w.Exists("user1"); err != nil || !ok {
+		t.Fatalf("Expected user1 to exist, got %t, %v", ok, err)
+	}
+
+	// Export and import under a new label
+	content, err := w.Export("admin")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if err := w.Import("admin2", content); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	got, err = w.Get("admin2")
+	if err != nil || !reflect.DeepEqual(got, id2) {
+		t.Fatalf("Imported identity does not match: %v", err)
+	}
+	if err := w.Import("bad", []byte("{}")); err == nil {
+		t.Fatalf("Expected import of invalid identity to fail")
+	}
+
+	// Invalid identities and labels are rejected
+	if err := w.Put("user3", &Identity{MspID: testMspID}); err == nil {
+		t.Fatalf("Expected Put of invalid identity to fail")
+	}
+	for _, label := range []string{"", "..", "a/b", `a\b`} {
+		if err := w.Put(label, id1); err == nil {
+			t.Fatalf("Expected Put with label [%s] to fail", label)
+		}
+	}
+
+	if err := w.Remove("admin2"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := w.Remove("admin2"); err != nil {
+		t.Fatalf("Remove of missing identity failed: %v", err)
+	}
+	if err := w.Remove("admin"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := w.Remove("user1"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	checkLabels(t, w, []string{})
+}
+
+func checkLabels(t *testing.T, w *Wallet, expected []string) {
+	labels, err := w.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Fatalf("Expected labels %v, got %v", expected, labels)
+	}
+}
@@ -32,7 +32,7 @@ type ServiceProviderFactory interface {
 // OrgClientFactory allows overriding default clients and providers of an organization
 // Currently, a context is created for each organization that the client app needs.
 type OrgClientFactory interface {
-	CreateCredentialManager(orgName string, config core.Config, cryptoProvider core.CryptoSuite) (api.CredentialManager, error)
+	CreateCredentialManager(orgName string, config core.Config, cryptoProvider core.CryptoSuite, stateStore api.KVStore) (api.CredentialManager, error)
 }
 
 // SessionClientFactory allows overriding default clients and providers of a session
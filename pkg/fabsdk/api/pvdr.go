@@ -12,6 +12,9 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	metricsApi "github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+	tracingApi "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
 )
 
 // FabricProvider enables access to fabric objects such as peer and user based on config or
@@ -42,6 +45,9 @@ type CoreProviders interface {
 	Config() core.Config
 	SigningManager() contextApi.SigningManager
 	FabricProvider() FabricProvider
+	LoggerProvider() logApi.LoggerProvider
+	MetricsProvider() metricsApi.Provider
+	Tracer() tracingApi.Tracer
 }
 
 // SvcProviders represents the SDK configured service providers context.
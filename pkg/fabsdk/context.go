@@ -12,6 +12,9 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk/api"
+	logApi "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	metricsApi "github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+	tracingApi "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
 	"github.com/pkg/errors"
 )
 
@@ -39,10 +42,25 @@ func (c *fabContext) SigningManager() contextApi.SigningManager {
 }
 
 // StateStore returns state store
-func (c *sdkContext) StateStore() contextApi.KVStore {
+func (c *fabContext) StateStore() contextApi.KVStore {
 	return c.sdk.stateStore
 }
 
+// LoggerProvider returns the logger provider of sdk.
+func (c *fabContext) LoggerProvider() logApi.LoggerProvider {
+	return c.sdk.opts.Logger
+}
+
+// MetricsProvider returns the metrics provider of sdk.
+func (c *fabContext) MetricsProvider() metricsApi.Provider {
+	return c.sdk.opts.Metrics
+}
+
+// Tracer returns the tracer of sdk.
+func (c *fabContext) Tracer() tracingApi.Tracer {
+	return c.sdk.opts.Tracer
+}
+
 // DiscoveryProvider returns discovery provider
 func (c *sdkContext) DiscoveryProvider() fab.DiscoveryProvider {
 	return c.sdk.discoveryProvider
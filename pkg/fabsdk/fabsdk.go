@@ -21,9 +21,15 @@ import (
 	sdkApi "github.com/hyperledger/fabric-sdk-go/pkg/fabsdk/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk/provider/chpvdr"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
+	metricsApi "github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+	tracingApi "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
 	"github.com/pkg/errors"
 )
 
+// sdkLogModule is the parent module of all SDK loggers
+const sdkLogModule = "fabric_sdk_go"
+
 // FabricSDK provides access (and context) to clients being managed by the SDK.
 type FabricSDK struct {
 	opts options
@@ -44,6 +50,8 @@ type options struct {
 	Context sdkApi.OrgClientFactory
 	Session sdkApi.SessionClientFactory
 	Logger  api.LoggerProvider
+	Metrics metricsApi.Provider
+	Tracer  tracingApi.Tracer
 }
 
 // Option configures the SDK.
@@ -149,6 +157,11 @@ func WithSessionPkg(session sdkApi.SessionClientFactory) Option {
 }
 
 // WithLoggerPkg injects the logger implementation into the SDK.
+// Clients created by the SDK log through this provider. If the provider
+// implements loglevel.Leveler (e.g. modlog.NewProvider()), its levels are
+// scoped to the SDK instance and can be changed with SetLogLevel.
+// The provider of the first SDK is also installed as the process-wide logger
+// provider (see logging.InitLogger), which is used by code that has no SDK context.
 func WithLoggerPkg(logger api.LoggerProvider) Option {
 	return func(opts *options) error {
 		opts.Logger = logger
@@ -156,6 +169,24 @@ func WithLoggerPkg(logger api.LoggerProvider) Option {
 	}
 }
 
+// WithMetricsProvider injects the metrics provider into the SDK (e.g.
+// prometheus.NewProvider()). Metrics are disabled by default.
+func WithMetricsProvider(provider metricsApi.Provider) Option {
+	return func(opts *options) error {
+		opts.Metrics = provider
+		return nil
+	}
+}
+
+// WithTracer injects the tracer used to record the spans of transactions
+// (e.g. an OpenTracing or OpenTelemetry adapter). Tracing is disabled by default.
+func WithTracer(tracer tracingApi.Tracer) Option {
+	return func(opts *options) error {
+		opts.Tracer = tracer
+		return nil
+	}
+}
+
 // providerInit interface allows for initializing providers
 // TODO: minimize interface
 type providerInit interface {
@@ -175,6 +206,9 @@ func initSDK(sdk *FabricSDK, opts []Option) error {
 		return errors.New("Missing logger from pkg suite")
 	}
 	logging.InitLogger(sdk.opts.Logger)
+	if err := initLogLevel(sdk); err != nil {
+		return err
+	}
 
 	// Initialize crypto provider
 	cs, err := sdk.opts.Core.CreateCryptoSuiteProvider(sdk.config)
@@ -240,6 +274,59 @@ func initSDK(sdk *FabricSDK, opts []Option) error {
 	return nil
 }
 
+// initLogLevel applies the configured client logging level to the SDK logger provider
+func initLogLevel(sdk *FabricSDK) error {
+	if _, ok := sdk.opts.Logger.(loglevel.Leveler); !ok {
+		return nil
+	}
+	clientConfig, err := sdk.config.Client()
+	if err != nil || clientConfig.Logging.Level == "" {
+		return nil
+	}
+	level, err := logging.LogLevel(clientConfig.Logging.Level)
+	if err != nil {
+		return errors.WithMessage(err, "invalid client logging level")
+	}
+	sdk.SetLogLevel(sdkLogModule, level)
+	return nil
+}
+
+// SetLogLevel sets the log level of a module for this SDK instance.
+// Levels are hierarchical: setting "fabric_sdk_go" applies to all SDK modules
+// that don't have their own level. If the logger provider doesn't keep its own
+// levels, the process-wide level is set.
+func (sdk *FabricSDK) SetLogLevel(module string, level loglevel.Level) {
+	if leveler, ok := sdk.opts.Logger.(loglevel.Leveler); ok {
+		leveler.SetLevel(module, level)
+		return
+	}
+	logging.SetLevel(module, level)
+}
+
+// LogLevel returns the log level of a module for this SDK instance.
+func (sdk *FabricSDK) LogLevel(module string) loglevel.Level {
+	if leveler, ok := sdk.opts.Logger.(loglevel.Leveler); ok {
+		return leveler.GetLevel(module)
+	}
+	return logging.GetLevel(module)
+}
+
+// LoggerProvider returns the logger provider of this SDK instance.
+func (sdk *FabricSDK) LoggerProvider() api.LoggerProvider {
+	return sdk.opts.Logger
+}
+
+// MetricsProvider returns the metrics provider of this SDK instance, or nil
+// if metrics are disabled.
+func (sdk *FabricSDK) MetricsProvider() metricsApi.Provider {
+	return sdk.opts.Metrics
+}
+
+// Tracer returns the tracer of this SDK instance, or nil if tracing is disabled.
+func (sdk *FabricSDK) Tracer() tracingApi.Tracer {
+	return sdk.opts.Tracer
+}
+
 // Config returns the SDK's configuration.
 func (sdk *FabricSDK) Config() core.Config {
 	return sdk.config
@@ -261,7 +348,7 @@ func (sdk *FabricSDK) context() *sdkContext {
 
 func (sdk *FabricSDK) newUser(orgID string, userName string) (context.IdentityContext, error) {
 
-	credentialMgr, err := sdk.opts.Context.CreateCredentialManager(orgID, sdk.config, sdk.cryptoSuite)
+	credentialMgr, err := sdk.opts.Context.CreateCredentialManager(orgID, sdk.config, sdk.cryptoSuite, sdk.stateStore)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to get credential manager")
 	}
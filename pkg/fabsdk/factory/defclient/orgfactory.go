@@ -21,7 +21,8 @@ func NewOrgClientFactory() *OrgClientFactory {
 	return &f
 }
 
-// CreateCredentialManager returns a new default implementation of the credential manager
-func (f *OrgClientFactory) CreateCredentialManager(orgName string, config core.Config, cryptoProvider core.CryptoSuite) (api.CredentialManager, error) {
-	return identitymgr.New(orgName, config, cryptoProvider)
+// CreateCredentialManager returns a new default implementation of the credential manager.
+// Users are persisted into stateStore, when provided.
+func (f *OrgClientFactory) CreateCredentialManager(orgName string, config core.Config, cryptoProvider core.CryptoSuite, stateStore api.KVStore) (api.CredentialManager, error) {
+	return identitymgr.New(orgName, config, cryptoProvider, stateStore)
 }
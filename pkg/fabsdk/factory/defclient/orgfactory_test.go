@@ -28,7 +28,7 @@ func TestCreateCredentialManager(t *testing.T) {
 		t.Fatalf("Unexpected error creating cryptosuite provider %v", err)
 	}
 
-	mspClient, err := factory.CreateCredentialManager("org1", config, cryptosuite)
+	mspClient, err := factory.CreateCredentialManager("org1", config, cryptosuite, nil)
 	if err != nil {
 		t.Fatalf("Unexpected error creating credential manager %v", err)
 	}
@@ -39,6 +39,7 @@ func TestCreateChannelClient(t *testing.T) {
 	mockSDK.EXPECT().DiscoveryProvider().Return(p.DiscoveryProvider)
 	mockSDK.EXPECT().SelectionProvider().Return(p.SelectionProvider)
 	mockSDK.EXPECT().Config().Return(p.Config)
+	mockSDK.EXPECT().MetricsProvider().Return(nil)
 
 	factory := NewSessionClientFactory()
 	session := newMockSession()
@@ -14,6 +14,7 @@ import (
 	cryptosuiteimpl "github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
 	kvs "github.com/hyperledger/fabric-sdk-go/pkg/fab/keyvaluestore"
 	signingMgr "github.com/hyperledger/fabric-sdk-go/pkg/fab/signingmgr"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/signingmgr/remote"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk/provider/fabpvdr"
 	"github.com/pkg/errors"
 
@@ -24,6 +25,7 @@ import (
 
 // ProviderFactory represents the default SDK provider factory.
 type ProviderFactory struct {
+	keyStore contextApi.KVStore
 }
 
 // NewProviderFactory returns the default SDK provider factory.
@@ -32,30 +34,46 @@ func NewProviderFactory() *ProviderFactory {
 	return &f
 }
 
-// CreateStateStoreProvider creates a KeyValueStore using the SDK's default implementation
-func (f *ProviderFactory) CreateStateStoreProvider(config core.Config) (contextApi.KVStore, error) {
-
-	clientCofig, err := config.Client()
-	if err != nil {
-		return nil, errors.WithMessage(err, "Unable to retrieve client config")
-	}
-	stateStorePath := clientCofig.CredentialStore.Path
+// NewProviderFactoryWithKeyStore returns the default SDK provider factory with a
+// crypto suite that keeps private keys in keyStore rather than in the keystore
+// directory. keyStore may be the same store as the state store, e.g.
+//
+//	store, err := keyvaluestore.NewFromConfig(config)
+//	sdk, err := fabsdk.New(configProvider, fabsdk.WithCorePkg(defcore.NewProviderFactoryWithKeyStore(store)))
+func NewProviderFactoryWithKeyStore(keyStore contextApi.KVStore) *ProviderFactory {
+	return &ProviderFactory{keyStore: keyStore}
+}
 
-	stateStore, err := kvs.New(&kvs.FileKeyValueStoreOptions{Path: stateStorePath})
+// CreateStateStoreProvider creates a KeyValueStore using the SDK's default implementation.
+// The backend (file, memory or sql) is selected by client.credentialStore.type.
+func (f *ProviderFactory) CreateStateStoreProvider(config core.Config) (contextApi.KVStore, error) {
+	stateStore, err := kvs.NewFromConfig(config)
 	if err != nil {
-		return nil, errors.WithMessage(err, "CreateNewFileKeyValueStore failed")
+		return nil, errors.WithMessage(err, "CreateNewKeyValueStore failed")
 	}
 	return stateStore, nil
 }
 
 // CreateCryptoSuiteProvider returns a new default implementation of BCCSP
 func (f *ProviderFactory) CreateCryptoSuiteProvider(config core.Config) (core.CryptoSuite, error) {
+	if f.keyStore != nil {
+		return cryptosuiteimpl.GetSuiteWithKVStore(config, f.keyStore)
+	}
 	cryptoSuiteProvider, err := cryptosuiteimpl.GetSuiteByConfig(config)
 	return cryptoSuiteProvider, err
 }
 
-// CreateSigningManager returns a new default implementation of signing manager
+// CreateSigningManager returns a new default implementation of signing manager.
+// If a remote signing service is configured (client.signingService) then keys held
+// by the service (see signingmgr/remote) are signed remotely.
 func (f *ProviderFactory) CreateSigningManager(cryptoProvider core.CryptoSuite, config core.Config) (contextApi.SigningManager, error) {
+	clientConfig, err := config.Client()
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to retrieve client config")
+	}
+	if clientConfig != nil && clientConfig.SigningService.URL != "" {
+		return remote.NewFromConfig(cryptoProvider, config)
+	}
 	return signingMgr.New(cryptoProvider, config)
 }
 
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package defcore
 
 import (
+	"encoding/hex"
 	"errors"
 	"testing"
 
@@ -14,10 +15,12 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context/api/core/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
 	cryptosuitewrapper "github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/wrapper"
 	kvs "github.com/hyperledger/fabric-sdk-go/pkg/fab/keyvaluestore"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	signingMgr "github.com/hyperledger/fabric-sdk-go/pkg/fab/signingmgr"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/signingmgr/remote"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk/provider/fabpvdr"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging/modlog"
 )
@@ -113,6 +116,25 @@ func TestCreateCryptoSuiteProvider(t *testing.T) {
 	}
 }
 
+func TestCreateCryptoSuiteProviderWithKeyStore(t *testing.T) {
+	store := kvs.NewMemKeyValueStore(nil)
+	factory := NewProviderFactoryWithKeyStore(store)
+	config := mocks.NewMockConfig()
+
+	cs, err := factory.CreateCryptoSuiteProvider(config)
+	if err != nil {
+		t.Fatalf("Unexpected error creating cryptosuite provider %v", err)
+	}
+
+	key, err := cs.KeyGen(cryptosuite.GetECDSAP256KeyGenOpts(false))
+	if err != nil {
+		t.Fatalf("Unexpected error generating key %v", err)
+	}
+	if _, err := store.Load(hex.EncodeToString(key.SKI()) + "_sk"); err != nil {
+		t.Fatalf("Expected private key to be stored in the key store: %v", err)
+	}
+}
+
 func TestCreateSigningManager(t *testing.T) {
 	factory := NewProviderFactory()
 	config := mocks.NewMockConfig()
@@ -133,6 +155,44 @@ func TestCreateSigningManager(t *testing.T) {
 	}
 }
 
+func TestCreateRemoteSigningManager(t *testing.T) {
+	factory := NewProviderFactory()
+	config := &signingServiceConfig{
+		Config: mocks.NewMockConfig(),
+		url:    "https://localhost:7443/sign",
+	}
+
+	cryptosuite, err := factory.CreateCryptoSuiteProvider(config)
+	if err != nil {
+		t.Fatalf("Unexpected error creating cryptosuite provider %v", err)
+	}
+
+	signer, err := factory.CreateSigningManager(cryptosuite, config)
+	if err != nil {
+		t.Fatalf("Unexpected error creating signing manager %v", err)
+	}
+
+	_, ok := signer.(*remote.SigningManager)
+	if !ok {
+		t.Fatalf("Expected remote signing manager to be created for a configured signing service")
+	}
+}
+
+// signingServiceConfig configures a signing service URL in the client config
+type signingServiceConfig struct {
+	core.Config
+	url string
+}
+
+func (c *signingServiceConfig) Client() (*core.ClientConfig, error) {
+	clientConfig, err := c.Config.Client()
+	if err != nil {
+		return nil, err
+	}
+	clientConfig.SigningService.URL = c.url
+	return clientConfig, nil
+}
+
 func TestNewFactoryFabricProvider(t *testing.T) {
 	factory := NewProviderFactory()
 	ctx := mocks.NewMockProviderContext()
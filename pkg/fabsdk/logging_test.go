@@ -0,0 +1,49 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabsdk
+
+import (
+	"testing"
+
+	configImpl "github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/modlog"
+)
+
+func TestSDKScopedLogLevels(t *testing.T) {
+	lp1 := modlog.NewProvider()
+	sdk1, err := New(configImpl.FromFile(sdkConfigFile), WithLoggerPkg(lp1))
+	if err != nil {
+		t.Fatalf("Error initializing SDK: %s", err)
+	}
+	lp2 := modlog.NewProvider()
+	sdk2, err := New(configImpl.FromFile(sdkConfigFile), WithLoggerPkg(lp2))
+	if err != nil {
+		t.Fatalf("Error initializing SDK: %s", err)
+	}
+
+	if sdk1.fabContext().LoggerProvider() != lp1 || sdk2.fabContext().LoggerProvider() != lp2 {
+		t.Fatal("expected each SDK context to supply its own logger provider")
+	}
+
+	const module = "fabric_sdk_go/invoke"
+	// client.logging.level from config is applied to the SDK modules
+	if sdk1.LogLevel(module) != loglevel.INFO {
+		t.Fatalf("expected configured INFO level, got %s", loglevel.ParseString(sdk1.LogLevel(module)))
+	}
+
+	sdk1.SetLogLevel(module, loglevel.DEBUG)
+	if !lp1.IsEnabledFor(module, loglevel.DEBUG) {
+		t.Fatal("expected DEBUG to be enabled for the first SDK")
+	}
+	if lp2.IsEnabledFor(module, loglevel.DEBUG) || sdk2.LogLevel(module) != loglevel.INFO {
+		t.Fatal("log level change must not affect the second SDK")
+	}
+	if modlog.IsEnabledFor(module, loglevel.DEBUG) {
+		t.Fatal("log level change must not affect the process-wide levels")
+	}
+}
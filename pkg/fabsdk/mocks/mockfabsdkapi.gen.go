@@ -14,6 +14,9 @@ import (
 	core "github.com/hyperledger/fabric-sdk-go/pkg/context/api/core"
 	fab "github.com/hyperledger/fabric-sdk-go/pkg/context/api/fab"
 	api0 "github.com/hyperledger/fabric-sdk-go/pkg/fabsdk/api"
+	api1 "github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	api2 "github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+	api3 "github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
 )
 
 // MockCoreProviders is a mock of CoreProviders interface
@@ -75,6 +78,30 @@ func (mr *MockCoreProvidersMockRecorder) FabricProvider() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FabricProvider", reflect.TypeOf((*MockCoreProviders)(nil).FabricProvider))
 }
 
+// LoggerProvider mocks base method
+func (m *MockCoreProviders) LoggerProvider() api1.LoggerProvider {
+	ret := m.ctrl.Call(m, "LoggerProvider")
+	ret0, _ := ret[0].(api1.LoggerProvider)
+	return ret0
+}
+
+// LoggerProvider indicates an expected call of LoggerProvider
+func (mr *MockCoreProvidersMockRecorder) LoggerProvider() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoggerProvider", reflect.TypeOf((*MockCoreProviders)(nil).LoggerProvider))
+}
+
+// MetricsProvider mocks base method
+func (m *MockCoreProviders) MetricsProvider() api2.Provider {
+	ret := m.ctrl.Call(m, "MetricsProvider")
+	ret0, _ := ret[0].(api2.Provider)
+	return ret0
+}
+
+// MetricsProvider indicates an expected call of MetricsProvider
+func (mr *MockCoreProvidersMockRecorder) MetricsProvider() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetricsProvider", reflect.TypeOf((*MockCoreProviders)(nil).MetricsProvider))
+}
+
 // SigningManager mocks base method
 func (m *MockCoreProviders) SigningManager() api.SigningManager {
 	ret := m.ctrl.Call(m, "SigningManager")
@@ -87,6 +114,18 @@ func (mr *MockCoreProvidersMockRecorder) SigningManager() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SigningManager", reflect.TypeOf((*MockCoreProviders)(nil).SigningManager))
 }
 
+// Tracer mocks base method
+func (m *MockCoreProviders) Tracer() api3.Tracer {
+	ret := m.ctrl.Call(m, "Tracer")
+	ret0, _ := ret[0].(api3.Tracer)
+	return ret0
+}
+
+// Tracer indicates an expected call of Tracer
+func (mr *MockCoreProvidersMockRecorder) Tracer() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tracer", reflect.TypeOf((*MockCoreProviders)(nil).Tracer))
+}
+
 // StateStore mocks base method
 func (m *MockCoreProviders) StateStore() api.KVStore {
 	ret := m.ctrl.Call(m, "StateStore")
@@ -253,6 +292,30 @@ func (mr *MockProvidersMockRecorder) SelectionProvider() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectionProvider", reflect.TypeOf((*MockProviders)(nil).SelectionProvider))
 }
 
+// LoggerProvider mocks base method
+func (m *MockProviders) LoggerProvider() api1.LoggerProvider {
+	ret := m.ctrl.Call(m, "LoggerProvider")
+	ret0, _ := ret[0].(api1.LoggerProvider)
+	return ret0
+}
+
+// LoggerProvider indicates an expected call of LoggerProvider
+func (mr *MockProvidersMockRecorder) LoggerProvider() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoggerProvider", reflect.TypeOf((*MockProviders)(nil).LoggerProvider))
+}
+
+// MetricsProvider mocks base method
+func (m *MockProviders) MetricsProvider() api2.Provider {
+	ret := m.ctrl.Call(m, "MetricsProvider")
+	ret0, _ := ret[0].(api2.Provider)
+	return ret0
+}
+
+// MetricsProvider indicates an expected call of MetricsProvider
+func (mr *MockProvidersMockRecorder) MetricsProvider() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetricsProvider", reflect.TypeOf((*MockProviders)(nil).MetricsProvider))
+}
+
 // SigningManager mocks base method
 func (m *MockProviders) SigningManager() api.SigningManager {
 	ret := m.ctrl.Call(m, "SigningManager")
@@ -265,6 +328,18 @@ func (mr *MockProvidersMockRecorder) SigningManager() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SigningManager", reflect.TypeOf((*MockProviders)(nil).SigningManager))
 }
 
+// Tracer mocks base method
+func (m *MockProviders) Tracer() api3.Tracer {
+	ret := m.ctrl.Call(m, "Tracer")
+	ret0, _ := ret[0].(api3.Tracer)
+	return ret0
+}
+
+// Tracer indicates an expected call of Tracer
+func (mr *MockProvidersMockRecorder) Tracer() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tracer", reflect.TypeOf((*MockProviders)(nil).Tracer))
+}
+
 // StateStore mocks base method
 func (m *MockProviders) StateStore() api.KVStore {
 	ret := m.ctrl.Call(m, "StateStore")
@@ -425,16 +500,16 @@ func (m *MockOrgClientFactory) EXPECT() *MockOrgClientFactoryMockRecorder {
 }
 
 // CreateCredentialManager mocks base method
-func (m *MockOrgClientFactory) CreateCredentialManager(arg0 string, arg1 core.Config, arg2 core.CryptoSuite) (api.CredentialManager, error) {
-	ret := m.ctrl.Call(m, "CreateCredentialManager", arg0, arg1, arg2)
+func (m *MockOrgClientFactory) CreateCredentialManager(arg0 string, arg1 core.Config, arg2 core.CryptoSuite, arg3 api.KVStore) (api.CredentialManager, error) {
+	ret := m.ctrl.Call(m, "CreateCredentialManager", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(api.CredentialManager)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateCredentialManager indicates an expected call of CreateCredentialManager
-func (mr *MockOrgClientFactoryMockRecorder) CreateCredentialManager(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredentialManager", reflect.TypeOf((*MockOrgClientFactory)(nil).CreateCredentialManager), arg0, arg1, arg2)
+func (mr *MockOrgClientFactoryMockRecorder) CreateCredentialManager(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredentialManager", reflect.TypeOf((*MockOrgClientFactory)(nil).CreateCredentialManager), arg0, arg1, arg2, arg3)
 }
 
 // MockSessionClientFactory is a mock of SessionClientFactory interface
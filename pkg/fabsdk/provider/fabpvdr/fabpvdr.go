@@ -28,6 +28,11 @@ type FabricProvider struct {
 	providerContext context.ProviderContext
 }
 
+// stateStoreProvider is implemented by provider contexts that expose the SDK state store
+type stateStoreProvider interface {
+	StateStore() contextApi.KVStore
+}
+
 type fabContext struct {
 	context.ProviderContext
 	context.IdentityContext
@@ -129,9 +134,19 @@ func (f *FabricProvider) CreateChannelTransactor(ic context.IdentityContext, cfg
 	return channelImpl.NewTransactor(ctx, cfg)
 }
 
-// CreateIdentityManager returns a new IdentityManager for an organization
+// CreateIdentityManager returns a new IdentityManager for an organization.
+// Users are persisted into the provider context's state store, if it has one.
 func (f *FabricProvider) CreateIdentityManager(orgID string) (fab.IdentityManager, error) {
-	return identitymgr.New(orgID, f.providerContext.Config(), f.providerContext.CryptoSuite())
+	var stateStore contextApi.KVStore
+	if sp, ok := f.providerContext.(stateStoreProvider); ok {
+		stateStore = sp.StateStore()
+	}
+	mgr, err := identitymgr.New(orgID, f.providerContext.Config(), f.providerContext.CryptoSuite(), stateStore)
+	if err != nil {
+		return nil, err
+	}
+	mgr.SetLoggerProvider(f.providerContext.LoggerProvider())
+	return mgr, nil
 }
 
 // CreateUser returns a new default implementation of a User.
@@ -147,12 +162,12 @@ func (f *FabricProvider) CreateUser(name string, signingIdentity *contextApi.Sig
 
 // CreatePeerFromConfig returns a new default implementation of Peer based configuration
 func (f *FabricProvider) CreatePeerFromConfig(peerCfg *core.NetworkPeer) (fab.Peer, error) {
-	return peerImpl.New(f.providerContext.Config(), peerImpl.FromPeerConfig(peerCfg))
+	return peerImpl.New(f.providerContext.Config(), peerImpl.FromPeerConfig(peerCfg), peerImpl.WithLoggerProvider(f.providerContext.LoggerProvider()))
 }
 
 // CreateOrdererFromConfig creates a default implementation of Orderer based on configuration.
 func (f *FabricProvider) CreateOrdererFromConfig(cfg *core.OrdererConfig) (fab.Orderer, error) {
-	orderer, err := orderer.New(f.providerContext.Config(), orderer.FromOrdererConfig(cfg))
+	orderer, err := orderer.New(f.providerContext.Config(), orderer.FromOrdererConfig(cfg), orderer.WithLoggerProvider(f.providerContext.LoggerProvider()))
 	if err != nil {
 		return nil, errors.WithMessage(err, "creating orderer failed")
 	}
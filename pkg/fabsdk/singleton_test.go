@@ -79,6 +79,36 @@ func TestOptLoggerFactory(t *testing.T) {
 	}
 }
 
+func TestContextLoggerFactory(t *testing.T) {
+	// Cleanup logging singleton
+	logging.UnsafeReset()
+
+	// the first SDK's logger factory is the process-wide logger factory
+	_, err := New(configImpl.FromFile(sdkConfigFile))
+	if err != nil {
+		t.Fatalf("Error initializing SDK: %s", err)
+	}
+
+	lf := NewMockLoggerFactory()
+	sdk, err := New(configImpl.FromFile(sdkConfigFile),
+		WithLoggerPkg(lf))
+	if err != nil {
+		t.Fatalf("Error initializing SDK: %s", err)
+	}
+
+	const moduleName = "mymodule"
+	l, err := logging.GetLogger(moduleName)
+	if err != nil {
+		t.Fatal("Unexpected error getting logger")
+	}
+
+	// loggers bound to the SDK context log through the SDK's logger factory
+	l.ForProvider(sdk.fabContext().LoggerProvider()).Info("message")
+	if !lf.ActiveModules[moduleName] {
+		t.Fatal("Expected the SDK logger factory to be used")
+	}
+}
+
 // MockLoggerFactory records the modules that have loggers
 type MockLoggerFactory struct {
 	ActiveModules map[string]bool
@@ -51,6 +51,18 @@ type Logger interface {
 	Errorln(args ...interface{})
 }
 
+// StructuredLogger is a Logger that attaches key/value fields to log lines.
+// Loggers returned by a LoggerProvider may implement it to receive fields
+// natively (e.g. as zap or logrus fields) instead of having them rendered
+// into the message.
+type StructuredLogger interface {
+	Logger
+
+	// With returns a child logger adding the given alternating keys and values
+	// to every line it logs
+	With(keysAndValues ...interface{}) StructuredLogger
+}
+
 // LoggerProvider is a factory for module loggers
 // TODO: should this be renamed to LoggerFactory?
 type LoggerProvider interface {
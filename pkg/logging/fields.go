@@ -0,0 +1,200 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+)
+
+// Field keys used to tag log lines consistently across packages
+const (
+	// ChannelIDKey tags the channel ID
+	ChannelIDKey = "channel"
+	// TxIDKey tags the transaction ID
+	TxIDKey = "txID"
+	// EndorserKey tags the URL of an endorsing peer
+	EndorserKey = "endorser"
+	// OrdererKey tags the URL of an orderer
+	OrdererKey = "orderer"
+	// PeerKey tags the URL of a peer serving events
+	PeerKey = "peer"
+)
+
+// With returns a child logger adding the given alternating keys and values
+// to every line it logs, e.g. logger.With(logging.TxIDKey, txID).
+// If the logger provider returns api.StructuredLogger instances, the fields are
+// passed on natively; otherwise they are appended to the message as key=value.
+func (l *Logger) With(keysAndValues ...interface{}) *Logger {
+	if len(keysAndValues) == 0 {
+		return l
+	}
+	f := make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+	f = append(f, l.fields...)
+	f = append(f, keysAndValues...)
+	return &Logger{module: l.module, fields: f, provider: l.provider}
+}
+
+// withFields returns a logger adding fields to every line logged by instance
+func withFields(instance api.Logger, keysAndValues []interface{}) api.Logger {
+	if len(keysAndValues) == 0 {
+		return instance
+	}
+	if structured, ok := instance.(api.StructuredLogger); ok {
+		return structured.With(keysAndValues...)
+	}
+	return &fieldLogger{Logger: instance, fields: fields(keysAndValues)}
+}
+
+// fields renders alternating keys and values as "key=value" pairs
+type fields []interface{}
+
+func (f fields) String() string {
+	var buf bytes.Buffer
+	for i := 0; i < len(f); i += 2 {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		fmt.Fprintf(&buf, "%v=", f[i])
+		if i+1 < len(f) {
+			fmt.Fprintf(&buf, "%v", f[i+1])
+		}
+	}
+	return buf.String()
+}
+
+const (
+	printStyle = iota
+	printfStyle
+	printlnStyle
+)
+
+// message is formatted only when (and if) it is logged
+type message struct {
+	style  int
+	format string
+	args   []interface{}
+	fields fields
+}
+
+func (m *message) String() string {
+	var msg string
+	switch m.style {
+	case printfStyle:
+		msg = strings.TrimSuffix(fmt.Sprintf(m.format, m.args...), "\n")
+	case printlnStyle:
+		msg = strings.TrimSuffix(fmt.Sprintln(m.args...), "\n")
+	default:
+		msg = strings.TrimSuffix(fmt.Sprint(m.args...), "\n")
+	}
+	return msg + " " + m.fields.String()
+}
+
+// fieldLogger appends fields to the messages logged by a Logger without native field support
+type fieldLogger struct {
+	api.Logger
+	fields fields
+}
+
+func (l *fieldLogger) msg(args []interface{}) *message {
+	return &message{style: printStyle, args: args, fields: l.fields}
+}
+
+func (l *fieldLogger) msgf(format string, args []interface{}) *message {
+	return &message{style: printfStyle, format: format, args: args, fields: l.fields}
+}
+
+func (l *fieldLogger) msgln(args []interface{}) *message {
+	return &message{style: printlnStyle, args: args, fields: l.fields}
+}
+
+func (l *fieldLogger) Fatal(args ...interface{}) {
+	l.Logger.Fatal(l.msg(args))
+}
+
+func (l *fieldLogger) Fatalf(format string, args ...interface{}) {
+	l.Logger.Fatal(l.msgf(format, args))
+}
+
+func (l *fieldLogger) Fatalln(args ...interface{}) {
+	l.Logger.Fatalln(l.msgln(args))
+}
+
+func (l *fieldLogger) Panic(args ...interface{}) {
+	l.Logger.Panic(l.msg(args))
+}
+
+func (l *fieldLogger) Panicf(format string, args ...interface{}) {
+	l.Logger.Panic(l.msgf(format, args))
+}
+
+func (l *fieldLogger) Panicln(args ...interface{}) {
+	l.Logger.Panicln(l.msgln(args))
+}
+
+func (l *fieldLogger) Print(args ...interface{}) {
+	l.Logger.Print(l.msg(args))
+}
+
+func (l *fieldLogger) Printf(format string, args ...interface{}) {
+	l.Logger.Print(l.msgf(format, args))
+}
+
+func (l *fieldLogger) Println(args ...interface{}) {
+	l.Logger.Println(l.msgln(args))
+}
+
+func (l *fieldLogger) Debug(args ...interface{}) {
+	l.Logger.Debug(l.msg(args))
+}
+
+func (l *fieldLogger) Debugf(format string, args ...interface{}) {
+	l.Logger.Debug(l.msgf(format, args))
+}
+
+func (l *fieldLogger) Debugln(args ...interface{}) {
+	l.Logger.Debugln(l.msgln(args))
+}
+
+func (l *fieldLogger) Info(args ...interface{}) {
+	l.Logger.Info(l.msg(args))
+}
+
+func (l *fieldLogger) Infof(format string, args ...interface{}) {
+	l.Logger.Info(l.msgf(format, args))
+}
+
+func (l *fieldLogger) Infoln(args ...interface{}) {
+	l.Logger.Infoln(l.msgln(args))
+}
+
+func (l *fieldLogger) Warn(args ...interface{}) {
+	l.Logger.Warn(l.msg(args))
+}
+
+func (l *fieldLogger) Warnf(format string, args ...interface{}) {
+	l.Logger.Warn(l.msgf(format, args))
+}
+
+func (l *fieldLogger) Warnln(args ...interface{}) {
+	l.Logger.Warnln(l.msgln(args))
+}
+
+func (l *fieldLogger) Error(args ...interface{}) {
+	l.Logger.Error(l.msg(args))
+}
+
+func (l *fieldLogger) Errorf(format string, args ...interface{}) {
+	l.Logger.Error(l.msgf(format, args))
+}
+
+func (l *fieldLogger) Errorln(args ...interface{}) {
+	l.Logger.Errorln(l.msgln(args))
+}
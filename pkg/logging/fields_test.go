@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/modlog"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/structured"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/testdata"
+)
+
+func TestLoggerWithFields(t *testing.T) {
+	resetLoggerInstance()
+	InitLogger(modlog.LoggerProvider())
+
+	var output bytes.Buffer
+	txLogger := NewLogger(moduleName).With(ChannelIDKey, "mychannel").With(TxIDKey, "tx1")
+	txLogger.logger().(*fieldLogger).Logger.(*modlog.Log).ChangeOutput(&output)
+
+	txLogger.Infof("sending %s", "proposal")
+	line := output.String()
+	if !strings.Contains(line, "sending proposal channel=mychannel txID=tx1") {
+		t.Fatalf("expected fields to be appended to message, got [%s]", line)
+	}
+
+	output.Reset()
+	txLogger.Debug("not logged")
+	if output.Len() != 0 {
+		t.Fatalf("debug log isn't supposed to show up for info level: [%s]", output.String())
+	}
+}
+
+func TestLoggerWithFieldsStructuredProvider(t *testing.T) {
+	resetLoggerInstance()
+
+	var kv []interface{}
+	var msg string
+	InitLogger(structured.NewProvider(structured.SinkFunc(func(module string, level loglevel.Level, m string, keysAndValues []interface{}) {
+		msg, kv = m, keysAndValues
+	})))
+	defer resetLoggerInstance()
+
+	logger := NewLogger(moduleName).With(EndorserKey, "peer0:7051")
+	if _, ok := logger.logger().(api.StructuredLogger); !ok {
+		t.Fatal("expected fields to be passed to the structured logger natively")
+	}
+	logger.Warnf("endorsement %s", "failed")
+	if msg != "endorsement failed" || len(kv) != 2 || kv[0] != EndorserKey || kv[1] != "peer0:7051" {
+		t.Fatalf("unexpected structured log line: %s %v", msg, kv)
+	}
+}
+
+func TestLoggerForProvider(t *testing.T) {
+	resetLoggerInstance()
+	InitLogger(modlog.LoggerProvider())
+
+	var output bytes.Buffer
+	provider := testdata.GetSampleLoggingProvider(&output)
+	logger := NewLogger(moduleName).With(TxIDKey, "tx1").ForProvider(provider)
+
+	logger.Info("brown fox jumps over the lazy dog")
+	if !strings.Contains(output.String(), "CUSTOM LOG OUTPUT") {
+		t.Fatalf("expected logger to log through the given provider, got [%s]", output.String())
+	}
+
+	output.Reset()
+	logger.With(ChannelIDKey, "mychannel").Info("brown fox jumps over the lazy dog")
+	if !strings.Contains(output.String(), "CUSTOM LOG OUTPUT") {
+		t.Fatalf("expected child logger to keep the provider, got [%s]", output.String())
+	}
+
+	if NewLogger(moduleName).ForProvider(nil).provider != nil {
+		t.Fatal("nil provider should keep the process-wide provider")
+	}
+}
@@ -18,6 +18,8 @@ import (
 type Logger struct {
 	instance api.Logger // access only via Logger.logger()
 	module   string
+	fields   []interface{}
+	provider api.LoggerProvider
 	once     sync.Once
 }
 
@@ -60,6 +62,22 @@ func loggerProvider() api.LoggerProvider {
 	return loggerProviderInstance
 }
 
+// LoggerProvider returns the process-wide logger provider, i.e. the provider set by
+// InitLogger or the default provider if none was set.
+func LoggerProvider() api.LoggerProvider {
+	return loggerProvider()
+}
+
+// ForProvider returns a logger for the same module and fields that logs through
+// provider (e.g. the logger provider of an SDK instance) instead of the
+// process-wide logger provider. A nil provider returns l.
+func (l *Logger) ForProvider(provider api.LoggerProvider) *Logger {
+	if provider == nil {
+		return l
+	}
+	return &Logger{module: l.module, fields: l.fields, provider: provider}
+}
+
 //InitLogger sets new logger which takes over logging operations.
 //It is required to call this function before making any loggings.
 func InitLogger(l api.LoggerProvider) {
@@ -203,7 +221,11 @@ func (l *Logger) Errorln(args ...interface{}) {
 
 func (l *Logger) logger() api.Logger {
 	l.once.Do(func() {
-		l.instance = loggerProvider().GetLogger(l.module)
+		provider := l.provider
+		if provider == nil {
+			provider = loggerProvider()
+		}
+		l.instance = withFields(provider.GetLogger(l.module), l.fields)
 	})
 	return l.instance
 }
@@ -6,13 +6,16 @@ SPDX-License-Identifier: Apache-2.0
 
 package loglevel
 
-// TODO: Leveler allows log levels to be enabled or disabled
-//type Leveler interface {
-//	SetLevel(module string, level Level)
-//	GetLevel(module string) Level
-//	IsEnabledFor(module string, level Level) bool
-//	LogLevel(level string) (Level, error)
-//}
+import "strings"
+
+// Leveler allows log levels to be enabled or disabled.
+// Logger providers that keep their own module levels implement it
+// so that levels can be changed per provider.
+type Leveler interface {
+	SetLevel(module string, level Level)
+	GetLevel(module string) Level
+	IsEnabledFor(module string, level Level) bool
+}
 
 // Level defines all available log levels for log messages.
 type Level int
@@ -32,14 +35,22 @@ type ModuleLevels struct {
 }
 
 // GetLevel returns the log level for the given module.
+// Modules are hierarchical: "a/b" inherits the level of "a" unless set explicitly.
 func (l *ModuleLevels) GetLevel(module string) Level {
-	level, exists := l.levels[module]
-	if exists == false {
-		level, exists = l.levels[""]
-		// no configuration exists, default to info
-		if exists == false {
-			level = INFO
+	for {
+		if level, exists := l.levels[module]; exists {
+			return level
 		}
+		i := strings.LastIndex(module, "/")
+		if i < 0 {
+			break
+		}
+		module = module[:i]
+	}
+	level, exists := l.levels[""]
+	// no configuration exists, default to info
+	if exists == false {
+		level = INFO
 	}
 	return level
 }
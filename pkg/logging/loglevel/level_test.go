@@ -51,3 +51,19 @@ func TestLogLevels(t *testing.T) {
 	testutils.VerifyTrue(t, mlevel.IsEnabledFor("module-xyz-random-module", WARNING))
 
 }
+
+func TestHierarchicalLogLevels(t *testing.T) {
+
+	mlevel := ModuleLevels{}
+
+	mlevel.SetLevel("", ERROR)
+	mlevel.SetLevel("sdk", DEBUG)
+	mlevel.SetLevel("sdk/events", WARNING)
+
+	//Sub-modules inherit the level of their closest configured parent
+	testutils.VerifyTrue(t, mlevel.GetLevel("sdk/txn") == DEBUG)
+	testutils.VerifyTrue(t, mlevel.GetLevel("sdk/events") == WARNING)
+	testutils.VerifyTrue(t, mlevel.GetLevel("sdk/events/deliver") == WARNING)
+	testutils.VerifyTrue(t, mlevel.GetLevel("other/txn") == ERROR)
+	testutils.VerifyTrue(t, mlevel.GetLevel("sdkx") == ERROR)
+}
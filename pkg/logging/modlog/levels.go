@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package modlog
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/decorator"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
+)
+
+// Levels maintains module log levels and caller info settings.
+// It implements loglevel.Leveler and is safe for concurrent use.
+type Levels struct {
+	rwmutex      sync.RWMutex
+	moduleLevels loglevel.ModuleLevels
+	callerInfos  decorator.CallerInfo
+}
+
+// NewLevels returns empty levels (all modules log at INFO level)
+func NewLevels() *Levels {
+	return &Levels{}
+}
+
+// SetLevel - setting log level for given module
+func (l *Levels) SetLevel(module string, level loglevel.Level) {
+	l.rwmutex.Lock()
+	defer l.rwmutex.Unlock()
+	l.moduleLevels.SetLevel(module, level)
+}
+
+// GetLevel - getting log level for given module
+func (l *Levels) GetLevel(module string) loglevel.Level {
+	l.rwmutex.RLock()
+	defer l.rwmutex.RUnlock()
+	return l.moduleLevels.GetLevel(module)
+}
+
+// IsEnabledFor - Check if given log level is enabled for given module
+func (l *Levels) IsEnabledFor(module string, level loglevel.Level) bool {
+	l.rwmutex.RLock()
+	defer l.rwmutex.RUnlock()
+	return l.moduleLevels.IsEnabledFor(module, level)
+}
+
+// ShowCallerInfo - Show caller info in log lines for given log level
+func (l *Levels) ShowCallerInfo(module string, level loglevel.Level) {
+	l.rwmutex.Lock()
+	defer l.rwmutex.Unlock()
+	l.callerInfos.ShowCallerInfo(module, level)
+}
+
+// HideCallerInfo - Do not show caller info in log lines for given log level
+func (l *Levels) HideCallerInfo(module string, level loglevel.Level) {
+	l.rwmutex.Lock()
+	defer l.rwmutex.Unlock()
+	l.callerInfos.HideCallerInfo(module, level)
+}
+
+// loggerOpts - returns LoggerOpts which can be used for customization.
+// nil levels fall back to the process-wide levels.
+func (l *Levels) loggerOpts(module string, level loglevel.Level) *loggerOpts {
+	if l == nil {
+		l = defaultLevels
+	}
+	l.rwmutex.RLock()
+	defer l.rwmutex.RUnlock()
+	return &loggerOpts{
+		levelEnabled:      l.moduleLevels.IsEnabledFor(module, level),
+		callerInfoEnabled: l.callerInfos.IsCallerInfoEnabled(module, level),
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package modlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
+)
+
+func TestScopedProviders(t *testing.T) {
+	const module = "module-scoped"
+
+	p1 := NewProvider()
+	p2 := NewProvider()
+	p1.SetLevel(module, loglevel.DEBUG)
+
+	var buf1, buf2 bytes.Buffer
+	l1 := p1.GetLogger(module)
+	l1.(*Log).ChangeOutput(&buf1)
+	l2 := p2.GetLogger(module)
+	l2.(*Log).ChangeOutput(&buf2)
+
+	l1.Debug("brown fox jumps over the lazy dog")
+	l2.Debug("brown fox jumps over the lazy dog")
+
+	if !strings.Contains(buf1.String(), "DEBU brown fox jumps over the lazy dog") {
+		t.Fatalf("expected debug output from first provider, got [%s]", buf1.String())
+	}
+	if buf2.Len() != 0 {
+		t.Fatalf("second provider isn't supposed to log at debug level: [%s]", buf2.String())
+	}
+	if IsEnabledFor(module, loglevel.DEBUG) {
+		t.Fatal("scoped provider levels must not change process-wide levels")
+	}
+	if p2.GetLevel(module) != loglevel.INFO {
+		t.Fatalf("expected default INFO level, got %s", loglevel.ParseString(p2.GetLevel(module)))
+	}
+}
@@ -21,13 +21,11 @@ import (
 	"sync/atomic"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
-	"github.com/hyperledger/fabric-sdk-go/pkg/logging/decorator"
 	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
 )
 
-var rwmutex = &sync.RWMutex{}
-var moduleLevels = &loglevel.ModuleLevels{}
-var callerInfos = &decorator.CallerInfo{}
+// process-wide levels used by LoggerProvider() and the package level functions
+var defaultLevels = NewLevels()
 var useCustomLogger int32
 
 // default logger factory singleton
@@ -36,12 +34,14 @@ var loggerProviderOnce sync.Once
 
 // Provider is the default logger implementation
 type Provider struct {
+	levels *Levels
+	scoped bool
 }
 
 //GetLogger returns SDK logger implementation
 func (p *Provider) GetLogger(module string) api.Logger {
 	newDefLogger := log.New(os.Stdout, fmt.Sprintf(logPrefixFormatter, module), log.Ldate|log.Ltime|log.LUTC)
-	return &Log{deflogger: newDefLogger, module: module}
+	return &Log{deflogger: newDefLogger, module: module, levels: p.levels, scoped: p.scoped}
 }
 
 //LoggerProvider returns logging provider for SDK logger
@@ -49,6 +49,45 @@ func LoggerProvider() api.LoggerProvider {
 	return &Provider{}
 }
 
+//NewProvider returns a logging provider with its own module levels and caller info
+//settings, independent of the package level functions and of the custom logger
+//set by InitLogger. It allows each SDK instance to log differently.
+func NewProvider() *Provider {
+	return &Provider{levels: NewLevels(), scoped: true}
+}
+
+func (p *Provider) getLevels() *Levels {
+	if p.levels == nil {
+		return defaultLevels
+	}
+	return p.levels
+}
+
+//SetLevel - setting log level for given module
+func (p *Provider) SetLevel(module string, level loglevel.Level) {
+	p.getLevels().SetLevel(module, level)
+}
+
+//GetLevel - getting log level for given module
+func (p *Provider) GetLevel(module string) loglevel.Level {
+	return p.getLevels().GetLevel(module)
+}
+
+//IsEnabledFor - Check if given log level is enabled for given module
+func (p *Provider) IsEnabledFor(module string, level loglevel.Level) bool {
+	return p.getLevels().IsEnabledFor(module, level)
+}
+
+//ShowCallerInfo - Show caller info in log lines for given log level
+func (p *Provider) ShowCallerInfo(module string, level loglevel.Level) {
+	p.getLevels().ShowCallerInfo(module, level)
+}
+
+//HideCallerInfo - Do not show caller info in log lines for given log level
+func (p *Provider) HideCallerInfo(module string, level loglevel.Level) {
+	p.getLevels().HideCallerInfo(module, level)
+}
+
 //InitLogger sets custom logger which will be used over deflogger.
 //It is required to call this function before making any loggings.
 func InitLogger(l api.LoggerProvider) {
@@ -63,6 +102,8 @@ type Log struct {
 	deflogger    *log.Logger
 	customLogger api.Logger
 	module       string
+	levels       *Levels
+	scoped       bool
 	custom       bool
 	once         sync.Once
 }
@@ -81,52 +122,37 @@ const (
 
 //SetLevel - setting log level for given module
 func SetLevel(module string, level loglevel.Level) {
-	rwmutex.Lock()
-	defer rwmutex.Unlock()
-	moduleLevels.SetLevel(module, level)
+	defaultLevels.SetLevel(module, level)
 }
 
 //GetLevel - getting log level for given module
 func GetLevel(module string) loglevel.Level {
-	rwmutex.RLock()
-	defer rwmutex.RUnlock()
-	return moduleLevels.GetLevel(module)
+	return defaultLevels.GetLevel(module)
 }
 
 //IsEnabledFor - Check if given log level is enabled for given module
 func IsEnabledFor(module string, level loglevel.Level) bool {
-	rwmutex.RLock()
-	defer rwmutex.RUnlock()
-	return moduleLevels.IsEnabledFor(module, level)
+	return defaultLevels.IsEnabledFor(module, level)
 }
 
 //ShowCallerInfo - Show caller info in log lines for given log level
 func ShowCallerInfo(module string, level loglevel.Level) {
-	rwmutex.Lock()
-	defer rwmutex.Unlock()
-	callerInfos.ShowCallerInfo(module, level)
+	defaultLevels.ShowCallerInfo(module, level)
 }
 
 //HideCallerInfo - Do not show caller info in log lines for given log level
 func HideCallerInfo(module string, level loglevel.Level) {
-	rwmutex.Lock()
-	defer rwmutex.Unlock()
-	callerInfos.HideCallerInfo(module, level)
+	defaultLevels.HideCallerInfo(module, level)
 }
 
-//getLoggerOpts - returns LoggerOpts which can be used for customization
+//getLoggerOpts - returns LoggerOpts of the process-wide levels
 func getLoggerOpts(module string, level loglevel.Level) *loggerOpts {
-	rwmutex.RLock()
-	defer rwmutex.RUnlock()
-	return &loggerOpts{
-		levelEnabled:      moduleLevels.IsEnabledFor(module, level),
-		callerInfoEnabled: callerInfos.IsCallerInfoEnabled(module, level),
-	}
+	return defaultLevels.loggerOpts(module, level)
 }
 
 // Fatal is CRITICAL log followed by a call to os.Exit(1).
 func (l *Log) Fatal(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.CRITICAL)
+	opts := l.levels.loggerOpts(l.module, loglevel.CRITICAL)
 	if l.loadCustomLogger() {
 		l.customLogger.Fatal(args...)
 		return
@@ -137,7 +163,7 @@ func (l *Log) Fatal(args ...interface{}) {
 
 // Fatalf is CRITICAL log formatted followed by a call to os.Exit(1).
 func (l *Log) Fatalf(format string, args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.CRITICAL)
+	opts := l.levels.loggerOpts(l.module, loglevel.CRITICAL)
 	if l.loadCustomLogger() {
 		l.customLogger.Fatalf(format, args...)
 		return
@@ -148,7 +174,7 @@ func (l *Log) Fatalf(format string, args ...interface{}) {
 
 // Fatalln is CRITICAL log ln followed by a call to os.Exit(1).
 func (l *Log) Fatalln(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.CRITICAL)
+	opts := l.levels.loggerOpts(l.module, loglevel.CRITICAL)
 	if l.loadCustomLogger() {
 		l.customLogger.Fatalln(args...)
 		return
@@ -159,7 +185,7 @@ func (l *Log) Fatalln(args ...interface{}) {
 
 // Panic is CRITICAL log followed by a call to panic()
 func (l *Log) Panic(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.CRITICAL)
+	opts := l.levels.loggerOpts(l.module, loglevel.CRITICAL)
 	if l.loadCustomLogger() {
 		l.customLogger.Panic(args...)
 		return
@@ -170,7 +196,7 @@ func (l *Log) Panic(args ...interface{}) {
 
 // Panicf is CRITICAL log formatted followed by a call to panic()
 func (l *Log) Panicf(format string, args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.CRITICAL)
+	opts := l.levels.loggerOpts(l.module, loglevel.CRITICAL)
 	if l.loadCustomLogger() {
 		l.customLogger.Panicf(format, args...)
 		return
@@ -181,7 +207,7 @@ func (l *Log) Panicf(format string, args ...interface{}) {
 
 // Panicln is CRITICAL log ln followed by a call to panic()
 func (l *Log) Panicln(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.CRITICAL)
+	opts := l.levels.loggerOpts(l.module, loglevel.CRITICAL)
 	if l.loadCustomLogger() {
 		l.customLogger.Panicln(args...)
 		return
@@ -223,7 +249,7 @@ func (l *Log) Println(args ...interface{}) {
 // Debug calls go log.Output.
 // Arguments are handled in the manner of fmt.Print.
 func (l *Log) Debug(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.DEBUG)
+	opts := l.levels.loggerOpts(l.module, loglevel.DEBUG)
 	if !opts.levelEnabled {
 		return
 	}
@@ -237,7 +263,7 @@ func (l *Log) Debug(args ...interface{}) {
 // Debugf calls go log.Output.
 // Arguments are handled in the manner of fmt.Printf.
 func (l *Log) Debugf(format string, args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.DEBUG)
+	opts := l.levels.loggerOpts(l.module, loglevel.DEBUG)
 	if !opts.levelEnabled {
 		return
 	}
@@ -251,7 +277,7 @@ func (l *Log) Debugf(format string, args ...interface{}) {
 // Debugln calls go log.Output.
 // Arguments are handled in the manner of fmt.Println.
 func (l *Log) Debugln(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.DEBUG)
+	opts := l.levels.loggerOpts(l.module, loglevel.DEBUG)
 	if !opts.levelEnabled {
 		return
 	}
@@ -265,7 +291,7 @@ func (l *Log) Debugln(args ...interface{}) {
 // Info calls go log.Output.
 // Arguments are handled in the manner of fmt.Print.
 func (l *Log) Info(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.INFO)
+	opts := l.levels.loggerOpts(l.module, loglevel.INFO)
 	if !opts.levelEnabled {
 		return
 	}
@@ -279,7 +305,7 @@ func (l *Log) Info(args ...interface{}) {
 // Infof calls go log.Output.
 // Arguments are handled in the manner of fmt.Printf.
 func (l *Log) Infof(format string, args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.INFO)
+	opts := l.levels.loggerOpts(l.module, loglevel.INFO)
 	if !opts.levelEnabled {
 		return
 	}
@@ -293,7 +319,7 @@ func (l *Log) Infof(format string, args ...interface{}) {
 // Infoln calls go log.Output.
 // Arguments are handled in the manner of fmt.Println.
 func (l *Log) Infoln(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.INFO)
+	opts := l.levels.loggerOpts(l.module, loglevel.INFO)
 	if !opts.levelEnabled {
 		return
 	}
@@ -307,7 +333,7 @@ func (l *Log) Infoln(args ...interface{}) {
 // Warn calls go log.Output.
 // Arguments are handled in the manner of fmt.Print.
 func (l *Log) Warn(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.WARNING)
+	opts := l.levels.loggerOpts(l.module, loglevel.WARNING)
 	if !opts.levelEnabled {
 		return
 	}
@@ -321,7 +347,7 @@ func (l *Log) Warn(args ...interface{}) {
 // Warnf calls go log.Output.
 // Arguments are handled in the manner of fmt.Printf.
 func (l *Log) Warnf(format string, args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.WARNING)
+	opts := l.levels.loggerOpts(l.module, loglevel.WARNING)
 	if !opts.levelEnabled {
 		return
 	}
@@ -335,7 +361,7 @@ func (l *Log) Warnf(format string, args ...interface{}) {
 // Warnln calls go log.Output.
 // Arguments are handled in the manner of fmt.Println.
 func (l *Log) Warnln(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.WARNING)
+	opts := l.levels.loggerOpts(l.module, loglevel.WARNING)
 	if !opts.levelEnabled {
 		return
 	}
@@ -349,7 +375,7 @@ func (l *Log) Warnln(args ...interface{}) {
 // Error calls go log.Output.
 // Arguments are handled in the manner of fmt.Print.
 func (l *Log) Error(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.ERROR)
+	opts := l.levels.loggerOpts(l.module, loglevel.ERROR)
 	if !opts.levelEnabled {
 		return
 	}
@@ -363,7 +389,7 @@ func (l *Log) Error(args ...interface{}) {
 // Errorf calls go log.Output.
 // Arguments are handled in the manner of fmt.Printf.
 func (l *Log) Errorf(format string, args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.ERROR)
+	opts := l.levels.loggerOpts(l.module, loglevel.ERROR)
 	if !opts.levelEnabled {
 		return
 	}
@@ -377,7 +403,7 @@ func (l *Log) Errorf(format string, args ...interface{}) {
 // Errorln calls go log.Output.
 // Arguments are handled in the manner of fmt.Println.
 func (l *Log) Errorln(args ...interface{}) {
-	opts := getLoggerOpts(l.module, loglevel.ERROR)
+	opts := l.levels.loggerOpts(l.module, loglevel.ERROR)
 	if !opts.levelEnabled {
 		return
 	}
@@ -413,7 +439,7 @@ func (l *Log) logln(opts *loggerOpts, level loglevel.Level, args ...interface{})
 
 func (l *Log) loadCustomLogger() bool {
 	l.once.Do(func() {
-		if atomic.LoadInt32(&useCustomLogger) > 0 {
+		if !l.scoped && atomic.LoadInt32(&useCustomLogger) > 0 {
 			l.customLogger = loggerProviderInstance.GetLogger(l.module)
 			l.custom = true
 		}
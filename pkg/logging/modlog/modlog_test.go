@@ -85,7 +85,7 @@ func testDefaultLogging(t *testing.T) {
 	VerifyBasicLogging(t, loglevel.DEBUG, nil, logger.Debugf, &buf, false, moduleName)
 
 	//Reset module levels for next test
-	moduleLevels = &loglevel.ModuleLevels{}
+	defaultLevels = NewLevels()
 }
 
 func TestDefaultLoggingPanic(t *testing.T) {
@@ -0,0 +1,252 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+/*
+Package structured provides a logger provider that hands log lines to a Sink
+as a message plus key/value fields instead of a pre-formatted string, so that
+structured logging libraries such as zap or logrus can record the fields
+(channel, txID, endorser, ...) natively.
+
+A logrus bridge, for example, can be written as:
+
+	sink := structured.SinkFunc(func(module string, level loglevel.Level, msg string, kv []interface{}) {
+		entry := logrusLogger.WithFields(structured.FieldMap(kv)).WithField("module", module)
+		switch level {
+		case loglevel.DEBUG:
+			entry.Debug(msg)
+		...
+		}
+	})
+	sdk, err := fabsdk.New(configProvider, fabsdk.WithLoggerPkg(structured.NewProvider(sink)))
+
+Module levels are held by the provider (see Provider.SetLevel), so that
+providers of different SDK instances can log at different levels.
+*/
+package structured
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/modlog"
+)
+
+// Sink receives structured log lines
+type Sink interface {
+	// Log records msg at the given level. keysAndValues holds alternating
+	// field keys and values.
+	Log(module string, level loglevel.Level, msg string, keysAndValues []interface{})
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(module string, level loglevel.Level, msg string, keysAndValues []interface{})
+
+// Log calls f
+func (f SinkFunc) Log(module string, level loglevel.Level, msg string, keysAndValues []interface{}) {
+	f(module, level, msg, keysAndValues)
+}
+
+// Provider creates loggers writing to a Sink. It implements loglevel.Leveler:
+// each provider has its own module levels (all modules log at INFO level by default).
+type Provider struct {
+	sink   Sink
+	levels *modlog.Levels
+}
+
+// NewProvider returns a logger provider writing to sink
+func NewProvider(sink Sink) *Provider {
+	return &Provider{sink: sink, levels: modlog.NewLevels()}
+}
+
+// GetLogger returns a structured logger for the module
+func (p *Provider) GetLogger(module string) api.Logger {
+	return &Logger{sink: p.sink, levels: p.levels, module: module}
+}
+
+// SetLevel sets the log level of module for the loggers of this provider
+func (p *Provider) SetLevel(module string, level loglevel.Level) {
+	p.levels.SetLevel(module, level)
+}
+
+// GetLevel returns the log level of module for the loggers of this provider
+func (p *Provider) GetLevel(module string) loglevel.Level {
+	return p.levels.GetLevel(module)
+}
+
+// IsEnabledFor returns true if module logs at the given level with this provider
+func (p *Provider) IsEnabledFor(module string, level loglevel.Level) bool {
+	return p.levels.IsEnabledFor(module, level)
+}
+
+// FieldMap converts alternating keys and values into a map, as expected by
+// logrus.WithFields. A trailing key without value is mapped to nil.
+func FieldMap(keysAndValues []interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(keysAndValues)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		var v interface{}
+		if i+1 < len(keysAndValues) {
+			v = keysAndValues[i+1]
+		}
+		m[fmt.Sprint(keysAndValues[i])] = v
+	}
+	return m
+}
+
+// Logger implements api.StructuredLogger on top of a Sink
+type Logger struct {
+	sink   Sink
+	levels *modlog.Levels
+	module string
+	fields []interface{}
+}
+
+// With returns a child logger adding the given alternating keys and values
+// to every line it logs
+func (l *Logger) With(keysAndValues ...interface{}) api.StructuredLogger {
+	f := make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+	f = append(f, l.fields...)
+	f = append(f, keysAndValues...)
+	return &Logger{sink: l.sink, levels: l.levels, module: l.module, fields: f}
+}
+
+func (l *Logger) log(level loglevel.Level, msg string) {
+	l.sink.Log(l.module, level, msg, l.fields)
+}
+
+func (l *Logger) logIfEnabled(level loglevel.Level, msg func() string) {
+	if l.levels.IsEnabledFor(l.module, level) {
+		l.log(level, msg())
+	}
+}
+
+func sprint(args []interface{}) func() string {
+	return func() string { return fmt.Sprint(args...) }
+}
+
+func sprintf(format string, args []interface{}) func() string {
+	return func() string { return fmt.Sprintf(format, args...) }
+}
+
+func sprintln(args []interface{}) func() string {
+	return func() string { return strings.TrimSuffix(fmt.Sprintln(args...), "\n") }
+}
+
+// Fatal is CRITICAL log followed by a call to os.Exit(1).
+func (l *Logger) Fatal(args ...interface{}) {
+	l.log(loglevel.CRITICAL, sprint(args)())
+	os.Exit(1)
+}
+
+// Fatalf is CRITICAL log formatted followed by a call to os.Exit(1).
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.log(loglevel.CRITICAL, sprintf(format, args)())
+	os.Exit(1)
+}
+
+// Fatalln is CRITICAL log ln followed by a call to os.Exit(1).
+func (l *Logger) Fatalln(args ...interface{}) {
+	l.log(loglevel.CRITICAL, sprintln(args)())
+	os.Exit(1)
+}
+
+// Panic is CRITICAL log followed by a call to panic()
+func (l *Logger) Panic(args ...interface{}) {
+	msg := sprint(args)()
+	l.log(loglevel.CRITICAL, msg)
+	panic(msg)
+}
+
+// Panicf is CRITICAL log formatted followed by a call to panic()
+func (l *Logger) Panicf(format string, args ...interface{}) {
+	msg := sprintf(format, args)()
+	l.log(loglevel.CRITICAL, msg)
+	panic(msg)
+}
+
+// Panicln is CRITICAL log ln followed by a call to panic()
+func (l *Logger) Panicln(args ...interface{}) {
+	msg := sprintln(args)()
+	l.log(loglevel.CRITICAL, msg)
+	panic(msg)
+}
+
+// Print logs at INFO level regardless of the module level
+func (l *Logger) Print(args ...interface{}) {
+	l.log(loglevel.INFO, sprint(args)())
+}
+
+// Printf logs at INFO level regardless of the module level
+func (l *Logger) Printf(format string, args ...interface{}) {
+	l.log(loglevel.INFO, sprintf(format, args)())
+}
+
+// Println logs at INFO level regardless of the module level
+func (l *Logger) Println(args ...interface{}) {
+	l.log(loglevel.INFO, sprintln(args)())
+}
+
+// Debug logs at DEBUG level
+func (l *Logger) Debug(args ...interface{}) {
+	l.logIfEnabled(loglevel.DEBUG, sprint(args))
+}
+
+// Debugf logs at DEBUG level
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.logIfEnabled(loglevel.DEBUG, sprintf(format, args))
+}
+
+// Debugln logs at DEBUG level
+func (l *Logger) Debugln(args ...interface{}) {
+	l.logIfEnabled(loglevel.DEBUG, sprintln(args))
+}
+
+// Info logs at INFO level
+func (l *Logger) Info(args ...interface{}) {
+	l.logIfEnabled(loglevel.INFO, sprint(args))
+}
+
+// Infof logs at INFO level
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.logIfEnabled(loglevel.INFO, sprintf(format, args))
+}
+
+// Infoln logs at INFO level
+func (l *Logger) Infoln(args ...interface{}) {
+	l.logIfEnabled(loglevel.INFO, sprintln(args))
+}
+
+// Warn logs at WARNING level
+func (l *Logger) Warn(args ...interface{}) {
+	l.logIfEnabled(loglevel.WARNING, sprint(args))
+}
+
+// Warnf logs at WARNING level
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.logIfEnabled(loglevel.WARNING, sprintf(format, args))
+}
+
+// Warnln logs at WARNING level
+func (l *Logger) Warnln(args ...interface{}) {
+	l.logIfEnabled(loglevel.WARNING, sprintln(args))
+}
+
+// Error logs at ERROR level
+func (l *Logger) Error(args ...interface{}) {
+	l.logIfEnabled(loglevel.ERROR, sprint(args))
+}
+
+// Errorf logs at ERROR level
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.logIfEnabled(loglevel.ERROR, sprintf(format, args))
+}
+
+// Errorln logs at ERROR level
+func (l *Logger) Errorln(args ...interface{}) {
+	l.logIfEnabled(loglevel.ERROR, sprintln(args))
+}
@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package structured
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
+)
+
+type entry struct {
+	module string
+	level  loglevel.Level
+	msg    string
+	kv     []interface{}
+}
+
+type recordingSink struct {
+	entries []entry
+}
+
+func (s *recordingSink) Log(module string, level loglevel.Level, msg string, keysAndValues []interface{}) {
+	s.entries = append(s.entries, entry{module: module, level: level, msg: msg, kv: keysAndValues})
+}
+
+func TestStructuredLogger(t *testing.T) {
+	const module = "structured-test"
+
+	sink := &recordingSink{}
+	logger := NewProvider(sink).GetLogger(module)
+
+	structuredLogger, ok := logger.(api.StructuredLogger)
+	if !ok {
+		t.Fatal("expected logger to implement api.StructuredLogger")
+	}
+	txLogger := structuredLogger.With("channel", "mychannel").With("txID", "tx1")
+
+	txLogger.Debugf("not %s", "logged")
+	txLogger.Infof("sent to %d peers", 2)
+	structuredLogger.Warn("no fields")
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(sink.entries))
+	}
+	e := sink.entries[0]
+	if e.module != module || e.level != loglevel.INFO || e.msg != "sent to 2 peers" {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+	if fmt.Sprint(e.kv) != "[channel mychannel txID tx1]" {
+		t.Fatalf("unexpected fields %v", e.kv)
+	}
+	if len(sink.entries[1].kv) != 0 {
+		t.Fatalf("parent logger must not inherit child fields: %v", sink.entries[1].kv)
+	}
+}
+
+func TestStructuredLoggerLevels(t *testing.T) {
+	const module = "structured-test"
+
+	sink := &recordingSink{}
+	provider := NewProvider(sink)
+	provider.SetLevel(module, loglevel.DEBUG)
+	otherSink := &recordingSink{}
+	otherProvider := NewProvider(otherSink)
+	otherProvider.SetLevel(module, loglevel.WARNING)
+
+	var _ loglevel.Leveler = provider
+
+	provider.GetLogger(module).Debug("debug")
+	otherProvider.GetLogger(module).Info("info")
+	otherProvider.GetLogger(module).(api.StructuredLogger).With("txID", "tx1").Warn("warn")
+
+	if len(sink.entries) != 1 || sink.entries[0].msg != "debug" {
+		t.Fatalf("expected DEBUG entry, got %+v", sink.entries)
+	}
+	if len(otherSink.entries) != 1 || otherSink.entries[0].msg != "warn" {
+		t.Fatalf("expected only the WARNING entry, got %+v", otherSink.entries)
+	}
+	if provider.GetLevel(module) != loglevel.DEBUG || otherProvider.IsEnabledFor(module, loglevel.INFO) {
+		t.Fatalf("unexpected provider levels")
+	}
+}
+
+func TestStructuredLoggerPanic(t *testing.T) {
+	sink := &recordingSink{}
+	logger := NewProvider(sink).GetLogger("structured-test")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic")
+		}
+		if len(sink.entries) != 1 || sink.entries[0].level != loglevel.CRITICAL {
+			t.Fatalf("expected CRITICAL entry before panic, got %+v", sink.entries)
+		}
+	}()
+	logger.Panicf("bad %s", "state")
+}
+
+type zapRecorder struct {
+	level string
+	msg   string
+	kv    []interface{}
+}
+
+func (z *zapRecorder) Debugw(msg string, kv ...interface{}) { z.level, z.msg, z.kv = "debug", msg, kv }
+func (z *zapRecorder) Infow(msg string, kv ...interface{})  { z.level, z.msg, z.kv = "info", msg, kv }
+func (z *zapRecorder) Warnw(msg string, kv ...interface{})  { z.level, z.msg, z.kv = "warn", msg, kv }
+func (z *zapRecorder) Errorw(msg string, kv ...interface{}) { z.level, z.msg, z.kv = "error", msg, kv }
+
+func TestZapSink(t *testing.T) {
+	z := &zapRecorder{}
+	ZapSink(z).Log("mod", loglevel.WARNING, "hello", []interface{}{"txID", "tx1"})
+	if z.level != "warn" || z.msg != "hello" || fmt.Sprint(z.kv) != "[module mod txID tx1]" {
+		t.Fatalf("unexpected zap call %+v", z)
+	}
+}
+
+func TestFieldMap(t *testing.T) {
+	m := FieldMap([]interface{}{"a", 1, "b"})
+	if len(m) != 2 || m["a"] != 1 || m["b"] != nil {
+		t.Fatalf("unexpected field map %v", m)
+	}
+}
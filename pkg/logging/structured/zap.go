@@ -0,0 +1,43 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package structured
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/logging/loglevel"
+)
+
+// SugaredLogger is the subset of *zap.SugaredLogger used by ZapSink
+type SugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// ZapSink returns a Sink writing to a zap sugared logger, e.g.
+//
+//	logging.InitLogger(structured.NewProvider(structured.ZapSink(zapLogger.Sugar())))
+//
+// The module name is added as the "module" field. CRITICAL lines are logged
+// with Errorw; the Fatal and Panic behaviour is handled by the SDK logger.
+func ZapSink(logger SugaredLogger) Sink {
+	return SinkFunc(func(module string, level loglevel.Level, msg string, keysAndValues []interface{}) {
+		kv := make([]interface{}, 0, len(keysAndValues)+2)
+		kv = append(kv, "module", module)
+		kv = append(kv, keysAndValues...)
+		switch level {
+		case loglevel.DEBUG:
+			logger.Debugw(msg, kv...)
+		case loglevel.INFO:
+			logger.Infow(msg, kv...)
+		case loglevel.WARNING:
+			logger.Warnw(msg, kv...)
+		default:
+			logger.Errorw(msg, kv...)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+// Provider creates metrics. Implementations must return the same metric when
+// asked twice for the same fully-qualified name, so that several clients may
+// share a provider.
+type Provider interface {
+	NewCounter(opts CounterOpts) Counter
+	NewGauge(opts GaugeOpts) Gauge
+	NewHistogram(opts HistogramOpts) Histogram
+}
+
+// Counter is a monotonically increasing value
+type Counter interface {
+	// With returns the counter for the given alternating label names and values
+	With(labelValues ...string) Counter
+	Add(delta float64)
+}
+
+// Gauge is a value that can go up and down
+type Gauge interface {
+	// With returns the gauge for the given alternating label names and values
+	With(labelValues ...string) Gauge
+	Add(delta float64)
+	Set(value float64)
+}
+
+// Histogram samples observations (e.g. durations in seconds) into buckets
+type Histogram interface {
+	// With returns the histogram for the given alternating label names and values
+	With(labelValues ...string) Histogram
+	Observe(value float64)
+}
+
+// CounterOpts describes a counter. The fully-qualified name is
+// Namespace_Subsystem_Name, omitting empty parts.
+type CounterOpts struct {
+	Namespace  string
+	Subsystem  string
+	Name       string
+	Help       string
+	LabelNames []string
+}
+
+// GaugeOpts describes a gauge
+type GaugeOpts struct {
+	Namespace  string
+	Subsystem  string
+	Name       string
+	Help       string
+	LabelNames []string
+}
+
+// HistogramOpts describes a histogram. Buckets are upper bounds in increasing order.
+type HistogramOpts struct {
+	Namespace  string
+	Subsystem  string
+	Name       string
+	Help       string
+	LabelNames []string
+	Buckets    []float64
+}
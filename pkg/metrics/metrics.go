@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics defines the metrics recorded by the SDK. Metrics are
+// created from the metrics provider injected with fabsdk.WithMetricsProvider;
+// without a provider nothing is recorded.
+package metrics
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics/disabled"
+)
+
+const namespace = "fabric_sdk"
+
+// Label names
+const (
+	ChannelLabel   = "channel"
+	ChaincodeLabel = "chaincode"
+	PeerLabel      = "peer"
+	OrdererLabel   = "orderer"
+	CodeLabel      = "code"
+)
+
+// durationBuckets are the buckets of the duration histograms (seconds)
+var durationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+// Metrics holds the metrics recorded by the SDK
+type Metrics struct {
+	// EndorsementDuration is the time taken by a peer to endorse a proposal
+	EndorsementDuration api.Histogram
+	// EndorsementErrors counts failed or rejected endorsements per peer
+	EndorsementErrors api.Counter
+	// BroadcastDuration is the time taken to broadcast a transaction to the orderer
+	BroadcastDuration api.Histogram
+	// CommitDuration is the time from broadcast until the transaction is committed
+	CommitDuration api.Histogram
+	// ValidationCodes counts committed transactions per validation code
+	ValidationCodes api.Counter
+	// Retries counts the retries of channel client requests
+	Retries api.Counter
+	// GreylistSize is the number of peers currently greylisted
+	GreylistSize api.Gauge
+	// EventReconnects counts the reconnections of event clients
+	EventReconnects api.Counter
+	// EventLag is the delay between a block's creation and its delivery to the event client
+	EventLag api.Histogram
+}
+
+// New creates the SDK metrics from provider. If provider is nil the metrics
+// are no-ops.
+func New(provider api.Provider) *Metrics {
+	if provider == nil {
+		provider = disabled.NewProvider()
+	}
+	return &Metrics{
+		EndorsementDuration: provider.NewHistogram(api.HistogramOpts{
+			Namespace:  namespace,
+			Subsystem:  "endorser",
+			Name:       "proposal_duration_seconds",
+			Help:       "The time taken by a peer to endorse a proposal.",
+			LabelNames: []string{ChannelLabel, ChaincodeLabel, PeerLabel},
+			Buckets:    durationBuckets,
+		}),
+		EndorsementErrors: provider.NewCounter(api.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "endorser",
+			Name:       "proposal_errors_total",
+			Help:       "The number of failed or rejected endorsements.",
+			LabelNames: []string{ChannelLabel, ChaincodeLabel, PeerLabel},
+		}),
+		BroadcastDuration: provider.NewHistogram(api.HistogramOpts{
+			Namespace:  namespace,
+			Subsystem:  "orderer",
+			Name:       "broadcast_duration_seconds",
+			Help:       "The time taken to broadcast a transaction to the orderer.",
+			LabelNames: []string{ChannelLabel, ChaincodeLabel, OrdererLabel},
+			Buckets:    durationBuckets,
+		}),
+		CommitDuration: provider.NewHistogram(api.HistogramOpts{
+			Namespace:  namespace,
+			Subsystem:  "transaction",
+			Name:       "commit_duration_seconds",
+			Help:       "The time from broadcast until the transaction is committed.",
+			LabelNames: []string{ChannelLabel, ChaincodeLabel},
+			Buckets:    durationBuckets,
+		}),
+		ValidationCodes: provider.NewCounter(api.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "transaction",
+			Name:       "validation_codes_total",
+			Help:       "The number of committed transactions per validation code.",
+			LabelNames: []string{ChannelLabel, ChaincodeLabel, CodeLabel},
+		}),
+		Retries: provider.NewCounter(api.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "channel",
+			Name:       "retries_total",
+			Help:       "The number of retried channel client requests.",
+			LabelNames: []string{ChannelLabel, ChaincodeLabel},
+		}),
+		GreylistSize: provider.NewGauge(api.GaugeOpts{
+			Namespace:  namespace,
+			Subsystem:  "channel",
+			Name:       "greylist_size",
+			Help:       "The number of greylisted peers.",
+			LabelNames: []string{ChannelLabel},
+		}),
+		EventReconnects: provider.NewCounter(api.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "events",
+			Name:       "reconnects_total",
+			Help:       "The number of event client reconnections.",
+			LabelNames: []string{ChannelLabel},
+		}),
+		EventLag: provider.NewHistogram(api.HistogramOpts{
+			Namespace:  namespace,
+			Subsystem:  "events",
+			Name:       "block_lag_seconds",
+			Help:       "The delay between the creation of a block's first transaction and its delivery.",
+			LabelNames: []string{ChannelLabel},
+			Buckets:    durationBuckets,
+		}),
+	}
+}
@@ -0,0 +1,346 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+/*
+Package prometheus provides a metrics provider that keeps metrics in memory and
+exposes them in the Prometheus text exposition format, so that they can be
+scraped by a Prometheus server:
+
+	provider := prometheus.NewProvider()
+	sdk, err := fabsdk.New(configProvider, fabsdk.WithMetricsProvider(provider))
+	...
+	http.Handle("/metrics", provider)
+*/
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+)
+
+// DefaultBuckets are the histogram buckets used when none are given (seconds)
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+
+	// unknownLabelValue is used for a label name passed to With without a value
+	unknownLabelValue = "unknown"
+	contentType       = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Provider creates metrics and serves them over HTTP in the Prometheus text format
+type Provider struct {
+	mutex    sync.RWMutex
+	families map[string]*family
+}
+
+// NewProvider returns a new, empty provider
+func NewProvider() *Provider {
+	return &Provider{families: make(map[string]*family)}
+}
+
+// NewCounter returns the counter with the given name, creating it if needed
+func (p *Provider) NewCounter(opts api.CounterOpts) api.Counter {
+	f := p.family(counterType, fullName(opts.Namespace, opts.Subsystem, opts.Name), opts.Help, opts.LabelNames, nil)
+	return &counter{labels: newLabels(f)}
+}
+
+// NewGauge returns the gauge with the given name, creating it if needed
+func (p *Provider) NewGauge(opts api.GaugeOpts) api.Gauge {
+	f := p.family(gaugeType, fullName(opts.Namespace, opts.Subsystem, opts.Name), opts.Help, opts.LabelNames, nil)
+	return &gauge{labels: newLabels(f)}
+}
+
+// NewHistogram returns the histogram with the given name, creating it if needed
+func (p *Provider) NewHistogram(opts api.HistogramOpts) api.Histogram {
+	buckets := opts.Buckets
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	f := p.family(histogramType, fullName(opts.Namespace, opts.Subsystem, opts.Name), opts.Help, opts.LabelNames, buckets)
+	return &histogram{labels: newLabels(f)}
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format
+func (p *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	p.WriteTo(w)
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format
+func (p *Provider) WriteTo(w io.Writer) (int64, error) {
+	p.mutex.RLock()
+	names := make([]string, 0, len(p.families))
+	for name := range p.families {
+		names = append(names, name)
+	}
+	families := make([]*family, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		families = append(families, p.families[name])
+	}
+	p.mutex.RUnlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range families {
+		f.write(cw)
+	}
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+func (p *Provider) family(typ, name, help string, labelNames []string, buckets []float64) *family {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if f, ok := p.families[name]; ok {
+		if f.typ != typ {
+			panic(fmt.Sprintf("metric %s already registered as %s", name, f.typ))
+		}
+		return f
+	}
+	f := &family{
+		name:       name,
+		help:       help,
+		typ:        typ,
+		labelNames: append([]string(nil), labelNames...),
+		buckets:    append([]float64(nil), buckets...),
+		series:     make(map[string]*series),
+	}
+	p.families[name] = f
+	return f
+}
+
+func fullName(namespace, subsystem, name string) string {
+	var parts []string
+	for _, part := range []string{namespace, subsystem, name} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// family holds all the series (label value combinations) of a metric
+type family struct {
+	name       string
+	help       string
+	typ        string
+	labelNames []string
+	buckets    []float64
+
+	mutex  sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	// histogram only
+	bucketCounts []uint64
+	sum          float64
+	count        uint64
+}
+
+// update applies fn to the series with the given label values
+func (f *family) update(labelValues []string, fn func(s *series)) {
+	key := strings.Join(labelValues, "\xff")
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: labelValues}
+		if f.typ == histogramType {
+			s.bucketCounts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	fn(s)
+}
+
+func (f *family) write(w io.Writer) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.help != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.typ)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		if f.typ != histogramType {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, f.labelString(s.labelValues, ""), formatFloat(s.value))
+			continue
+		}
+		var cumulative uint64
+		for i, upperBound := range f.buckets {
+			cumulative += s.bucketCounts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, f.labelString(s.labelValues, formatFloat(upperBound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, f.labelString(s.labelValues, "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, f.labelString(s.labelValues, ""), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, f.labelString(s.labelValues, ""), s.count)
+	}
+}
+
+// labelString renders {name="value",...}, adding the le label of histogram buckets if given
+func (f *family) labelString(labelValues []string, le string) string {
+	var pairs []string
+	for i, name := range f.labelNames {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", name, escapeLabelValue(labelValues[i])))
+	}
+	if le != "" {
+		pairs = append(pairs, fmt.Sprintf("le=\"%s\"", le))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// labels holds the label values bound to a metric by With
+type labels struct {
+	family *family
+	values []string
+}
+
+func newLabels(f *family) labels {
+	return labels{family: f, values: make([]string, len(f.labelNames))}
+}
+
+// with returns a copy of l with the given alternating label names and values set.
+// Label names that the metric doesn't declare are ignored.
+func (l labels) with(labelValues []string) labels {
+	values := append([]string(nil), l.values...)
+	for i := 0; i < len(labelValues); i += 2 {
+		value := unknownLabelValue
+		if i+1 < len(labelValues) {
+			value = labelValues[i+1]
+		}
+		for j, name := range l.family.labelNames {
+			if name == labelValues[i] {
+				values[j] = value
+			}
+		}
+	}
+	return labels{family: l.family, values: values}
+}
+
+type counter struct {
+	labels
+}
+
+func (c *counter) With(labelValues ...string) api.Counter {
+	return &counter{labels: c.with(labelValues)}
+}
+
+// Add increases the counter. Negative deltas are ignored.
+func (c *counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.family.update(c.values, func(s *series) { s.value += delta })
+}
+
+type gauge struct {
+	labels
+}
+
+func (g *gauge) With(labelValues ...string) api.Gauge {
+	return &gauge{labels: g.with(labelValues)}
+}
+
+func (g *gauge) Add(delta float64) {
+	g.family.update(g.values, func(s *series) { s.value += delta })
+}
+
+func (g *gauge) Set(value float64) {
+	g.family.update(g.values, func(s *series) { s.value = value })
+}
+
+type histogram struct {
+	labels
+}
+
+func (h *histogram) With(labelValues ...string) api.Histogram {
+	return &histogram{labels: h.with(labelValues)}
+}
+
+func (h *histogram) Observe(value float64) {
+	h.family.update(h.values, func(s *series) {
+		for i, upperBound := range h.family.buckets {
+			if value <= upperBound {
+				s.bucketCounts[i]++
+				break
+			}
+		}
+		s.sum += value
+		s.count++
+	})
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
+
+// countingWriter records the number of bytes written and the first error
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
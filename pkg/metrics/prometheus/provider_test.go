@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prometheus
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/metrics/api"
+)
+
+func TestCounterAndGauge(t *testing.T) {
+	p := NewProvider()
+
+	c := p.NewCounter(api.CounterOpts{Namespace: "fabric_sdk", Subsystem: "endorser", Name: "errors_total", Help: "Endorsement errors.", LabelNames: []string{"channel", "peer"}})
+	c.With("channel", "mychannel", "peer", "peer0").Add(1)
+	c.With("channel", "mychannel").With("peer", "peer0").Add(2)
+	c.With("channel", "mychannel", "peer", "peer1").Add(-1)
+	c.With("channel", "mychannel", "peer", `a"b`).Add(1)
+
+	g := p.NewGauge(api.GaugeOpts{Name: "greylist_size"})
+	g.Set(3)
+	g.Add(-1)
+
+	expected := `# HELP fabric_sdk_endorser_errors_total Endorsement errors.
+# TYPE fabric_sdk_endorser_errors_total counter
+fabric_sdk_endorser_errors_total{channel="mychannel",peer="a\"b"} 1
+fabric_sdk_endorser_errors_total{channel="mychannel",peer="peer0"} 3
+# TYPE greylist_size gauge
+greylist_size 2
+`
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %s", err)
+	}
+	if buf.String() != expected {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	p := NewProvider()
+
+	h := p.NewHistogram(api.HistogramOpts{Name: "duration_seconds", Buckets: []float64{0.1, 1}, LabelNames: []string{"channel"}})
+	h.With("channel", "ch1").Observe(0.05)
+	h.With("channel", "ch1").Observe(0.5)
+	h.With("channel", "ch1").Observe(2)
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("unexpected content type %s", rec.Header().Get("Content-Type"))
+	}
+	expected := `# TYPE duration_seconds histogram
+duration_seconds_bucket{channel="ch1",le="0.1"} 1
+duration_seconds_bucket{channel="ch1",le="1"} 2
+duration_seconds_bucket{channel="ch1",le="+Inf"} 3
+duration_seconds_sum{channel="ch1"} 2.55
+duration_seconds_count{channel="ch1"} 3
+`
+	if rec.Body.String() != expected {
+		t.Fatalf("unexpected output:\n%s", rec.Body.String())
+	}
+}
+
+func TestRegistration(t *testing.T) {
+	p := NewProvider()
+
+	opts := api.CounterOpts{Name: "requests_total"}
+	p.NewCounter(opts).Add(1)
+	p.NewCounter(opts).Add(1)
+
+	var buf bytes.Buffer
+	p.WriteTo(&buf)
+	if !strings.Contains(buf.String(), "requests_total 2") {
+		t.Fatalf("expected metric to be shared between registrations, got:\n%s", buf.String())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic registering a gauge with a counter's name")
+		}
+	}()
+	p.NewGauge(api.GaugeOpts{Name: "requests_total"})
+}
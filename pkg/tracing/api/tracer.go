@@ -0,0 +1,29 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+)
+
+// Tracer starts spans. It is modelled on the OpenTracing and OpenTelemetry
+// tracers so that either can be adapted with a few lines of code.
+type Tracer interface {
+	// StartSpan starts a span that is a child of the span carried by ctx, if
+	// any, and returns the span together with a context carrying it.
+	StartSpan(ctx context.Context, operationName string) (Span, context.Context)
+}
+
+// Span is a timed operation
+type Span interface {
+	// SetTag sets an attribute of the span
+	SetTag(key string, value interface{})
+	// SetError marks the span as failed
+	SetError(err error)
+	// Finish ends the span
+	Finish()
+}
@@ -0,0 +1,154 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package recorder provides a tracer that keeps finished spans in memory,
+// for use in tests.
+package recorder
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
+)
+
+type spanKey struct{}
+
+// Tracer records spans in memory
+type Tracer struct {
+	mutex    sync.Mutex
+	nextID   uint64
+	finished []*Span
+}
+
+// New returns a new recording tracer
+func New() *Tracer {
+	return &Tracer{}
+}
+
+// StartSpan starts a span that is a child of the recorder span carried by ctx, if any
+func (t *Tracer) StartSpan(ctx context.Context, operationName string) (api.Span, context.Context) {
+	t.mutex.Lock()
+	t.nextID++
+	span := &Span{
+		tracer:        t,
+		ID:            t.nextID,
+		OperationName: operationName,
+		Start:         time.Now(),
+		tags:          make(map[string]interface{}),
+	}
+	t.mutex.Unlock()
+
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok {
+		span.ParentID = parent.ID
+	}
+	return span, context.WithValue(ctx, spanKey{}, span)
+}
+
+// Spans returns the finished spans in the order they were finished
+func (t *Tracer) Spans() []*Span {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]*Span(nil), t.finished...)
+}
+
+// SpansByName returns the finished spans with the given operation name
+func (t *Tracer) SpansByName(operationName string) []*Span {
+	var spans []*Span
+	for _, span := range t.Spans() {
+		if span.OperationName == operationName {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Reset discards all finished spans
+func (t *Tracer) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.finished = nil
+}
+
+// Span is a recorded span
+type Span struct {
+	tracer *Tracer
+
+	ID            uint64
+	ParentID      uint64 // 0 if the span has no parent
+	OperationName string
+	Start         time.Time
+
+	mutex    sync.Mutex
+	tags     map[string]interface{}
+	err      error
+	end      time.Time
+	finished bool
+}
+
+// SetTag sets a tag of the span
+func (s *Span) SetTag(key string, value interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tags[key] = value
+}
+
+// SetError marks the span as failed
+func (s *Span) SetError(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.err = err
+}
+
+// Finish ends the span. Only the first call has an effect.
+func (s *Span) Finish() {
+	s.mutex.Lock()
+	if s.finished {
+		s.mutex.Unlock()
+		return
+	}
+	s.finished = true
+	s.end = time.Now()
+	s.mutex.Unlock()
+
+	s.tracer.mutex.Lock()
+	s.tracer.finished = append(s.tracer.finished, s)
+	s.tracer.mutex.Unlock()
+}
+
+// Tag returns the value of a tag
+func (s *Span) Tag(key string) (interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := s.tags[key]
+	return value, ok
+}
+
+// Tags returns a copy of the span's tags
+func (s *Span) Tags() map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tags := make(map[string]interface{}, len(s.tags))
+	for k, v := range s.tags {
+		tags[k] = v
+	}
+	return tags
+}
+
+// Err returns the error the span was marked with, if any
+func (s *Span) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
+}
+
+// Duration returns the duration of a finished span
+func (s *Span) Duration() time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.end.Sub(s.Start)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package recorder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing"
+)
+
+func TestRecorder(t *testing.T) {
+	tracer := New()
+
+	parent, ctx := tracer.StartSpan(context.Background(), "parent")
+	child, _ := tracing.StartSpan(ctx, tracer, "child")
+	child.SetTag("key", "value")
+	tracing.FinishSpan(child, errors.New("failed"))
+	tracing.FinishSpan(parent, nil)
+	parent.Finish()
+
+	spans := tracer.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 finished spans, got %d", len(spans))
+	}
+	c, p := spans[0], spans[1]
+	if c.OperationName != "child" || p.OperationName != "parent" {
+		t.Fatalf("unexpected spans %s, %s", c.OperationName, p.OperationName)
+	}
+	if c.ParentID != p.ID || p.ParentID != 0 {
+		t.Fatalf("unexpected parent IDs %d, %d", c.ParentID, p.ParentID)
+	}
+	if v, ok := c.Tag("key"); !ok || v != "value" {
+		t.Fatalf("unexpected tag %v", v)
+	}
+	if c.Err() == nil || p.Err() != nil {
+		t.Fatal("expected only the child span to be failed")
+	}
+
+	tracer.Reset()
+	if len(tracer.Spans()) != 0 {
+		t.Fatal("expected no spans after reset")
+	}
+}
+
+func TestDisabledTracing(t *testing.T) {
+	span, ctx := tracing.StartSpan(nil, nil, "noop")
+	if ctx == nil {
+		t.Fatal("expected background context")
+	}
+	span.SetTag("key", "value")
+	tracing.FinishSpan(span, errors.New("ignored"))
+}
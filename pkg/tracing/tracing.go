@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+/*
+Package tracing defines the spans created by the SDK over a transaction's
+lifecycle. Spans are created by the tracer injected with fabsdk.WithTracer;
+without a tracer nothing is recorded.
+
+A caller traces a request end-to-end by passing its own context, carrying
+the caller's span, to the channel client:
+
+	response, err := chClient.Execute(request, channel.WithParentContext(ctx))
+
+An OpenTracing tracer can be adapted as follows:
+
+	type otTracer struct{ tracer opentracing.Tracer }
+
+	func (t *otTracer) StartSpan(ctx context.Context, name string) (api.Span, context.Context) {
+		span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, t.tracer, name)
+		return &otSpan{span}, ctx
+	}
+*/
+package tracing
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/tracing/api"
+)
+
+// Span operation names
+const (
+	InvokeOperation              = "fabric.invoke"
+	SelectionOperation           = "fabric.selection"
+	EndorsementOperation         = "fabric.endorsement"
+	EndorseOperation             = "fabric.endorse"
+	EndorsementValidateOperation = "fabric.validation"
+	SignatureValidateOperation   = "fabric.signature_validation"
+	BroadcastOperation           = "fabric.broadcast"
+	CommitOperation              = "fabric.commit_wait"
+)
+
+// Span tag keys
+const (
+	ChannelTag        = "fabric.channel"
+	ChaincodeTag      = "fabric.chaincode"
+	FcnTag            = "fabric.fcn"
+	TxIDTag           = "fabric.txid"
+	PeerTag           = "peer.address"
+	PeersTag          = "fabric.peers"
+	OrdererTag        = "fabric.orderer"
+	ValidationCodeTag = "fabric.validation_code"
+	StatusTag         = "fabric.status"
+)
+
+// StartSpan starts a span with tracer, which may be nil to disable tracing.
+// A nil ctx is treated as context.Background().
+func StartSpan(ctx context.Context, tracer api.Tracer, operationName string) (api.Span, context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if tracer == nil {
+		return noopSpan{}, ctx
+	}
+	return tracer.StartSpan(ctx, operationName)
+}
+
+// FinishSpan marks span as failed if err is not nil and finishes it
+func FinishSpan(span api.Span, err error) {
+	if err != nil {
+		span.SetError(err)
+	}
+	span.Finish()
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetTag(string, interface{}) {}
+func (noopSpan) SetError(error)             {}
+func (noopSpan) Finish()                    {}
@@ -66,6 +66,7 @@ declare -a FILES=(
     "bccsp/pkcs11/ecdsakey.go"
     "bccsp/pkcs11/impl.go"
     "bccsp/pkcs11/pkcs11.go"
+    "bccsp/pkcs11/sessions_test.go"
 
     "bccsp/signer/signer.go"
 
@@ -82,6 +83,7 @@ declare -a FILES=(
     "bccsp/sw/keyderiv.go"
     "bccsp/sw/keygen.go"
     "bccsp/sw/keyimport.go"
+    "bccsp/sw/kvks.go"
     "bccsp/sw/rsa.go"
     "bccsp/sw/rsakey.go"
 
@@ -25,7 +25,7 @@ func TestEnrollOrg2(t *testing.T) {
 		t.Fatalf("Failed getting cryptosuite from config : %s", err)
 	}
 
-	caClient, err := identitymgr.New(org2Name, testFabricConfig, cryptoSuiteProvider)
+	caClient, err := identitymgr.New(org2Name, testFabricConfig, cryptoSuiteProvider, nil)
 	if err != nil {
 		t.Fatalf("NewFabricCAClient return error: %v", err)
 	}
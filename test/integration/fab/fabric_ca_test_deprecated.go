@@ -52,7 +52,7 @@ func TestRegisterEnrollRevoke(t *testing.T) {
 	}
 	client.SetStateStore(stateStore)
 
-	idmgr, err := identitymgr.New(org1Name, testFabricConfig, cryptoSuiteProvider)
+	idmgr, err := identitymgr.New(org1Name, testFabricConfig, cryptoSuiteProvider, nil)
 	if err != nil {
 		t.Fatalf("NewFabricCAClient return error: %v", err)
 	}
@@ -123,7 +123,7 @@ func TestEnrollAndTransact(t *testing.T) {
 		t.Fatalf("Could not create signing manager: %s", err)
 	}
 
-	caClient, err := identitymgr.New(org1Name, testFabricConfig, cryptoSuiteProvider)
+	caClient, err := identitymgr.New(org1Name, testFabricConfig, cryptoSuiteProvider, nil)
 	if err != nil {
 		t.Fatalf("NewFabricCAClient returned error: %v", err)
 	}